@@ -27,6 +27,39 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// How plaintext is split into chunks before it is encrypted
+type Chunker int32
+
+const (
+	// Use the chunker configured on the server, which cuts fixed size chunks unless configured otherwise
+	Chunker_DEFAULT Chunker = 0
+	// Cut a chunk every ChunkSize bytes
+	Chunker_FIXED Chunker = 1
+	// Cut chunks where a rolling hash of the data matches so that chunk boundaries (and so deduplication of the chunks
+	// between them) survive insertions and deletions. Chunks are ChunkSize bytes on average.
+	Chunker_CONTENT_DEFINED Chunker = 2
+)
+
+var Chunker_name = map[int32]string{
+	0: "DEFAULT",
+	1: "FIXED",
+	2: "CONTENT_DEFINED",
+}
+
+var Chunker_value = map[string]int32{
+	"DEFAULT":         0,
+	"FIXED":           1,
+	"CONTENT_DEFINED": 2,
+}
+
+func (x Chunker) String() string {
+	return proto.EnumName(Chunker_name, int32(x))
+}
+
+func (Chunker) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+
 type GrantAndGrantSpec struct {
 	Grant *grant.Grant `protobuf:"bytes,1,opt,name=Grant,proto3" json:"Grant,omitempty"`
 	// The type of grant to output
@@ -74,6 +107,184 @@ func (m *GrantAndGrantSpec) GetGrantSpec() *grant.Spec {
 	return nil
 }
 
+type UnsealGetRangeRequest struct {
+	Grant *grant.Grant `protobuf:"bytes,1,opt,name=Grant,proto3" json:"Grant,omitempty"`
+	// The offset in bytes into the Plaintext body at which to start
+	Offset int64 `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// The number of bytes to return, if zero the remainder of the body is returned
+	Length               int64    `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsealGetRangeRequest) Reset()         { *m = UnsealGetRangeRequest{} }
+func (m *UnsealGetRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsealGetRangeRequest) ProtoMessage()    {}
+func (*UnsealGetRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+func (m *UnsealGetRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsealGetRangeRequest.Unmarshal(m, b)
+}
+func (m *UnsealGetRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsealGetRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *UnsealGetRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsealGetRangeRequest.Merge(m, src)
+}
+func (m *UnsealGetRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_UnsealGetRangeRequest.Size(m)
+}
+func (m *UnsealGetRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsealGetRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsealGetRangeRequest proto.InternalMessageInfo
+
+func (m *UnsealGetRangeRequest) GetGrant() *grant.Grant {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *UnsealGetRangeRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *UnsealGetRangeRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type AnnotateRequest struct {
+	Grant *grant.Grant `protobuf:"bytes,1,opt,name=Grant,proto3" json:"Grant,omitempty"`
+	// The Header with the metadata (Data) to set, other fields must be empty
+	Head                 *Header  `protobuf:"bytes,2,opt,name=Head,proto3" json:"Head,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnotateRequest) Reset()         { *m = AnnotateRequest{} }
+func (m *AnnotateRequest) String() string { return proto.CompactTextString(m) }
+func (*AnnotateRequest) ProtoMessage()    {}
+func (*AnnotateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+func (m *AnnotateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnotateRequest.Unmarshal(m, b)
+}
+func (m *AnnotateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnotateRequest.Marshal(b, m, deterministic)
+}
+func (m *AnnotateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotateRequest.Merge(m, src)
+}
+func (m *AnnotateRequest) XXX_Size() int {
+	return xxx_messageInfo_AnnotateRequest.Size(m)
+}
+func (m *AnnotateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnotateRequest proto.InternalMessageInfo
+
+func (m *AnnotateRequest) GetGrant() *grant.Grant {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *AnnotateRequest) GetHead() *Header {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+type GrantStat struct {
+	// The total size in bytes of the Plaintext body
+	Size_ int64 `protobuf:"varint,1,opt,name=Size,proto3" json:"Size,omitempty"`
+	// The number of body chunks
+	Chunks int64 `protobuf:"varint,2,opt,name=Chunks,proto3" json:"Chunks,omitempty"`
+	// The Header of the Plaintext if it has one
+	Head *Header `protobuf:"bytes,3,opt,name=Head,proto3" json:"Head,omitempty"`
+	// The version of the Grant
+	Version int32 `protobuf:"varint,4,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Whether every chunk (including the Header and any LINK References) is currently stored
+	Complete             bool     `protobuf:"varint,5,opt,name=Complete,proto3" json:"Complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GrantStat) Reset()         { *m = GrantStat{} }
+func (m *GrantStat) String() string { return proto.CompactTextString(m) }
+func (*GrantStat) ProtoMessage()    {}
+func (*GrantStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+}
+func (m *GrantStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GrantStat.Unmarshal(m, b)
+}
+func (m *GrantStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GrantStat.Marshal(b, m, deterministic)
+}
+func (m *GrantStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantStat.Merge(m, src)
+}
+func (m *GrantStat) XXX_Size() int {
+	return xxx_messageInfo_GrantStat.Size(m)
+}
+func (m *GrantStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GrantStat proto.InternalMessageInfo
+
+func (m *GrantStat) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *GrantStat) GetChunks() int64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *GrantStat) GetHead() *Header {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+func (m *GrantStat) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GrantStat) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
 type PlaintextAndGrantSpec struct {
 	Plaintext *Plaintext `protobuf:"bytes,1,opt,name=Plaintext,proto3" json:"Plaintext,omitempty"`
 	// The type of grant to output
@@ -87,7 +298,7 @@ func (m *PlaintextAndGrantSpec) Reset()         { *m = PlaintextAndGrantSpec{} }
 func (m *PlaintextAndGrantSpec) String() string { return proto.CompactTextString(m) }
 func (*PlaintextAndGrantSpec) ProtoMessage()    {}
 func (*PlaintextAndGrantSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
 }
 func (m *PlaintextAndGrantSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlaintextAndGrantSpec.Unmarshal(m, b)
@@ -134,7 +345,7 @@ func (m *ReferenceAndGrantSpec) Reset()         { *m = ReferenceAndGrantSpec{} }
 func (m *ReferenceAndGrantSpec) String() string { return proto.CompactTextString(m) }
 func (*ReferenceAndGrantSpec) ProtoMessage()    {}
 func (*ReferenceAndGrantSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
 }
 func (m *ReferenceAndGrantSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReferenceAndGrantSpec.Unmarshal(m, b)
@@ -173,7 +384,11 @@ type Header struct {
 	// Metadata
 	Data []byte `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
 	// The chunk size in bytes to use for the data
-	ChunkSize            int64    `protobuf:"varint,3,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
+	ChunkSize int64 `protobuf:"varint,3,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
+	// The name of the configured store in which to place the data, if empty the default store is used
+	Store string `protobuf:"bytes,4,opt,name=Store,proto3" json:"Store,omitempty"`
+	// The chunker used to split the data
+	Chunker              Chunker  `protobuf:"varint,5,opt,name=Chunker,proto3,enum=api.Chunker" json:"Chunker,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -183,7 +398,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -224,6 +439,20 @@ func (m *Header) GetChunkSize() int64 {
 	return 0
 }
 
+func (m *Header) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *Header) GetChunker() Chunker {
+	if m != nil {
+		return m.Chunker
+	}
+	return Chunker_DEFAULT
+}
+
 type Plaintext struct {
 	Body                 []byte   `protobuf:"bytes,1,opt,name=Body,proto3" json:"Body,omitempty"`
 	Head                 *Header  `protobuf:"bytes,3,opt,name=Head,proto3" json:"Head,omitempty"`
@@ -236,7 +465,7 @@ func (m *Plaintext) Reset()         { *m = Plaintext{} }
 func (m *Plaintext) String() string { return proto.CompactTextString(m) }
 func (*Plaintext) ProtoMessage()    {}
 func (*Plaintext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 func (m *Plaintext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Plaintext.Unmarshal(m, b)
@@ -281,7 +510,7 @@ func (m *Ciphertext) Reset()         { *m = Ciphertext{} }
 func (m *Ciphertext) String() string { return proto.CompactTextString(m) }
 func (*Ciphertext) ProtoMessage()    {}
 func (*Ciphertext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 func (m *Ciphertext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Ciphertext.Unmarshal(m, b)
@@ -320,7 +549,7 @@ func (m *ReferenceAndCiphertext) Reset()         { *m = ReferenceAndCiphertext{}
 func (m *ReferenceAndCiphertext) String() string { return proto.CompactTextString(m) }
 func (*ReferenceAndCiphertext) ProtoMessage()    {}
 func (*ReferenceAndCiphertext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 func (m *ReferenceAndCiphertext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReferenceAndCiphertext.Unmarshal(m, b)
@@ -354,8 +583,190 @@ func (m *ReferenceAndCiphertext) GetCiphertext() *Ciphertext {
 	return nil
 }
 
+type ListRequest struct {
+	// The name of the configured store to list, if empty the default store is used
+	Store string `protobuf:"bytes,1,opt,name=Store,proto3" json:"Store,omitempty"`
+	// Only list addresses beginning with Prefix
+	Prefix []byte `protobuf:"bytes,2,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	// Continue a listing from after this address (the last address received), in an order determined by the store
+	After []byte `protobuf:"bytes,3,opt,name=After,proto3" json:"After,omitempty"`
+	// The maximum number of addresses to list, if zero all addresses are listed
+	Limit                int64    `protobuf:"varint,4,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *ListRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *ListRequest) GetAfter() []byte {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *ListRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GarbageCollectRequest struct {
+	// Must be provided in the first message only
+	Options *GarbageCollectOptions `protobuf:"bytes,1,opt,name=Options,proto3" json:"Options,omitempty"`
+	// A grant whose data must be kept
+	Grant *grant.Grant `protobuf:"bytes,2,opt,name=Grant,proto3" json:"Grant,omitempty"`
+	// A reference whose data must be kept
+	Reference            *reference.Ref `protobuf:"bytes,3,opt,name=Reference,proto3" json:"Reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GarbageCollectRequest) Reset()         { *m = GarbageCollectRequest{} }
+func (m *GarbageCollectRequest) String() string { return proto.CompactTextString(m) }
+func (*GarbageCollectRequest) ProtoMessage()    {}
+func (*GarbageCollectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+func (m *GarbageCollectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GarbageCollectRequest.Unmarshal(m, b)
+}
+func (m *GarbageCollectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GarbageCollectRequest.Marshal(b, m, deterministic)
+}
+func (m *GarbageCollectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GarbageCollectRequest.Merge(m, src)
+}
+func (m *GarbageCollectRequest) XXX_Size() int {
+	return xxx_messageInfo_GarbageCollectRequest.Size(m)
+}
+func (m *GarbageCollectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GarbageCollectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GarbageCollectRequest proto.InternalMessageInfo
+
+func (m *GarbageCollectRequest) GetOptions() *GarbageCollectOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *GarbageCollectRequest) GetGrant() *grant.Grant {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *GarbageCollectRequest) GetReference() *reference.Ref {
+	if m != nil {
+		return m.Reference
+	}
+	return nil
+}
+
+type GarbageCollectOptions struct {
+	// Report unreachable blobs without deleting them
+	DryRun bool `protobuf:"varint,1,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+	// The name of the configured store to collect, if empty the default store is used
+	Store string `protobuf:"bytes,2,opt,name=Store,proto3" json:"Store,omitempty"`
+	// Collect even if no grants or references are received, which deletes every blob in the store
+	All                  bool     `protobuf:"varint,3,opt,name=All,proto3" json:"All,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GarbageCollectOptions) Reset()         { *m = GarbageCollectOptions{} }
+func (m *GarbageCollectOptions) String() string { return proto.CompactTextString(m) }
+func (*GarbageCollectOptions) ProtoMessage()    {}
+func (*GarbageCollectOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+func (m *GarbageCollectOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GarbageCollectOptions.Unmarshal(m, b)
+}
+func (m *GarbageCollectOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GarbageCollectOptions.Marshal(b, m, deterministic)
+}
+func (m *GarbageCollectOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GarbageCollectOptions.Merge(m, src)
+}
+func (m *GarbageCollectOptions) XXX_Size() int {
+	return xxx_messageInfo_GarbageCollectOptions.Size(m)
+}
+func (m *GarbageCollectOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_GarbageCollectOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GarbageCollectOptions proto.InternalMessageInfo
+
+func (m *GarbageCollectOptions) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *GarbageCollectOptions) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *GarbageCollectOptions) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
 type Address struct {
-	Address              []byte   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Address []byte `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	// The name of the configured store holding the address, if empty the default store is used
+	Store                string   `protobuf:"bytes,2,opt,name=Store,proto3" json:"Store,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -365,7 +776,7 @@ func (m *Address) Reset()         { *m = Address{} }
 func (m *Address) String() string { return proto.CompactTextString(m) }
 func (*Address) ProtoMessage()    {}
 func (*Address) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 func (m *Address) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Address.Unmarshal(m, b)
@@ -392,58 +803,156 @@ func (m *Address) GetAddress() []byte {
 	return nil
 }
 
+func (m *Address) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+type UnsealDeleteRequest struct {
+	Grant *grant.Grant `protobuf:"bytes,1,opt,name=Grant,proto3" json:"Grant,omitempty"`
+	// Grants that may share data with Grant, any chunk reachable from them is not deleted
+	Keep []*grant.Grant `protobuf:"bytes,2,rep,name=Keep,proto3" json:"Keep,omitempty"`
+	// Delete every chunk of Grant even though no Keep grants are given, asserting that no other grant shares its data
+	Force                bool     `protobuf:"varint,3,opt,name=Force,proto3" json:"Force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsealDeleteRequest) Reset()         { *m = UnsealDeleteRequest{} }
+func (m *UnsealDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsealDeleteRequest) ProtoMessage()    {}
+func (*UnsealDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+func (m *UnsealDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsealDeleteRequest.Unmarshal(m, b)
+}
+func (m *UnsealDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsealDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *UnsealDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsealDeleteRequest.Merge(m, src)
+}
+func (m *UnsealDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_UnsealDeleteRequest.Size(m)
+}
+func (m *UnsealDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsealDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsealDeleteRequest proto.InternalMessageInfo
+
+func (m *UnsealDeleteRequest) GetGrant() *grant.Grant {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *UnsealDeleteRequest) GetKeep() []*grant.Grant {
+	if m != nil {
+		return m.Keep
+	}
+	return nil
+}
+
+func (m *UnsealDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("api.Chunker", Chunker_name, Chunker_value)
 	proto.RegisterType((*GrantAndGrantSpec)(nil), "api.GrantAndGrantSpec")
+	proto.RegisterType((*UnsealGetRangeRequest)(nil), "api.UnsealGetRangeRequest")
+	proto.RegisterType((*AnnotateRequest)(nil), "api.AnnotateRequest")
+	proto.RegisterType((*GrantStat)(nil), "api.GrantStat")
 	proto.RegisterType((*PlaintextAndGrantSpec)(nil), "api.PlaintextAndGrantSpec")
 	proto.RegisterType((*ReferenceAndGrantSpec)(nil), "api.ReferenceAndGrantSpec")
 	proto.RegisterType((*Header)(nil), "api.Header")
 	proto.RegisterType((*Plaintext)(nil), "api.Plaintext")
 	proto.RegisterType((*Ciphertext)(nil), "api.Ciphertext")
 	proto.RegisterType((*ReferenceAndCiphertext)(nil), "api.ReferenceAndCiphertext")
+	proto.RegisterType((*ListRequest)(nil), "api.ListRequest")
+	proto.RegisterType((*GarbageCollectRequest)(nil), "api.GarbageCollectRequest")
+	proto.RegisterType((*GarbageCollectOptions)(nil), "api.GarbageCollectOptions")
 	proto.RegisterType((*Address)(nil), "api.Address")
+	proto.RegisterType((*UnsealDeleteRequest)(nil), "api.UnsealDeleteRequest")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 582 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x1b, 0x93, 0xc8, 0x53, 0x43, 0x61, 0x25, 0xaa, 0xc8, 0x80, 0x5a, 0x99, 0x0a, 0x05,
-	0x51, 0x39, 0x91, 0x11, 0x12, 0xea, 0x89, 0x36, 0x41, 0x85, 0x0b, 0x8a, 0xd6, 0xe2, 0xc2, 0x6d,
-	0x13, 0x4f, 0x12, 0x0b, 0xd7, 0xb6, 0xd6, 0x6b, 0x68, 0x39, 0xf3, 0x5f, 0x38, 0xf1, 0x1f, 0xd1,
-	0xae, 0x37, 0xfe, 0x4a, 0x85, 0x94, 0x93, 0x77, 0x67, 0xde, 0xbc, 0x37, 0xfb, 0x76, 0xd6, 0x60,
-	0xb1, 0x2c, 0xf2, 0x32, 0x9e, 0x8a, 0x94, 0xf4, 0x58, 0x16, 0x39, 0x87, 0x6b, 0xce, 0x12, 0x51,
-	0x46, 0x9c, 0x23, 0x8e, 0x2b, 0xe4, 0x98, 0x2c, 0x51, 0x07, 0xec, 0x5c, 0xa4, 0x1c, 0xf3, 0x72,
-	0xe7, 0x2e, 0xe0, 0xc9, 0xb5, 0x44, 0x5f, 0x26, 0xa1, 0xfa, 0x06, 0x19, 0x2e, 0x89, 0x0b, 0x0f,
-	0xd4, 0x66, 0x68, 0x9c, 0x1a, 0xa3, 0x43, 0xdf, 0xf6, 0x4a, 0x42, 0x15, 0xa3, 0x65, 0x8a, 0xbc,
-	0x06, 0xab, 0x2a, 0x18, 0x1e, 0x28, 0xdc, 0xa1, 0xc6, 0xc9, 0x10, 0xad, 0xb3, 0x6e, 0x06, 0x4f,
-	0xe7, 0x31, 0x8b, 0x12, 0x81, 0xb7, 0x6d, 0x9d, 0x73, 0xb0, 0xaa, 0x84, 0xd6, 0x7a, 0xe4, 0xc9,
-	0xc3, 0x54, 0x51, 0x5a, 0x03, 0xf6, 0x54, 0xa4, 0xdb, 0x63, 0x77, 0x15, 0xab, 0x44, 0xa5, 0x58,
-	0x3b, 0x44, 0x71, 0x45, 0x6b, 0xc0, 0x3e, 0x8a, 0x5f, 0xa0, 0xff, 0x09, 0x59, 0x88, 0x9c, 0x10,
-	0x30, 0x03, 0x16, 0x97, 0xe7, 0xb1, 0xa9, 0x5a, 0xcb, 0xd8, 0x8c, 0x09, 0xa6, 0x38, 0x6c, 0xaa,
-	0xd6, 0xe4, 0x39, 0x58, 0xd3, 0x4d, 0x91, 0x7c, 0x0f, 0xa2, 0x5f, 0x38, 0xec, 0x9d, 0x1a, 0xa3,
-	0x1e, 0xad, 0x03, 0xee, 0x87, 0x86, 0x35, 0xb2, 0xfc, 0x2a, 0x0d, 0xef, 0xb6, 0x94, 0x72, 0x4d,
-	0x4e, 0xc0, 0x94, 0x82, 0xaa, 0x52, 0xb6, 0x25, 0x6d, 0x2b, 0x3b, 0xa0, 0x2a, 0xe1, 0xfa, 0x00,
-	0xd3, 0x28, 0xdb, 0x20, 0x57, 0x14, 0x67, 0xf0, 0xf0, 0x63, 0xb2, 0xe4, 0x77, 0x99, 0xc0, 0x50,
-	0xb5, 0x52, 0x72, 0xb5, 0x83, 0xee, 0x4f, 0x38, 0x6e, 0xfa, 0xd6, 0xa8, 0xdf, 0xcf, 0xb8, 0x71,
-	0x53, 0x5b, 0x3b, 0x77, 0xa4, 0x5a, 0xac, 0xc3, 0xb4, 0x01, 0x71, 0x5f, 0xc2, 0xe0, 0x32, 0x0c,
-	0x39, 0xe6, 0x39, 0x19, 0x56, 0x4b, 0xdd, 0xe3, 0x76, 0xeb, 0xff, 0x39, 0xd0, 0x73, 0x49, 0xde,
-	0xc1, 0x60, 0x5e, 0x88, 0x00, 0x59, 0x4c, 0x9c, 0xf6, 0xc0, 0x34, 0x6f, 0xdb, 0x69, 0x0d, 0xee,
-	0xc8, 0x20, 0x6f, 0xc0, 0xfa, 0x9a, 0xe4, 0xc8, 0xe2, 0x6b, 0x14, 0xa4, 0x95, 0x74, 0x3a, 0x73,
-	0x37, 0x31, 0x88, 0x0f, 0x66, 0x43, 0xe0, 0xde, 0x71, 0xda, 0x11, 0x18, 0x41, 0xbf, 0x14, 0xd8,
-	0x61, 0x6f, 0x59, 0x35, 0x31, 0x88, 0x07, 0x7d, 0x8a, 0x0a, 0x79, 0xac, 0xf8, 0x77, 0x1e, 0x61,
-	0x9b, 0x9b, 0x9c, 0x83, 0x5d, 0x32, 0xcf, 0x30, 0x46, 0x81, 0x1d, 0x7e, 0x5b, 0x71, 0x68, 0x9f,
-	0x26, 0x86, 0xcf, 0xc0, 0x9a, 0xc6, 0xc8, 0xb8, 0x7e, 0x37, 0xbd, 0x79, 0x21, 0x48, 0xe7, 0x84,
-	0xdd, 0x9e, 0x46, 0xc6, 0xc4, 0x90, 0x50, 0x69, 0x4d, 0x27, 0xd5, 0x35, 0x47, 0x42, 0xfd, 0xdf,
-	0x06, 0x80, 0x1e, 0x9e, 0x28, 0x4d, 0xc8, 0x05, 0x0c, 0xf4, 0x6e, 0x47, 0xe8, 0xd9, 0x8e, 0x81,
-	0xf5, 0xc5, 0x2b, 0xd5, 0x0b, 0x18, 0xcc, 0xb0, 0xac, 0xfd, 0x1f, 0xf6, 0xde, 0x36, 0xfe, 0x1a,
-	0x30, 0x08, 0x44, 0xca, 0xd9, 0x5a, 0x3e, 0x57, 0x73, 0x5e, 0xe4, 0x1b, 0xd2, 0x9d, 0xb4, 0xb6,
-	0x3d, 0xfa, 0xa0, 0xe6, 0xbc, 0x88, 0xe5, 0x35, 0x35, 0x32, 0x4e, 0xb7, 0x50, 0x41, 0x5f, 0x81,
-	0x19, 0x08, 0x26, 0x3a, 0xd0, 0xc7, 0x9e, 0xfe, 0x8d, 0xca, 0xdc, 0xe7, 0x64, 0x95, 0x92, 0x33,
-	0xe8, 0x57, 0x77, 0xd3, 0x44, 0xb6, 0x76, 0x57, 0x27, 0xdf, 0x5e, 0xac, 0x23, 0xb1, 0x29, 0x16,
-	0xde, 0x32, 0xbd, 0x19, 0xdf, 0xa4, 0x09, 0xbb, 0x1d, 0x6f, 0x52, 0xc6, 0xc3, 0xf1, 0x8f, 0xf7,
-	0x63, 0x96, 0x45, 0x8b, 0xbe, 0xfa, 0x2f, 0xbf, 0xfd, 0x17, 0x00, 0x00, 0xff, 0xff, 0x7d, 0x2f,
-	0xf3, 0x4c, 0xd5, 0x05, 0x00, 0x00,
+	// 1016 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x8f, 0xda, 0x46,
+	0x10, 0xaf, 0xb1, 0x8f, 0x8f, 0x81, 0xde, 0xd1, 0x4d, 0x40, 0x88, 0xb6, 0x0a, 0xb2, 0xa2, 0x88,
+	0xb4, 0x11, 0x9c, 0xe8, 0x87, 0xda, 0x3c, 0x44, 0x25, 0xc0, 0xa5, 0xa7, 0x9e, 0xee, 0xd0, 0xde,
+	0x25, 0xad, 0xfa, 0x52, 0xed, 0xc1, 0x00, 0x56, 0x7c, 0xb6, 0x63, 0x2f, 0xe9, 0x5d, 0x9f, 0xdb,
+	0xb7, 0xaa, 0xaf, 0x7d, 0xec, 0xbf, 0x5a, 0xed, 0x87, 0x8d, 0x6d, 0x48, 0x53, 0x9e, 0xd8, 0x99,
+	0xf9, 0xed, 0xcc, 0xec, 0x6f, 0xc6, 0x33, 0x40, 0x85, 0x05, 0x4e, 0x2f, 0x08, 0x7d, 0xee, 0x13,
+	0x93, 0x05, 0x4e, 0xbb, 0xba, 0x0c, 0x99, 0xc7, 0x95, 0xa6, 0x7d, 0x14, 0xe2, 0x02, 0x43, 0xf4,
+	0x66, 0xa8, 0x15, 0xb5, 0x88, 0xfb, 0x21, 0x46, 0x4a, 0xb2, 0xaf, 0xe1, 0xa3, 0x17, 0x02, 0x3d,
+	0xf4, 0xe6, 0xf2, 0xf7, 0x32, 0xc0, 0x19, 0xb1, 0xe1, 0x40, 0x0a, 0x2d, 0xa3, 0x63, 0x74, 0xab,
+	0x83, 0x5a, 0x4f, 0x39, 0x94, 0x3a, 0xaa, 0x4c, 0xe4, 0x31, 0x54, 0x92, 0x0b, 0xad, 0x82, 0xc4,
+	0x55, 0x35, 0x4e, 0xa8, 0xe8, 0xc6, 0x6a, 0xbf, 0x86, 0xc6, 0x4b, 0x2f, 0x42, 0xe6, 0xbe, 0x40,
+	0x4e, 0x99, 0xb7, 0x44, 0x8a, 0x6f, 0xd6, 0x18, 0xf1, 0xff, 0x15, 0xa7, 0x09, 0xc5, 0x8b, 0xc5,
+	0x22, 0x42, 0x2e, 0x83, 0x98, 0x54, 0x4b, 0x42, 0x7f, 0x86, 0xde, 0x92, 0xaf, 0x5a, 0xa6, 0xd2,
+	0x2b, 0xc9, 0x7e, 0x05, 0x47, 0x43, 0xcf, 0xf3, 0x39, 0xe3, 0x7b, 0x85, 0x79, 0x00, 0xd6, 0xf7,
+	0xc8, 0xe6, 0xc9, 0x4b, 0x04, 0xa5, 0x42, 0x81, 0x21, 0x95, 0x06, 0xfb, 0x2f, 0x23, 0x7e, 0x30,
+	0x67, 0x9c, 0x10, 0xb0, 0x2e, 0x9d, 0xdf, 0x50, 0x7a, 0x34, 0xa9, 0x3c, 0x8b, 0x8c, 0x46, 0xab,
+	0xb5, 0xf7, 0x3a, 0x8a, 0x33, 0x55, 0x52, 0xe2, 0xda, 0x7c, 0x87, 0x6b, 0xd2, 0x82, 0xd2, 0x2b,
+	0x0c, 0x23, 0xc7, 0xf7, 0x5a, 0x56, 0xc7, 0xe8, 0x1e, 0xd0, 0x58, 0x24, 0x6d, 0x28, 0x8f, 0xfc,
+	0x9b, 0xc0, 0x45, 0x8e, 0xad, 0x83, 0x8e, 0xd1, 0x2d, 0xd3, 0x44, 0xb6, 0x03, 0x68, 0x4c, 0x5d,
+	0xe6, 0x78, 0x1c, 0x6f, 0xb3, 0xd5, 0x7b, 0x02, 0x95, 0xc4, 0xa0, 0x9f, 0x7c, 0x28, 0x83, 0x26,
+	0x5a, 0xba, 0x01, 0xec, 0x53, 0xc7, 0x00, 0x1a, 0x34, 0x6e, 0xa6, 0x7c, 0xc4, 0xc4, 0x90, 0x44,
+	0xdc, 0xf4, 0x1d, 0xc5, 0x05, 0xdd, 0x00, 0xf6, 0x89, 0xf8, 0xa7, 0x01, 0x45, 0x45, 0x95, 0x64,
+	0x9c, 0xb9, 0xea, 0x41, 0x35, 0x2a, 0xcf, 0x42, 0x37, 0x66, 0x9c, 0x49, 0x27, 0x35, 0x2a, 0xcf,
+	0xe4, 0x13, 0xa8, 0x48, 0xde, 0x65, 0x79, 0x54, 0x6b, 0x6c, 0x14, 0xe4, 0x3e, 0x1c, 0x5c, 0x8a,
+	0xf6, 0x97, 0x44, 0x57, 0xa8, 0x12, 0xc8, 0x23, 0x28, 0x49, 0x08, 0x86, 0x92, 0xe5, 0xc3, 0x41,
+	0x4d, 0xf2, 0xa5, 0x75, 0x34, 0x36, 0xda, 0xdf, 0xa5, 0x98, 0x15, 0xc1, 0x9f, 0xfb, 0xf3, 0xbb,
+	0x38, 0x21, 0x71, 0x7e, 0x6f, 0xa9, 0xed, 0x01, 0xc0, 0xc8, 0x09, 0x56, 0x18, 0x4a, 0x17, 0x0f,
+	0xe1, 0xc3, 0x89, 0x37, 0x0b, 0xef, 0x02, 0x8e, 0x73, 0xf9, 0x10, 0xe5, 0x2b, 0xab, 0xb4, 0x7f,
+	0x85, 0x66, 0x9a, 0xf6, 0xd4, 0xfd, 0xfd, 0x78, 0xef, 0xa7, 0x63, 0x6b, 0xe2, 0x8f, 0xd4, 0x43,
+	0x13, 0x35, 0x4d, 0x41, 0xec, 0x25, 0x54, 0xcf, 0x9c, 0x88, 0xc7, 0x9f, 0x51, 0xc2, 0x9d, 0x91,
+	0xe6, 0xae, 0x09, 0xc5, 0x69, 0x88, 0x0b, 0xe7, 0x56, 0x57, 0x41, 0x4b, 0x02, 0x3d, 0x5c, 0x70,
+	0x0c, 0x25, 0x17, 0x35, 0xaa, 0x04, 0xa1, 0x3d, 0x73, 0x6e, 0x1c, 0x2e, 0xf9, 0x37, 0xa9, 0x12,
+	0xec, 0x7f, 0x0c, 0x68, 0xbc, 0x60, 0xe1, 0x35, 0x5b, 0xe2, 0xc8, 0x77, 0x5d, 0x9c, 0x25, 0x31,
+	0xbf, 0x84, 0xd2, 0x45, 0xc0, 0x1d, 0xdf, 0x8b, 0xf4, 0xfb, 0xda, 0x32, 0xe1, 0x2c, 0x58, 0x23,
+	0x68, 0x0c, 0xdd, 0x7c, 0xf0, 0x85, 0x77, 0x7f, 0xf0, 0x19, 0xee, 0xcc, 0xf7, 0x70, 0x67, 0xff,
+	0x98, 0x4f, 0x30, 0x0e, 0xd5, 0x84, 0xe2, 0x38, 0xbc, 0xa3, 0x6b, 0x4f, 0xe6, 0x57, 0xa6, 0x5a,
+	0xda, 0x90, 0x55, 0x48, 0x93, 0x55, 0x07, 0x73, 0xe8, 0xba, 0x32, 0x5c, 0x99, 0x8a, 0xa3, 0xfd,
+	0x2d, 0x94, 0x86, 0xf3, 0x79, 0x88, 0x51, 0x44, 0x5a, 0xc9, 0x51, 0xf7, 0x41, 0x62, 0xd9, 0xe9,
+	0xcc, 0x7e, 0x03, 0xf7, 0xd4, 0x58, 0x1d, 0xa3, 0x8b, 0xfb, 0x4d, 0xbb, 0x0e, 0x58, 0x3f, 0x20,
+	0x06, 0xad, 0x42, 0xc7, 0xdc, 0x82, 0x48, 0x8b, 0x08, 0x79, 0xe2, 0x87, 0x9a, 0x9a, 0x32, 0x55,
+	0xc2, 0x67, 0x5f, 0x27, 0x1f, 0x0a, 0xa9, 0x42, 0x69, 0x3c, 0x39, 0x19, 0xbe, 0x3c, 0xbb, 0xaa,
+	0x7f, 0x40, 0x2a, 0x70, 0x70, 0x72, 0xfa, 0xd3, 0x64, 0x5c, 0x37, 0xc8, 0x3d, 0x38, 0x1a, 0x5d,
+	0x9c, 0x5f, 0x4d, 0xce, 0xaf, 0x7e, 0x19, 0x4f, 0x4e, 0x4e, 0xcf, 0x27, 0xe3, 0x7a, 0x61, 0xf0,
+	0x87, 0xa5, 0x93, 0x22, 0x5f, 0x41, 0x69, 0xba, 0xe6, 0x97, 0xc8, 0x5c, 0xd2, 0xce, 0x0e, 0xa5,
+	0xf4, 0x44, 0x69, 0x67, 0x52, 0xea, 0x1a, 0xe4, 0x73, 0xa8, 0x24, 0x2b, 0x84, 0x64, 0x8c, 0xed,
+	0xdc, 0x6c, 0x3b, 0x36, 0xc8, 0x33, 0x38, 0xcc, 0xee, 0x1b, 0x1d, 0x6a, 0xe7, 0x12, 0xda, 0x71,
+	0x7f, 0x00, 0x56, 0x2a, 0xc1, 0x9d, 0x23, 0x6f, 0x2b, 0xc1, 0x2e, 0x14, 0x95, 0xfb, 0xad, 0xec,
+	0x32, 0x3d, 0x75, 0x6c, 0x90, 0x1e, 0x14, 0x29, 0x4a, 0x64, 0x53, 0xf5, 0x72, 0x7e, 0xfd, 0x66,
+	0x7d, 0x93, 0x27, 0x50, 0x4b, 0x97, 0x39, 0xe7, 0x5f, 0x4d, 0x2a, 0xdd, 0x28, 0xc7, 0x06, 0x19,
+	0x42, 0x23, 0x8d, 0xa6, 0x38, 0x5b, 0x87, 0x91, 0xf3, 0x16, 0x49, 0x2b, 0x45, 0x41, 0xa6, 0x61,
+	0xb6, 0x5c, 0xf4, 0xa0, 0x1c, 0x6f, 0x50, 0x72, 0x5f, 0xd9, 0xb2, 0x0b, 0x35, 0x97, 0xe0, 0x63,
+	0xa8, 0x88, 0x9d, 0xa8, 0x84, 0x5d, 0xb5, 0x49, 0xd6, 0xe6, 0x80, 0x41, 0x65, 0xe4, 0x22, 0x0b,
+	0xf5, 0xe6, 0x31, 0xa7, 0x6b, 0x4e, 0x72, 0xfc, 0xe7, 0x19, 0xeb, 0x1a, 0xc7, 0x86, 0x80, 0x8a,
+	0xc2, 0xe7, 0x4c, 0xf9, 0xd2, 0x09, 0xe8, 0xe0, 0x77, 0x03, 0x40, 0xcf, 0x4f, 0xb1, 0x41, 0x9f,
+	0x42, 0x49, 0x4b, 0x5b, 0x81, 0x3e, 0xde, 0x2a, 0xef, 0x66, 0xf6, 0xc9, 0xa8, 0x4f, 0xa1, 0x34,
+	0x46, 0x75, 0xf7, 0xbf, 0xb0, 0x3b, 0xd3, 0xf8, 0xbb, 0x00, 0x25, 0xf1, 0x99, 0xb2, 0xa5, 0x58,
+	0x78, 0xd6, 0x74, 0x1d, 0xad, 0x48, 0x7e, 0xd8, 0x66, 0x99, 0xd7, 0x0f, 0xb5, 0xa6, 0x6b, 0xd7,
+	0x25, 0x19, 0x4b, 0x3b, 0x7f, 0x51, 0x42, 0x1f, 0x81, 0x25, 0xff, 0x8a, 0x64, 0xa1, 0xf5, 0x9e,
+	0xfe, 0x7b, 0x27, 0x6c, 0xa7, 0xde, 0xc2, 0x27, 0x0f, 0xa1, 0x98, 0x74, 0x4e, 0x1a, 0x99, 0x91,
+	0x48, 0x17, 0x2c, 0x31, 0xeb, 0x49, 0x5d, 0x6a, 0x53, 0x63, 0x7f, 0xab, 0x3d, 0x9e, 0xc1, 0x61,
+	0x76, 0x14, 0x92, 0x5d, 0x33, 0x79, 0xe7, 0x6d, 0x91, 0xf7, 0xf3, 0x07, 0x3f, 0x7f, 0xba, 0x74,
+	0xf8, 0x6a, 0x7d, 0xdd, 0x9b, 0xf9, 0x37, 0xfd, 0x1b, 0xdf, 0x63, 0xb7, 0xfd, 0x95, 0xcf, 0xc2,
+	0x79, 0xff, 0xed, 0x37, 0x7d, 0x16, 0x38, 0xd7, 0x45, 0xf9, 0xcf, 0xf4, 0x8b, 0x7f, 0x07, 0x00,
+	0x0a, 0xbc, 0xd6, 0xe2, 0xd7, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -458,10 +967,14 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type GrantClient interface {
-	// Put a Plaintext and returned the sealed Reference as a Grant
+	// Put a Plaintext and returned the sealed Reference as a Grant. Header metadata (Header.Data) that is only known
+	// once the body has been sent may be sent in a final message containing just a Header.
 	PutSeal(ctx context.Context, opts ...grpc.CallOption) (Grant_PutSealClient, error)
 	// Unseal a Grant and follow the Reference to return a Plaintext
 	UnsealGet(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (Grant_UnsealGetClient, error)
+	// Unseal a Grant and return only the part of its Plaintext body in the requested range (along with any header).
+	// Only the chunks overlapping the range are fetched and decrypted.
+	UnsealGetRange(ctx context.Context, in *UnsealGetRangeRequest, opts ...grpc.CallOption) (Grant_UnsealGetRangeClient, error)
 	// Seal a Reference to create a Grant
 	Seal(ctx context.Context, opts ...grpc.CallOption) (Grant_SealClient, error)
 	// Unseal a Grant to recover the Reference
@@ -469,8 +982,21 @@ type GrantClient interface {
 	// Convert one grant to another grant to re-share with another party or just
 	// to change grant type
 	Reseal(ctx context.Context, in *GrantAndGrantSpec, opts ...grpc.CallOption) (*grant.Grant, error)
-	// Unseal a Grant and follow the Reference to delete the Plaintext
+	// Unseal a Grant and follow the References (including any linked References) to delete every chunk of the
+	// Plaintext, returning the address of each chunk deleted. Chunks with the same plaintext and salt are shared by
+	// convergent encryption with other Grants, whose data is lost along with them, so use UnsealDeleteRecursive to
+	// keep any chunks that may be shared. Grants sealed with a LinkNonce are refused since their LINK may be shared
+	// with Grants that are not known.
 	UnsealDelete(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (Grant_UnsealDeleteClient, error)
+	// UnsealDelete a Grant except for any chunk reachable from the Keep grants. The request is refused unless Keep
+	// grants are given or Force is set.
+	UnsealDeleteRecursive(ctx context.Context, in *UnsealDeleteRequest, opts ...grpc.CallOption) (Grant_UnsealDeleteRecursiveClient, error)
+	// Add or replace the metadata (Header.Data) in the Header of the Plaintext behind a Grant, returning a new Grant
+	// (sealed with the same spec) that shares its body chunks. Other Header fields cannot be changed.
+	Annotate(ctx context.Context, in *AnnotateRequest, opts ...grpc.CallOption) (*grant.Grant, error)
+	// Unseal a Grant and describe the Plaintext behind it (its size, number of chunks, and Header) and whether all of
+	// its chunks are stored. Only the Header and any LINK References are fetched.
+	StatGrant(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (*GrantStat, error)
 }
 
 type grantClient struct {
@@ -547,8 +1073,40 @@ func (x *grantUnsealGetClient) Recv() (*Plaintext, error) {
 	return m, nil
 }
 
+func (c *grantClient) UnsealGetRange(ctx context.Context, in *UnsealGetRangeRequest, opts ...grpc.CallOption) (Grant_UnsealGetRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[2], "/api.Grant/UnsealGetRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grantUnsealGetRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Grant_UnsealGetRangeClient interface {
+	Recv() (*Plaintext, error)
+	grpc.ClientStream
+}
+
+type grantUnsealGetRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *grantUnsealGetRangeClient) Recv() (*Plaintext, error) {
+	m := new(Plaintext)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *grantClient) Seal(ctx context.Context, opts ...grpc.CallOption) (Grant_SealClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[2], "/api.Grant/Seal", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[3], "/api.Grant/Seal", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -582,7 +1140,7 @@ func (x *grantSealClient) CloseAndRecv() (*grant.Grant, error) {
 }
 
 func (c *grantClient) Unseal(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (Grant_UnsealClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[3], "/api.Grant/Unseal", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[4], "/api.Grant/Unseal", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -623,7 +1181,7 @@ func (c *grantClient) Reseal(ctx context.Context, in *GrantAndGrantSpec, opts ..
 }
 
 func (c *grantClient) UnsealDelete(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (Grant_UnsealDeleteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[4], "/api.Grant/UnsealDelete", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[5], "/api.Grant/UnsealDelete", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -654,12 +1212,66 @@ func (x *grantUnsealDeleteClient) Recv() (*Address, error) {
 	return m, nil
 }
 
+func (c *grantClient) UnsealDeleteRecursive(ctx context.Context, in *UnsealDeleteRequest, opts ...grpc.CallOption) (Grant_UnsealDeleteRecursiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Grant_serviceDesc.Streams[6], "/api.Grant/UnsealDeleteRecursive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grantUnsealDeleteRecursiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Grant_UnsealDeleteRecursiveClient interface {
+	Recv() (*Address, error)
+	grpc.ClientStream
+}
+
+type grantUnsealDeleteRecursiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *grantUnsealDeleteRecursiveClient) Recv() (*Address, error) {
+	m := new(Address)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *grantClient) Annotate(ctx context.Context, in *AnnotateRequest, opts ...grpc.CallOption) (*grant.Grant, error) {
+	out := new(grant.Grant)
+	err := c.cc.Invoke(ctx, "/api.Grant/Annotate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *grantClient) StatGrant(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (*GrantStat, error) {
+	out := new(GrantStat)
+	err := c.cc.Invoke(ctx, "/api.Grant/StatGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GrantServer is the server API for Grant service.
 type GrantServer interface {
-	// Put a Plaintext and returned the sealed Reference as a Grant
+	// Put a Plaintext and returned the sealed Reference as a Grant. Header metadata (Header.Data) that is only known
+	// once the body has been sent may be sent in a final message containing just a Header.
 	PutSeal(Grant_PutSealServer) error
 	// Unseal a Grant and follow the Reference to return a Plaintext
 	UnsealGet(*grant.Grant, Grant_UnsealGetServer) error
+	// Unseal a Grant and return only the part of its Plaintext body in the requested range (along with any header).
+	// Only the chunks overlapping the range are fetched and decrypted.
+	UnsealGetRange(*UnsealGetRangeRequest, Grant_UnsealGetRangeServer) error
 	// Seal a Reference to create a Grant
 	Seal(Grant_SealServer) error
 	// Unseal a Grant to recover the Reference
@@ -667,8 +1279,21 @@ type GrantServer interface {
 	// Convert one grant to another grant to re-share with another party or just
 	// to change grant type
 	Reseal(context.Context, *GrantAndGrantSpec) (*grant.Grant, error)
-	// Unseal a Grant and follow the Reference to delete the Plaintext
+	// Unseal a Grant and follow the References (including any linked References) to delete every chunk of the
+	// Plaintext, returning the address of each chunk deleted. Chunks with the same plaintext and salt are shared by
+	// convergent encryption with other Grants, whose data is lost along with them, so use UnsealDeleteRecursive to
+	// keep any chunks that may be shared. Grants sealed with a LinkNonce are refused since their LINK may be shared
+	// with Grants that are not known.
 	UnsealDelete(*grant.Grant, Grant_UnsealDeleteServer) error
+	// UnsealDelete a Grant except for any chunk reachable from the Keep grants. The request is refused unless Keep
+	// grants are given or Force is set.
+	UnsealDeleteRecursive(*UnsealDeleteRequest, Grant_UnsealDeleteRecursiveServer) error
+	// Add or replace the metadata (Header.Data) in the Header of the Plaintext behind a Grant, returning a new Grant
+	// (sealed with the same spec) that shares its body chunks. Other Header fields cannot be changed.
+	Annotate(context.Context, *AnnotateRequest) (*grant.Grant, error)
+	// Unseal a Grant and describe the Plaintext behind it (its size, number of chunks, and Header) and whether all of
+	// its chunks are stored. Only the Header and any LINK References are fetched.
+	StatGrant(context.Context, *grant.Grant) (*GrantStat, error)
 }
 
 // UnimplementedGrantServer can be embedded to have forward compatible implementations.
@@ -681,6 +1306,9 @@ func (*UnimplementedGrantServer) PutSeal(srv Grant_PutSealServer) error {
 func (*UnimplementedGrantServer) UnsealGet(req *grant.Grant, srv Grant_UnsealGetServer) error {
 	return status.Errorf(codes.Unimplemented, "method UnsealGet not implemented")
 }
+func (*UnimplementedGrantServer) UnsealGetRange(req *UnsealGetRangeRequest, srv Grant_UnsealGetRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method UnsealGetRange not implemented")
+}
 func (*UnimplementedGrantServer) Seal(srv Grant_SealServer) error {
 	return status.Errorf(codes.Unimplemented, "method Seal not implemented")
 }
@@ -693,6 +1321,15 @@ func (*UnimplementedGrantServer) Reseal(ctx context.Context, req *GrantAndGrantS
 func (*UnimplementedGrantServer) UnsealDelete(req *grant.Grant, srv Grant_UnsealDeleteServer) error {
 	return status.Errorf(codes.Unimplemented, "method UnsealDelete not implemented")
 }
+func (*UnimplementedGrantServer) UnsealDeleteRecursive(req *UnsealDeleteRequest, srv Grant_UnsealDeleteRecursiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method UnsealDeleteRecursive not implemented")
+}
+func (*UnimplementedGrantServer) Annotate(ctx context.Context, req *AnnotateRequest) (*grant.Grant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Annotate not implemented")
+}
+func (*UnimplementedGrantServer) StatGrant(ctx context.Context, req *grant.Grant) (*GrantStat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatGrant not implemented")
+}
 
 func RegisterGrantServer(s *grpc.Server, srv GrantServer) {
 	s.RegisterService(&_Grant_serviceDesc, srv)
@@ -745,6 +1382,27 @@ func (x *grantUnsealGetServer) Send(m *Plaintext) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Grant_UnsealGetRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UnsealGetRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GrantServer).UnsealGetRange(m, &grantUnsealGetRangeServer{stream})
+}
+
+type Grant_UnsealGetRangeServer interface {
+	Send(*Plaintext) error
+	grpc.ServerStream
+}
+
+type grantUnsealGetRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *grantUnsealGetRangeServer) Send(m *Plaintext) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Grant_Seal_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(GrantServer).Seal(&grantSealServer{stream})
 }
@@ -831,6 +1489,63 @@ func (x *grantUnsealDeleteServer) Send(m *Address) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Grant_UnsealDeleteRecursive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UnsealDeleteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GrantServer).UnsealDeleteRecursive(m, &grantUnsealDeleteRecursiveServer{stream})
+}
+
+type Grant_UnsealDeleteRecursiveServer interface {
+	Send(*Address) error
+	grpc.ServerStream
+}
+
+type grantUnsealDeleteRecursiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *grantUnsealDeleteRecursiveServer) Send(m *Address) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Grant_Annotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServer).Annotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Grant/Annotate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServer).Annotate(ctx, req.(*AnnotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Grant_StatGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(grant.Grant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GrantServer).StatGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Grant/StatGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GrantServer).StatGrant(ctx, req.(*grant.Grant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Grant_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Grant",
 	HandlerType: (*GrantServer)(nil),
@@ -839,6 +1554,14 @@ var _Grant_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Reseal",
 			Handler:    _Grant_Reseal_Handler,
 		},
+		{
+			MethodName: "Annotate",
+			Handler:    _Grant_Annotate_Handler,
+		},
+		{
+			MethodName: "StatGrant",
+			Handler:    _Grant_StatGrant_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -851,6 +1574,11 @@ var _Grant_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Grant_UnsealGet_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "UnsealGetRange",
+			Handler:       _Grant_UnsealGetRange_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Seal",
 			Handler:       _Grant_Seal_Handler,
@@ -866,6 +1594,11 @@ var _Grant_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Grant_UnsealDelete_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "UnsealDeleteRecursive",
+			Handler:       _Grant_UnsealDeleteRecursive_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -1243,6 +1976,11 @@ type StorageClient interface {
 	Stat(ctx context.Context, in *Address, opts ...grpc.CallOption) (*stores.StatInfo, error)
 	// Delete the encrypted blob stored at address
 	Delete(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Address, error)
+	// List the addresses of the blobs held by a store
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Storage_ListClient, error)
+	// Find blobs in a store that are not reachable from any of the grants or references provided and delete them
+	// (unless DryRun is set) returning the address of each unreachable blob
+	GarbageCollect(ctx context.Context, opts ...grpc.CallOption) (Storage_GarbageCollectClient, error)
 }
 
 type storageClient struct {
@@ -1333,6 +2071,69 @@ func (c *storageClient) Delete(ctx context.Context, in *Address, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *storageClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Storage_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/api.Storage/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ListClient interface {
+	Recv() (*Address, error)
+	grpc.ClientStream
+}
+
+type storageListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageListClient) Recv() (*Address, error) {
+	m := new(Address)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) GarbageCollect(ctx context.Context, opts ...grpc.CallOption) (Storage_GarbageCollectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[3], "/api.Storage/GarbageCollect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageGarbageCollectClient{stream}
+	return x, nil
+}
+
+type Storage_GarbageCollectClient interface {
+	Send(*GarbageCollectRequest) error
+	Recv() (*Address, error)
+	grpc.ClientStream
+}
+
+type storageGarbageCollectClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageGarbageCollectClient) Send(m *GarbageCollectRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageGarbageCollectClient) Recv() (*Address, error) {
+	m := new(Address)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StorageServer is the server API for Storage service.
 type StorageServer interface {
 	// Insert the (presumably) encrypted data provided and get the its address.
@@ -1344,6 +2145,11 @@ type StorageServer interface {
 	Stat(context.Context, *Address) (*stores.StatInfo, error)
 	// Delete the encrypted blob stored at address
 	Delete(context.Context, *Address) (*Address, error)
+	// List the addresses of the blobs held by a store
+	List(*ListRequest, Storage_ListServer) error
+	// Find blobs in a store that are not reachable from any of the grants or references provided and delete them
+	// (unless DryRun is set) returning the address of each unreachable blob
+	GarbageCollect(Storage_GarbageCollectServer) error
 }
 
 // UnimplementedStorageServer can be embedded to have forward compatible implementations.
@@ -1362,6 +2168,12 @@ func (*UnimplementedStorageServer) Stat(ctx context.Context, req *Address) (*sto
 func (*UnimplementedStorageServer) Delete(ctx context.Context, req *Address) (*Address, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (*UnimplementedStorageServer) List(req *ListRequest, srv Storage_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedStorageServer) GarbageCollect(srv Storage_GarbageCollectServer) error {
+	return status.Errorf(codes.Unimplemented, "method GarbageCollect not implemented")
+}
 
 func RegisterStorageServer(s *grpc.Server, srv StorageServer) {
 	s.RegisterService(&_Storage_serviceDesc, srv)
@@ -1455,6 +2267,53 @@ func _Storage_Delete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).List(m, &storageListServer{stream})
+}
+
+type Storage_ListServer interface {
+	Send(*Address) error
+	grpc.ServerStream
+}
+
+type storageListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageListServer) Send(m *Address) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_GarbageCollect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServer).GarbageCollect(&storageGarbageCollectServer{stream})
+}
+
+type Storage_GarbageCollectServer interface {
+	Send(*Address) error
+	Recv() (*GarbageCollectRequest, error)
+	grpc.ServerStream
+}
+
+type storageGarbageCollectServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageGarbageCollectServer) Send(m *Address) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageGarbageCollectServer) Recv() (*GarbageCollectRequest, error) {
+	m := new(GarbageCollectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Storage_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Storage",
 	HandlerType: (*StorageServer)(nil),
@@ -1481,6 +2340,17 @@ var _Storage_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "List",
+			Handler:       _Storage_List_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GarbageCollect",
+			Handler:       _Storage_GarbageCollect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
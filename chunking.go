@@ -2,8 +2,11 @@ package hoard
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math/bits"
 )
 
 func CopyChunked(dest func(chunk []byte) error, src func() ([]byte, error), chunkSize int64) error {
@@ -15,6 +18,115 @@ func CopyChunked(dest func(chunk []byte) error, src func() ([]byte, error), chun
 	return nil
 }
 
+// CopyContentDefined is like CopyChunked but cuts chunks where a rolling hash of the data matches rather than every
+// chunkSize bytes. Chunks are chunkSize bytes on average and between a quarter and four times that size (but no larger
+// than MaxChunkSize). Since boundaries depend only on nearby data, an insertion or deletion changes at most a couple of
+// chunks either side of it and the rest can be deduplicated against an earlier version of the data.
+func CopyContentDefined(dest func(chunk []byte) error, src func() ([]byte, error), chunkSize int64) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("CopyContentDefined: chunk size must be positive but was %d", chunkSize)
+	}
+	cdc := newContentDefinedChunker(chunkSize)
+	reader := NewPuller(src)
+	buf := make([]byte, cdc.max)
+	var n int
+	var eof bool
+	for {
+		for !eof && n < len(buf) {
+			read, err := reader.Read(buf[n:])
+			n += read
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return err
+			}
+		}
+		if n == 0 {
+			return nil
+		}
+		cut := cdc.cut(buf[:n])
+		err := dest(buf[:cut])
+		if err != nil {
+			return err
+		}
+		n = copy(buf, buf[cut:n])
+	}
+}
+
+// A FastCDC chunker using normalised chunking, see https://www.usenix.org/system/files/conference/atc16/atc16-paper-xia.pdf
+type contentDefinedChunker struct {
+	min, avg, max int
+	// Harder to match before the average size and easier after to bring chunk sizes closer to it
+	maskSmall, maskLarge uint64
+}
+
+func newContentDefinedChunker(chunkSize int64) *contentDefinedChunker {
+	max := 4 * chunkSize
+	if max > MaxChunkSize {
+		max = MaxChunkSize
+	}
+	avg := chunkSize
+	if avg > max {
+		avg = max
+	}
+	// Match on the high bits of the hash since they depend on the most data
+	n := bits.Len64(uint64(avg)) - 1
+	return &contentDefinedChunker{
+		min:       int(avg / 4),
+		avg:       int(avg),
+		max:       int(max),
+		maskSmall: highBits(n + 1),
+		maskLarge: highBits(n - 1),
+	}
+}
+
+// Returns the length of the first chunk in data, which must be complete unless data is shorter than max
+func (cdc *contentDefinedChunker) cut(data []byte) int {
+	n := len(data)
+	if n <= cdc.min {
+		return n
+	}
+	if n > cdc.max {
+		n = cdc.max
+	}
+	normal := cdc.avg
+	if normal > n {
+		normal = n
+	}
+	var hash uint64
+	i := cdc.min
+	for ; i < normal; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&cdc.maskSmall == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&cdc.maskLarge == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
+
+func highBits(n int) uint64 {
+	if n <= 0 {
+		return 0
+	}
+	return ^uint64(0) << (64 - n)
+}
+
+// Random values for each byte of the rolling hash, these must never change or chunk boundaries (and so the addresses
+// of chunks) would change with them
+var gear = func() (table [256]uint64) {
+	for i := range table {
+		sum := sha256.Sum256([]byte{byte(i)})
+		table[i] = binary.BigEndian.Uint64(sum[:])
+	}
+	return
+}()
+
 type PullReader struct {
 	pull func() ([]byte, error)
 	buf  bytes.Buffer
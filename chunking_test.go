@@ -0,0 +1,78 @@
+package hoard
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyContentDefined(t *testing.T) {
+	const chunkSize = 4096
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	chunks := contentDefinedChunks(t, data, chunkSize)
+	assert.Equal(t, data, bytes.Join(chunks, nil))
+	for _, chunk := range chunks[:len(chunks)-1] {
+		assert.GreaterOrEqual(t, len(chunk), chunkSize/4)
+		assert.LessOrEqual(t, len(chunk), chunkSize*4)
+	}
+	// Sizes should be centred on the chunk size
+	average := len(data) / len(chunks)
+	assert.InDelta(t, chunkSize, average, chunkSize/2)
+
+	// Insert a byte near the start, only the chunks around it should change
+	edited := append([]byte{data[0], 'x'}, data[1:]...)
+	editedChunks := contentDefinedChunks(t, edited, chunkSize)
+	assert.Equal(t, edited, bytes.Join(editedChunks, nil))
+	seen := make(map[string]bool)
+	for _, chunk := range chunks {
+		seen[string(chunk)] = true
+	}
+	var changed int
+	for _, chunk := range editedChunks {
+		if !seen[string(chunk)] {
+			changed++
+		}
+	}
+	assert.LessOrEqual(t, changed, 2)
+
+	// Whereas fixed chunks all shift
+	var fixed [][]byte
+	require.NoError(t, CopyChunked(func(chunk []byte) error {
+		fixed = append(fixed, append([]byte(nil), chunk...))
+		return nil
+	}, sendBytes(edited), chunkSize))
+	assert.False(t, seen[string(fixed[len(fixed)/2])])
+}
+
+func contentDefinedChunks(t *testing.T, data []byte, chunkSize int64) [][]byte {
+	var chunks [][]byte
+	err := CopyContentDefined(func(chunk []byte) error {
+		// The chunk buffer is reused
+		chunks = append(chunks, append([]byte(nil), chunk...))
+		return nil
+	}, sendBytes(data), chunkSize)
+	require.NoError(t, err)
+	return chunks
+}
+
+// Sends data in messages of an awkward size
+func sendBytes(data []byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(data) == 0 {
+			return nil, io.EOF
+		}
+		n := 1000
+		if n > len(data) {
+			n = len(data)
+		}
+		msg := data[:n]
+		data = data[n:]
+		return msg, nil
+	}
+}
@@ -59,6 +59,17 @@ func (c Client) PutSeal(ctx context.Context,
 	header *api.Header,
 	plaintextReader io.Reader,
 	opts ...grpc.CallOption) (*grant.Grant, error) {
+	return c.PutSealWithMetadata(ctx, spec, header, plaintextReader, nil, opts...)
+}
+
+// PutSealWithMetadata is like PutSeal but once plaintextReader has been read sends the header metadata returned by
+// metadata (for example the size or checksum of the plaintext), if metadata is non-nil, as a trailer
+func (c Client) PutSealWithMetadata(ctx context.Context,
+	spec *grant.Spec,
+	header *api.Header,
+	plaintextReader io.Reader,
+	metadata func() ([]byte, error),
+	opts ...grpc.CallOption) (*grant.Grant, error) {
 
 	stream, err := c.grant.PutSeal(ctx, opts...)
 	if err != nil {
@@ -85,6 +96,20 @@ func (c Client) PutSeal(ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("PutSeal: could not read and send plaintext: %w", err)
 	}
+	if metadata != nil {
+		data, err := metadata()
+		if err != nil {
+			return nil, fmt.Errorf("PutSeal: could not get metadata: %w", err)
+		}
+		err = stream.Send(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{
+				Head: &api.Header{Data: data},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("PutSeal: could not send metadata: %w", err)
+		}
+	}
 	grt, err := stream.CloseAndRecv()
 	if err != nil {
 		return nil, fmt.Errorf("PutSeal: could not close stream and get grant: %w", err)
@@ -103,10 +128,36 @@ func (c Client) UnsealGet(ctx context.Context, grt *grant.Grant,
 	if err != nil {
 		return nil, fmt.Errorf("UnsealGet: not get first frame from stream: %w", err)
 	}
-	head := first.GetHead()
+	return newPlaintextStream(ctx, "UnsealGet", first, stream), nil
+}
 
+// UnsealGetRange gets length bytes of the plaintext stored in a grant starting from offset, or the remainder of the
+// plaintext if length is zero
+func (c Client) UnsealGetRange(ctx context.Context, grt *grant.Grant, offset, length int64,
+	opts ...grpc.CallOption) (*PlaintextStream, error) {
+	stream, err := c.grant.UnsealGetRange(ctx, &api.UnsealGetRangeRequest{
+		Grant:  grt,
+		Offset: offset,
+		Length: length,
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("UnsealGetRange: could not establish stream: %w", err)
+	}
+
+	first, err := stream.Recv()
+	// A range beyond the end of a plaintext without a header is empty
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("UnsealGetRange: not get first frame from stream: %w", err)
+	}
+	return newPlaintextStream(ctx, "UnsealGetRange", first, stream), nil
+}
+
+func newPlaintextStream(ctx context.Context, method string, first *api.Plaintext, stream interface {
+	Recv() (*api.Plaintext, error)
+	CloseSend() error
+}) *PlaintextStream {
 	return &PlaintextStream{
-		Head:   head,
+		Head:   first.GetHead(),
 		closer: stream.CloseSend,
 		writeTo: func(plaintextWriter io.Writer) (int64, error) {
 			defer stream.CloseSend()
@@ -122,11 +173,11 @@ func (c Client) UnsealGet(ctx context.Context, grt *grant.Grant,
 				return plaintext.Body, nil
 			}).WithOutput(plaintextWriter).StreamCount(ctx)
 			if err != nil {
-				return n, fmt.Errorf("UnsealGet: could not receive and write plaintext: %w", err)
+				return n, fmt.Errorf("%s: could not receive and write plaintext: %w", method, err)
 			}
 			return 0, nil
 		},
-	}, nil
+	}
 }
 
 func (c Client) Seal(ctx context.Context, spec *grant.Spec, refs []*reference.Ref,
@@ -185,3 +236,7 @@ func (c Client) Reseal(ctx context.Context, in *api.GrantAndGrantSpec, opts ...g
 func (c Client) UnsealDelete(ctx context.Context, in *grant.Grant, opts ...grpc.CallOption) (api.Grant_UnsealDeleteClient, error) {
 	panic("implement me")
 }
+
+func (c Client) UnsealDeleteRecursive(ctx context.Context, in *api.UnsealDeleteRequest, opts ...grpc.CallOption) (api.Grant_UnsealDeleteRecursiveClient, error) {
+	panic("implement me")
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"github.com/monax/hoard/v8/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Dial connects to a Hoard server listening on dialURL, which has the same '<net>://<laddr>' form as the server's
+// ListenAddress. If tlsConfig is nil the connection is made without transport security.
+func Dial(dialURL string, tlsConfig *tls.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	netProtocol, localAddress, err := config.SplitListenURL(dialURL)
+	if err != nil {
+		return nil, err
+	}
+	transport := grpc.WithInsecure()
+	if tlsConfig != nil {
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+	return grpc.Dial(localAddress, append([]grpc.DialOption{
+		transport,
+		// We have to bugger around with this so we can dial an arbitrary net.Conn
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, netProtocol, localAddress)
+		}),
+	}, opts...)...)
+}
+
+// TLSConfig builds a client TLS configuration that trusts servers whose certificates are signed by one of the
+// PEM-encoded certificate authorities in caFile, or by the system roots if caFile is empty. If certFile and keyFile
+// are provided their certificate is presented to the server for mutual TLS. If serverName is non-empty it overrides the
+// host name checked against the server certificate.
+func TLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+	var err error
+	if caFile != "" {
+		tlsConfig.RootCAs, err = config.LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("a client certificate requires both a certificate and key file")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client TLS key pair: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// BearerToken returns credentials that identify the client to a Hoard server authorizing clients by token. They
+// must be used with grpc.WithPerRPCCredentials over a TLS connection.
+func BearerToken(token string) credentials.PerRPCCredentials {
+	return bearerToken(token)
+}
+
+type bearerToken string
+
+func (bt bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(bt)}, nil
+}
+
+func (bt bearerToken) RequireTransportSecurity() bool {
+	return true
+}
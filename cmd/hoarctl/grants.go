@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/monax/hoard/v8/reference"
@@ -10,25 +11,21 @@ import (
 	cli "github.com/jawher/mow.cli"
 	"github.com/monax/hoard/v8"
 	"github.com/monax/hoard/v8/api"
-	"github.com/monax/hoard/v8/grant"
 )
 
 // PutSeal encrypts and stores data then prints a grant
 func (client *Client) PutSeal(cmd *cli.Cmd) {
 	salt := addStringOpt(cmd, "salt", saltOpt)
-	key := addStringOpt(cmd, "key", keyOpt)
+	grantSpec := addGrantSpecOpts(cmd)
 	chunk := addIntOpt(cmd, "chunk", chunkOpt, chunkSize)
+	store := addStoreOpt(cmd)
+	chunker := addContentDefinedOpt(cmd)
 
 	cmd.Action = func() {
 		validateChunkSize(int64(*chunk))
 
-		spec := &grant.Spec{Plaintext: &grant.PlaintextSpec{}}
-		if *key != "" {
-			spec = &grant.Spec{
-				Plaintext: nil,
-				Symmetric: &grant.SymmetricSpec{PublicID: *key},
-			}
-		}
+		spec := grantSpec()
+		spec.Store = *store
 
 		putseal, err := client.grant.PutSeal(context.Background())
 		if err != nil {
@@ -38,7 +35,8 @@ func (client *Client) PutSeal(cmd *cli.Cmd) {
 		err = putseal.Send(&api.PlaintextAndGrantSpec{
 			Plaintext: &api.Plaintext{
 				Head: &api.Header{
-					Salt: parseSalt(salt),
+					Salt:    parseSalt(salt),
+					Chunker: chunker(),
 				},
 			},
 			GrantSpec: spec,
@@ -73,16 +71,10 @@ func (client *Client) PutSeal(cmd *cli.Cmd) {
 
 // Seal reads encrypted data then prints a grant
 func (client *Client) Seal(cmd *cli.Cmd) {
-	key := addStringOpt(cmd, "key", keyOpt)
+	grantSpec := addGrantSpecOpts(cmd)
 
 	cmd.Action = func() {
-		spec := &grant.Spec{Plaintext: &grant.PlaintextSpec{}}
-		if *key != "" {
-			spec = &grant.Spec{
-				Plaintext: nil,
-				Symmetric: &grant.SymmetricSpec{PublicID: *key},
-			}
-		}
+		spec := grantSpec()
 
 		seal, err := client.grant.Seal(context.Background())
 		if err != nil {
@@ -117,23 +109,15 @@ func (client *Client) Seal(cmd *cli.Cmd) {
 
 // Reseal reads a grant then prints a new grant
 func (client *Client) Reseal(cmd *cli.Cmd) {
-	key := addStringOpt(cmd, "key", keyOpt)
+	grantSpec := addGrantSpecOpts(cmd)
 
 	cmd.Action = func() {
 		prev := readGrant()
-		next := grant.Spec{Plaintext: &grant.PlaintextSpec{}}
-
-		if *key != "" {
-			next = grant.Spec{
-				Plaintext: nil,
-				Symmetric: &grant.SymmetricSpec{PublicID: *key},
-			}
-		}
 
 		grt, err := client.grant.Reseal(context.Background(),
 			&api.GrantAndGrantSpec{
 				Grant:     prev,
-				GrantSpec: &next,
+				GrantSpec: grantSpec(),
 			})
 
 		if err != nil {
@@ -143,6 +127,35 @@ func (client *Client) Reseal(cmd *cli.Cmd) {
 	}
 }
 
+// Annotate reads a grant then prints a new grant for the same data with the header metadata replaced
+func (client *Client) Annotate(cmd *cli.Cmd) {
+	data := addStringOpt(cmd, "data", dataOpt)
+
+	cmd.Action = func() {
+		grt, err := client.grant.Annotate(context.Background(),
+			&api.AnnotateRequest{
+				Grant: readGrant(),
+				Head:  &api.Header{Data: []byte(*data)},
+			})
+
+		if err != nil {
+			fatalf("Error annotating grant: %v", err)
+		}
+		fmt.Printf("%s\n", jsonString(grt))
+	}
+}
+
+// StatGrant reads a grant then prints a description of the data behind it
+func (client *Client) StatGrant(cmd *cli.Cmd) {
+	cmd.Action = func() {
+		stat, err := client.grant.StatGrant(context.Background(), readGrant())
+		if err != nil {
+			fatalf("Error getting grant stats: %v", err)
+		}
+		fmt.Printf("%s\n", jsonString(stat))
+	}
+}
+
 // Unseal reads a grant then prints the original reference
 func (client *Client) Unseal(cmd *cli.Cmd) {
 	cmd.Action = func() {
@@ -171,10 +184,26 @@ func (client *Client) Unseal(cmd *cli.Cmd) {
 
 // UnsealGet reads a grant, decrypts and prints the stored data
 func (client *Client) UnsealGet(cmd *cli.Cmd) {
+	offset := cmd.IntOpt("offset", 0, "The offset in bytes into the data at which to start, only the chunks "+
+		"needed to read from this offset are fetched")
+	length := cmd.IntOpt("length", 0, "The number of bytes to print, the remainder of the data is printed if omitted")
+
 	cmd.Action = func() {
 		grt := readGrant()
 
-		unsealget, err := client.grant.UnsealGet(context.Background(), grt)
+		var unsealget interface {
+			Recv() (*api.Plaintext, error)
+		}
+		var err error
+		if *offset != 0 || *length != 0 {
+			unsealget, err = client.grant.UnsealGetRange(context.Background(), &api.UnsealGetRangeRequest{
+				Grant:  grt,
+				Offset: int64(*offset),
+				Length: int64(*length),
+			})
+		} else {
+			unsealget, err = client.grant.UnsealGet(context.Background(), grt)
+		}
 		if err != nil {
 			fatalf("Error starting client: %v", err)
 		}
@@ -195,13 +224,33 @@ func (client *Client) UnsealGet(cmd *cli.Cmd) {
 	}
 }
 
-// UnsealDelete reads a grant and deletes the encrypted data
+// UnsealDelete reads a grant and deletes the encrypted data, printing the address of each chunk deleted
 func (client *Client) UnsealDelete(cmd *cli.Cmd) {
+	keepOpt := cmd.StringOpt("k keep", "", "Path to a file of JSON grants that may share data with the grant, "+
+		"any chunk they reference is not deleted")
+	forceOpt := cmd.BoolOpt("force", false, "Delete every chunk of the grant without --keep grants, "+
+		"destroying the data of any other grant that shares it")
+	cmd.Spec = "[--keep=<path to grants file>] [--force]"
+
 	cmd.Action = func() {
-		grt := readGrant()
-		_, err := client.grant.UnsealDelete(context.Background(), grt)
+		req := &api.UnsealDeleteRequest{Grant: readGrant(), Force: *forceOpt}
+		if *keepOpt != "" {
+			req.Keep = readGrantsFile(*keepOpt)
+		}
+		unsealDelete, err := client.grant.UnsealDeleteRecursive(context.Background(), req)
 		if err != nil {
 			fatalf("Error unsealing data: %v", err)
 		}
+
+		for {
+			address, err := unsealDelete.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				fatalf("Error deleting data: %v", err)
+			}
+			fmt.Printf("%s\n", jsonString(address))
+		}
 	}
 }
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cli "github.com/jawher/mow.cli"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health prints the serving status reported by hoard, failing unless it is serving
+func (client *Client) Health(cmd *cli.Cmd) {
+	service := cmd.StringOpt("service", "", "The service to check (e.g. 'api.Grant'), the server as a whole "+
+		"is checked if omitted")
+	timeout := cmd.IntOpt("t timeout", 5, "Seconds to wait for a response")
+
+	cmd.Action = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*timeout)*time.Second)
+		defer cancel()
+		resp, err := client.health.Check(ctx, &healthgrpc.HealthCheckRequest{Service: *service})
+		if err != nil {
+			fatalf("Error checking health: %v", err)
+		}
+		fmt.Println(resp.Status)
+		if resp.Status != healthgrpc.HealthCheckResponse_SERVING {
+			fatalf("Hoard is not serving")
+		}
+	}
+}
@@ -1,17 +1,17 @@
 package main
 
 import (
-	"context"
-	"net"
+	"crypto/tls"
 	"os"
 
 	"github.com/monax/hoard/v8/api"
 
 	cli "github.com/jawher/mow.cli"
+	hoardclient "github.com/monax/hoard/v8/client"
 	"github.com/monax/hoard/v8/cmd"
 	"github.com/monax/hoard/v8/config"
-	"github.com/monax/hoard/v8/server"
 	"google.golang.org/grpc"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
@@ -23,6 +23,14 @@ const (
 	secretOpt string = "The secret key to decrypt the data with as base64-encoded string."
 	chunkOpt  string = "Size in bytes to chunk upload data at."
 	fileOpt   string = "File to read"
+	dataOpt   string = "The metadata to store in the header."
+	storeOpt  string = "The name of the configured store to place data in, the default store is used if omitted."
+	cdcOpt    string = "Have the server cut chunks where a rolling hash of the data matches, rather than at fixed " +
+		"offsets, so that chunks are deduplicated with other versions of the data despite insertions and deletions."
+	envelopeOpt string = "The ID of the key held by the key-management service with which to wrap a random key " +
+		"encrypting the grant."
+	recipientOpt string = "An X25519 recipient (age1...) for which to wrap a random key encrypting the grant, may " +
+		"be repeated."
 
 	chunkSize = 64 * 1024 // 64 Kb
 )
@@ -33,6 +41,7 @@ type Client struct {
 	encryption api.EncryptionClient
 	grant      api.GrantClient
 	storage    api.StorageClient
+	health     healthgrpc.HealthClient
 }
 
 func main() {
@@ -45,18 +54,38 @@ func main() {
 			"network protocol as the scheme, for example 'tcp://localhost:54192' "+
 			"or 'unix:///tmp/hoard.sock'")
 
+	useTLS := hoarctlApp.BoolOpt("tls", false, "connect to hoard using TLS, trusting the system "+
+		"certificate authorities unless --tls-ca is given (implied by the other --tls-* options)")
+	tlsCA := hoarctlApp.StringOpt("tls-ca", "", "file containing the PEM-encoded certificate authorities "+
+		"used to verify the hoard server certificate")
+	tlsCert := hoarctlApp.StringOpt("tls-cert", "", "file containing the PEM-encoded client certificate "+
+		"to present to hoard for mutual TLS")
+	tlsKey := hoarctlApp.StringOpt("tls-key", "", "file containing the PEM-encoded private key of --tls-cert")
+	tlsServerName := hoarctlApp.StringOpt("tls-server-name", "", "name to verify the hoard server "+
+		"certificate against instead of the host in the address")
+	token := hoarctlApp.StringOpt("token", "", "bearer token identifying this client to hoard "+
+		"(requires TLS)")
+
 	client := Client{}
 	var conn *grpc.ClientConn
 
 	hoarctlApp.Before = func() {
-		netProtocol, localAddress, err := server.SplitListenURL(*dialURL)
-
-		conn, err = grpc.Dial(*dialURL,
-			grpc.WithInsecure(),
-			// We have to bugger around with this so we can dial an arbitrary net.Conn
-			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
-				return net.Dial(netProtocol, localAddress)
-			}))
+		var tlsConfig *tls.Config
+		if *useTLS || *tlsCA != "" || *tlsCert != "" || *tlsKey != "" || *tlsServerName != "" {
+			var err error
+			tlsConfig, err = hoardclient.TLSConfig(*tlsCA, *tlsCert, *tlsKey, *tlsServerName)
+			if err != nil {
+				fatalf("Could not configure TLS: %v", err)
+			}
+		}
+
+		var opts []grpc.DialOption
+		if *token != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(hoardclient.BearerToken(*token)))
+		}
+
+		var err error
+		conn, err = hoardclient.Dial(*dialURL, tlsConfig, opts...)
 		if err != nil {
 			fatalf("Could not dial hoard server on %s: %v", *dialURL, err)
 		}
@@ -64,6 +93,7 @@ func main() {
 		client.encryption = api.NewEncryptionClient(conn)
 		client.grant = api.NewGrantClient(conn)
 		client.storage = api.NewStorageClient(conn)
+		client.health = healthgrpc.NewHealthClient(conn)
 	}
 
 	cmd.AddVersionCommand(hoarctlApp)
@@ -75,6 +105,7 @@ func main() {
 	hoarctlApp.Command("stat", "Get information about the encrypted blob stored as an address from a reference passed in on STDIN "+
 		"or passed as in as a single argument as a base64 encoded string", client.Stat)
 	hoarctlApp.Command("insert", "Insert data from STDIN directly into store at its address which is written to STDOUT", client.Insert)
+	hoarctlApp.Command("ls", "List the addresses of the encrypted blobs held by a store as JSON on STDOUT", client.List)
 	hoarctlApp.Command("cat", "Retrieve the encrypted blob stored as an address from a reference passed in on STDIN or passed as in as "+
 		"a single argument as a base64 encoded string", client.Cat)
 
@@ -85,8 +116,17 @@ func main() {
 	hoarctlApp.Command("seal", "Seal some data read from STDIN and return grant on STDOUT", client.Seal)
 	hoarctlApp.Command("unseal", "Unseal grant read from STDIN and print data to STDOUT", client.Unseal)
 	hoarctlApp.Command("reseal", "Reseal grant read from STDIN and print new grant to STDOUT", client.Reseal)
+	hoarctlApp.Command("annotate", "Replace the header metadata of the data behind a grant read from STDIN, "+
+		"printing a new grant sharing the same data to STDOUT", client.Annotate)
+	hoarctlApp.Command("statgrant", "Print the size, number of chunks, and header of the data behind a grant read "+
+		"from STDIN and whether all of its chunks are stored, without fetching the data", client.StatGrant)
 	hoarctlApp.Command("putseal", "Put some data read from STDIN into encrypted data store and return a grant on STDOUT", client.PutSeal)
 	hoarctlApp.Command("unsealget", "Unseal grant read from STDIN and print decrypted data to STDOUT", client.UnsealGet)
+	hoarctlApp.Command("unsealdelete", "Unseal grant read from STDIN and delete all of its data not shared with "+
+		"the --keep grants, printing the address of each deleted blob to STDOUT", client.UnsealDelete)
+
+	hoarctlApp.Command("health", "Check whether hoard is serving requests, which requires its stores to be "+
+		"reachable, exiting with a non-zero status if not", client.Health)
 
 	hoarctlApp.Run(os.Args)
 }
@@ -4,11 +4,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/monax/hoard/v8"
 
 	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8/api"
 	"github.com/monax/hoard/v8/grant"
 	"github.com/monax/hoard/v8/reference"
 )
@@ -20,6 +22,45 @@ func addStringOpt(cmd *cli.Cmd, arg, desc string) *string {
 	return opt
 }
 
+// Adds the options selecting the secret with which to seal a grant, returning a function that makes its spec
+func addGrantSpecOpts(cmd *cli.Cmd) func() *grant.Spec {
+	key := addStringOpt(cmd, "key", keyOpt)
+	envelope := addStringOpt(cmd, "envelope", envelopeOpt)
+	recipients := cmd.StringsOpt("r recipient", nil, recipientOpt)
+	cmd.Spec += "[-r | --recipient]..."
+	return func() *grant.Spec {
+		if *key != "" {
+			return &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: *key}}
+		}
+		if *envelope != "" {
+			return &grant.Spec{Envelope: &grant.EnvelopeSpec{KeyID: *envelope}}
+		}
+		if len(*recipients) > 0 {
+			return &grant.Spec{Age: &grant.AgeSpec{Recipients: *recipients}}
+		}
+		return &grant.Spec{Plaintext: &grant.PlaintextSpec{}}
+	}
+}
+
+// Store is added separately because its short name would collide with salt
+func addStoreOpt(cmd *cli.Cmd) *string {
+	opt := cmd.StringOpt("store", "", storeOpt)
+	cmd.Spec += "[--store]"
+	return opt
+}
+
+// Selects the content-defined chunker in a header if set
+func addContentDefinedOpt(cmd *cli.Cmd) func() api.Chunker {
+	opt := cmd.BoolOpt("content-defined", false, cdcOpt)
+	cmd.Spec += "[--content-defined]"
+	return func() api.Chunker {
+		if *opt {
+			return api.Chunker_CONTENT_DEFINED
+		}
+		return api.Chunker_DEFAULT
+	}
+}
+
 func addIntOpt(cmd *cli.Cmd, arg, desc string, def int) *int {
 	opt := cmd.IntOpt(fmt.Sprintf("%s %s", string(arg[0]), arg), def, desc)
 	cmd.Spec += fmt.Sprintf("[-%s | --%s]", string(arg[0]), arg)
@@ -91,6 +132,28 @@ func readGrant() *grant.Grant {
 	return grt
 }
 
+// Decodes every JSON grant in a file
+func readGrantsFile(path string) []*grant.Grant {
+	file, err := os.Open(path)
+	if err != nil {
+		fatalf("Could not open grants file: %v", err)
+	}
+	defer file.Close()
+	var grants []*grant.Grant
+	decoder := json.NewDecoder(file)
+	for {
+		grt := new(grant.Grant)
+		err := decoder.Decode(grt)
+		if err == io.EOF {
+			return grants
+		}
+		if err != nil {
+			fatalf("Could not read grant from %s: %v", path, err)
+		}
+		grants = append(grants, grt)
+	}
+}
+
 func readBase64(base64String *string) []byte {
 	if base64String == nil {
 		return nil
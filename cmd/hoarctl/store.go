@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/monax/hoard/v8/stores"
@@ -27,7 +28,7 @@ func (client *Client) Cat(cmd *cli.Cmd) {
 				return err
 			}
 			for _, ref := range *refs {
-				err := pull.Send(&api.Address{Address: ref.Address})
+				err := pull.Send(&api.Address{Address: ref.Address, Store: ref.Store})
 				if err != nil {
 					return err
 				}
@@ -109,6 +110,8 @@ func (client *Client) Put(cmd *cli.Cmd) {
 	// TODO: check if salt is too big
 	salt := addStringOpt(cmd, "salt", saltOpt)
 	chunk := addIntOpt(cmd, "chunk", chunkOpt, chunkSize)
+	store := addStoreOpt(cmd)
+	chunker := addContentDefinedOpt(cmd)
 
 	cmd.Action = func() {
 		validateChunkSize(int64(*chunk))
@@ -118,7 +121,7 @@ func (client *Client) Put(cmd *cli.Cmd) {
 			fatalf("Error starting client: %v", err)
 		}
 
-		err = put.Send(&api.Plaintext{Head: &api.Header{Salt: parseSalt(salt)}})
+		err = put.Send(&api.Plaintext{Head: &api.Header{Salt: parseSalt(salt), Store: *store, Chunker: chunker()}})
 		if err != nil {
 			fatalf("Error sending head: %v", err)
 		}
@@ -148,6 +151,7 @@ func (client *Client) Delete(cmd *cli.Cmd) {
 			_, err := client.storage.Delete(context.Background(),
 				&api.Address{
 					Address: ref.Address,
+					Store:   ref.Store,
 				})
 			return err
 		})).Stream(context.Background())
@@ -164,7 +168,7 @@ func (client *Client) Stat(cmd *cli.Cmd) {
 		var statInfos []*stores.StatInfo
 		err := hoard.NewStreamer().WithSend(readReferences(func(ref *reference.Ref) error {
 			statInfo, err := client.storage.Stat(context.Background(),
-				&api.Address{Address: ref.Address})
+				&api.Address{Address: ref.Address, Store: ref.Store})
 			if err != nil {
 				return err
 			}
@@ -178,3 +182,39 @@ func (client *Client) Stat(cmd *cli.Cmd) {
 		}
 	}
 }
+
+// List prints the address of each blob held by a store
+func (client *Client) List(cmd *cli.Cmd) {
+	prefix := cmd.StringOpt("p prefix", "", "Only list addresses beginning with this base64-encoded prefix")
+	after := cmd.StringOpt("after", "", "Continue listing from after this base64-encoded address (the last "+
+		"address printed by a previous listing)")
+	limit := cmd.IntOpt("n limit", 0, "The maximum number of addresses to list, all are listed if omitted")
+	store := cmd.StringOpt("store", "", "The name of the configured store to list, the default store is "+
+		"listed if omitted")
+
+	cmd.Spec = "[--prefix=<base64 prefix>] [--after=<base64 address>] [--limit=<number of addresses>] " +
+		"[--store=<store name>]"
+
+	cmd.Action = func() {
+		list, err := client.storage.List(context.Background(), &api.ListRequest{
+			Store:  *store,
+			Prefix: readBase64(prefix),
+			After:  readBase64(after),
+			Limit:  int64(*limit),
+		})
+		if err != nil {
+			fatalf("Error starting client: %v", err)
+		}
+
+		for {
+			address, err := list.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				fatalf("Error listing addresses: %v", err)
+			}
+			fmt.Printf("%s\n", jsonString(address))
+		}
+	}
+}
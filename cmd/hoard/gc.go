@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8"
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/reference"
+)
+
+func GarbageCollect(load func() *components) func(cmd *cli.Cmd) {
+	return func(cmd *cli.Cmd) {
+		dryRunOpt := cmd.BoolOpt("n dry-run", false,
+			"Only print the addresses that would be deleted")
+
+		storeOpt := cmd.StringOpt("store", "",
+			"Name of the store to collect, if omitted the default store is collected")
+
+		rootsOpt := cmd.StringOpt("r roots", "",
+			"Path to a file of JSON grants to treat as roots, if omitted the grants are read from STDIN")
+
+		allOpt := cmd.BoolOpt("all", false,
+			"Collect even if no root grants are given, deleting everything in the store")
+
+		cmd.Spec = "[--dry-run] [--store=<store name>] [--roots=<path to roots file>] [--all]"
+
+		cmd.Action = func() {
+			comps := load()
+			hrd := hoard.NewRoutingHoard(comps.store, comps.routes, comps.secretsManager, comps.logger)
+
+			input := os.Stdin
+			if *rootsOpt != "" {
+				file, err := os.Open(*rootsOpt)
+				if err != nil {
+					fatalf("Could not open roots file: %v", err)
+				}
+				defer file.Close()
+				input = file
+			}
+
+			roots, err := readRoots(hrd, input)
+			if err != nil {
+				fatalf("Could not read root grants: %v", err)
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			count := 0
+			err = hoard.CollectGarbage(hrd, *storeOpt, roots, *dryRunOpt, *allOpt, func(address []byte) error {
+				count++
+				return encoder.Encode(&api.Address{Address: address, Store: *storeOpt})
+			})
+			if err != nil {
+				fatalf("Could not collect garbage: %v", err)
+			}
+			if *dryRunOpt {
+				printf("Found %d unreachable addresses", count)
+			} else {
+				printf("Deleted %d unreachable addresses", count)
+			}
+		}
+	}
+}
+
+// Decodes a stream of JSON grants and unseals each of them
+func readRoots(grantService hoard.GrantService, reader io.Reader) ([]*reference.Ref, error) {
+	var roots []*reference.Ref
+	decoder := json.NewDecoder(reader)
+	for {
+		grt := new(grant.Grant)
+		err := decoder.Decode(grt)
+		if err != nil {
+			if err == io.EOF {
+				return roots, nil
+			}
+			return nil, err
+		}
+		refs, err := grantService.Unseal(grt)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, refs...)
+	}
+}
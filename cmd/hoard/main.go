@@ -5,13 +5,16 @@ import (
 	"io/ioutil"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/go-kit/kit/log"
 	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8/api"
 	"github.com/monax/hoard/v8/cmd"
 	"github.com/monax/hoard/v8/config"
 	"github.com/monax/hoard/v8/server"
+	"github.com/monax/hoard/v8/stores"
 )
 
 func main() {
@@ -34,7 +37,8 @@ func main() {
 			config.DefaultJSONConfigEnvironmentVariable))
 
 	secretsFromEnv := hoardApp.BoolOpt("env-secrets", false,
-		fmt.Sprintf("Decode the environment variables pointed to by the symmetric public IDs."))
+		fmt.Sprintf("Decode the environment variables pointed to by the symmetric public IDs as passphrases, "+
+			"or as base64 keys if prefixed with '%s'.", config.EnvSecretKeyPrefix))
 
 	// This string spec is parsed by mow.cli and has actual semantic significance
 	// around optionality and ordering of options and arguments
@@ -42,51 +46,73 @@ func main() {
 
 	cmd.AddVersionCommand(hoardApp)
 
+	load := func() *components {
+		return loadComponents(*environmentOpt, *configFileOpt, *loggingOpt, *secretsFromEnv)
+	}
+
 	hoardApp.Action = func() {
-		conf, err := hoardConfigCascade(*environmentOpt, *configFileOpt).Get(nil)
-		if err != nil {
-			fatalf("Could not get Hoard config: %s", err)
-		}
+		comps := load()
+		conf := comps.conf
 
-		// I can't think of a good reason to allow this...
-		if conf.ChunkSize == 0 {
-			conf.ChunkSize = config.DefaultChunkSize
+		if *listenAddressOpt != "" {
+			conf.ListenAddress = *listenAddressOpt
 		}
 
-		var logger log.Logger
-		if *loggingOpt {
-			logger, err = config.Logger(conf.Logging, os.Stderr)
+		chunker, err := parseChunker(conf.Chunker)
+		if err != nil {
+			fatalf("Invalid chunker config: %v", err)
+		}
+		options := []server.Option{server.WithHealthCheckInterval(conf.HealthCheckInterval()),
+			server.WithPrefetch(conf.Prefetch()), server.WithEncryptWorkers(conf.Workers()),
+			server.WithChunker(chunker)}
+		if conf.LogUploadStats {
+			options = append(options, server.WithUploadStats())
+		}
+		if conf.Metrics != nil {
+			option, err := serveMetrics(conf.Metrics, comps)
 			if err != nil {
-				fatalf("Could not create logging form logging config: %s", err)
+				fatalf("Could not configure metrics: %v", err)
 			}
+			options = append(options, option)
 		}
-
-		store, err := StoreFromStorageConfig(conf.Storage, logger)
-		if err != nil {
-			fatalf("Could not configure store from storage config: %s", err)
+		if conf.TLS != nil {
+			tlsConfig, err := conf.TLS.ServerConfig()
+			if err != nil {
+				fatalf("Could not configure TLS: %v", err)
+			}
+			options = append(options, server.WithTLS(tlsConfig))
 		}
-
-		if *listenAddressOpt != "" {
-			conf.ListenAddress = *listenAddressOpt
+		if conf.Gateway != nil {
+			options = append(options, server.WithHTTPGateway(conf.Gateway.ListenAddress))
 		}
-
-		symmetricProvider, err := config.NewSymmetricProvider(conf.Secrets, *secretsFromEnv)
-		if err != nil {
-			fatalf("Could not load symmetric keys: %s", err)
+		if conf.Authorization != nil {
+			err := conf.Authorization.Validate()
+			if err != nil {
+				fatalf("Invalid authorization config: %v", err)
+			}
+			if conf.Authorization.HasSubjects() && (conf.TLS == nil || conf.TLS.ClientCAFile == "") {
+				fatalf("Authorization policies with Subjects require TLS with a ClientCAFile")
+			}
+			options = append(options, server.WithAuthorization(conf.Authorization, comps.logger))
 		}
-		openPGPConf := config.NewOpenPGPSecret(conf.Secrets)
-		secretsManager := config.SecretsManager{Provider: symmetricProvider, OpenPGP: openPGPConf}
 
-		serv := server.New(conf.ListenAddress, store, secretsManager, conf.ChunkSize, logger)
+		store := comps.store
+		serv := server.New(conf.ListenAddress, store, comps.routes, comps.secretsManager, conf.ChunkSize,
+			comps.logger, options...)
 		// Catch interrupt etc
 		signalCh := make(chan os.Signal, 1)
-		signal.Notify(signalCh, os.Interrupt, os.Kill, syscall.SIGTERM)
+		signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+		stopped := make(chan struct{})
 		go func(c chan os.Signal) {
 			sig := <-c
-			printf("\nCaught %s signal: shutting down...", sig)
+			printf("\nCaught %s signal: shutting down, waiting up to %v for in-flight requests...", sig,
+				conf.DrainTimeout())
 			// Make sure we clean up
-			serv.Stop()
-			os.Exit(0)
+			err := serv.GracefulStop(conf.DrainTimeout())
+			if err != nil {
+				printf("%v", err)
+			}
+			close(stopped)
 		}(signalCh)
 
 		printf("Starting hoard daemon on %s with chunk size %d on %s...", conf.ListenAddress, conf.ChunkSize,
@@ -95,15 +121,108 @@ func main() {
 		if err != nil {
 			fatalf("Could not start hoard server: %s", err)
 		}
+		// Serve returns as soon as we stop listening so wait for in-flight requests
+		<-stopped
 	}
 
 	hoardApp.Command("config", "Initialise Hoard configuration by "+
 		"printing an example configuration file to STDOUT. Most config files emitted are "+
 		"examples demonstrating some features and need to be edited.", Config)
 
+	hoardApp.Command("gc", "Delete (or with --dry-run just list) any data in a store that is not reachable "+
+		"from a set of root grants. The root grants are read as JSON from STDIN unless a roots file is given. "+
+		"Hoard must not be serving writes to the store while garbage is collected.", GarbageCollect(load))
+
+	hoardApp.Command("scrub", "Check every blob in a store against its address, printing a JSON report of "+
+		"each blob that is unreadable, truncated, or corrupted along with its location", Scrub(load))
+
+	hoardApp.Command("migrate", "Copy every blob from one store to another, verifying each against its "+
+		"address. Blobs already in the destination store are skipped so an interrupted migration can be resumed.",
+		Migrate)
+
+	hoardApp.Command("rotate", "Reseal symmetric grants with the newest version of their secret's key, reading JSON "+
+		"grants from STDIN unless a grants file is given and printing every grant (resealed or not) in the same order",
+		Rotate(load))
+
 	hoardApp.Run(os.Args)
 }
 
+// The configured parts of a Hoard daemon
+type components struct {
+	conf           *config.HoardConfig
+	logger         log.Logger
+	store          stores.NamedStore
+	routes         map[string]stores.NamedStore
+	secretsManager config.SecretsManager
+}
+
+func loadComponents(environment bool, configFile string, logging, secretsFromEnv bool) *components {
+	conf, err := hoardConfigCascade(environment, configFile).Get(nil)
+	if err != nil {
+		fatalf("Could not get Hoard config: %s", err)
+	}
+
+	// I can't think of a good reason to allow this...
+	if conf.ChunkSize == 0 {
+		conf.ChunkSize = config.DefaultChunkSize
+	}
+
+	var logger log.Logger
+	if logging {
+		logger, err = config.Logger(conf.Logging, os.Stderr)
+		if err != nil {
+			fatalf("Could not create logging form logging config: %s", err)
+		}
+	}
+
+	store, err := StoreFromStorageConfig(conf.Storage, logger)
+	if err != nil {
+		fatalf("Could not configure store from storage config: %s", err)
+	}
+
+	routes, err := StoresFromNamedStorageConfigs(conf.Stores, logger)
+	if err != nil {
+		fatalf("Could not configure named stores: %s", err)
+	}
+
+	symmetricBackend, err := SymmetricBackendFromSecretsConfig(conf.Secrets, secretsFromEnv)
+	if err != nil {
+		fatalf("Could not load symmetric keys: %s", err)
+	}
+	keyWrapper, err := KeyWrapperFromSecretsConfig(conf.Secrets, symmetricBackend)
+	if err != nil {
+		fatalf("Could not configure key wrapping: %s", err)
+	}
+	ageIdentities, err := config.NewAgeIdentities(conf.Secrets)
+	if err != nil {
+		fatalf("Could not load age identities: %s", err)
+	}
+	openPGPConf := config.NewOpenPGPSecret(conf.Secrets)
+	secretsManager := config.NewSecretsManager(symmetricBackend, openPGPConf)
+	secretsManager.KeyWrapper = keyWrapper
+	secretsManager.Age = ageIdentities
+
+	return &components{
+		conf:           conf,
+		logger:         logger,
+		store:          store,
+		routes:         routes,
+		secretsManager: secretsManager,
+	}
+}
+
+// Parses a chunker name from config such as 'content-defined', an empty name selects the default chunker
+func parseChunker(name string) (api.Chunker, error) {
+	if name == "" {
+		return api.Chunker_DEFAULT, nil
+	}
+	chunker, ok := api.Chunker_value[strings.ToUpper(strings.Replace(name, "-", "_", -1))]
+	if !ok {
+		return 0, fmt.Errorf("unknown chunker '%s', expected 'fixed' or 'content-defined'", name)
+	}
+	return api.Chunker(chunker), nil
+}
+
 // Print informational output to Stderr
 func printf(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/server"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// The label given to the default store in metrics, named stores are labelled with their name
+const defaultStoreLabel = "default"
+
+// Instruments the stores in comps and returns a server option instrumenting the server, starting an HTTP listener
+// that serves the metrics as configured by conf
+func serveMetrics(conf *config.Metrics, comps *components) (server.Option, error) {
+	registry := prometheus.NewRegistry()
+	serverMetrics := server.NewMetrics()
+	storeMetrics := stores.NewStoreMetrics()
+	for _, collector := range []prometheus.Collector{
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		serverMetrics,
+		storeMetrics,
+	} {
+		err := registry.Register(collector)
+		if err != nil {
+			return nil, fmt.Errorf("could not register metrics: %w", err)
+		}
+	}
+
+	comps.store = stores.NewMetricsStore(comps.store, defaultStoreLabel, storeMetrics)
+	for name, store := range comps.routes {
+		comps.routes[name] = stores.NewMetricsStore(store, name, storeMetrics)
+	}
+
+	listener, err := net.Listen("tcp", conf.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("could not listen for metrics requests: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(conf.MetricsPath(), promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	go func() {
+		err := http.Serve(listener, mux)
+		if err != nil {
+			fatalf("Metrics server failed: %v", err)
+		}
+	}()
+	printf("Serving metrics on http://%s%s", listener.Addr(), conf.MetricsPath())
+	return server.WithMetrics(serverMetrics), nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"crypto/sha256"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/stores"
+)
+
+// How often to report progress
+const migrateProgressInterval = 1000
+
+func Migrate(cmd *cli.Cmd) {
+	fromOpt := cmd.StringOpt("f from", "", "Path to a config file whose Storage section describes the store to "+
+		"copy from, which must support listing")
+	toOpt := cmd.StringOpt("t to", "", "Path to a config file whose Storage section describes the store to copy to")
+	concurrencyOpt := cmd.IntOpt("n concurrency", 8, "The maximum number of blobs to copy at once")
+
+	cmd.Spec = "--from=<path to config file> --to=<path to config file> [--concurrency=<number of copies>]"
+
+	cmd.Action = func() {
+		from := storeFromConfigFile(*fromOpt)
+		to := storeFromConfigFile(*toOpt)
+		printf("Migrating from %s to %s...", from.Name(), to.Name())
+
+		var last stores.MigrationProgress
+		err := stores.Migrate(from, to, stores.MakeAddresser(sha256.New), *concurrencyOpt,
+			func(progress stores.MigrationProgress) {
+				last = progress
+				if progress.Processed()%migrateProgressInterval == 0 {
+					printf("Processed %d blobs: %v", progress.Processed(), progress)
+				}
+			})
+		if err != nil {
+			fatalf("Migration failed after processing %d blobs (%v), it can be resumed by running it again: %v",
+				last.Processed(), last, err)
+		}
+		printf("Migrated %d blobs: %v", last.Processed(), last)
+	}
+}
+
+func storeFromConfigFile(configFile string) stores.NamedStore {
+	conf, err := config.File(configFile).Get(nil)
+	if err != nil {
+		fatalf("Could not read config file '%s': %v", configFile, err)
+	}
+	if conf.Storage == nil {
+		fatalf("Config file '%s' has no Storage section describing the store to migrate", configFile)
+	}
+	store, err := StoreFromStorageConfig(conf.Storage, nil)
+	if err != nil {
+		fatalf("Could not configure store from '%s': %v", configFile, err)
+	}
+	return store
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8/grant"
+)
+
+func Rotate(load func() *components) func(cmd *cli.Cmd) {
+	return func(cmd *cli.Cmd) {
+		secretOpt := cmd.StringOpt("s secret", "",
+			"Only reseal grants sealed with the symmetric secret having this PublicID")
+
+		grantsOpt := cmd.StringOpt("g grants", "",
+			"Path to a file of JSON grants to reseal, if omitted the grants are read from STDIN")
+
+		cmd.Spec = "[--secret=<PublicID>] [--grants=<path to grants file>]"
+
+		cmd.Action = func() {
+			comps := load()
+
+			input := os.Stdin
+			if *grantsOpt != "" {
+				file, err := os.Open(*grantsOpt)
+				if err != nil {
+					fatalf("Could not open grants file: %v", err)
+				}
+				defer file.Close()
+				input = file
+			}
+
+			decoder := json.NewDecoder(input)
+			encoder := json.NewEncoder(os.Stdout)
+			count, rotated := 0, 0
+			for {
+				grt := new(grant.Grant)
+				err := decoder.Decode(grt)
+				if err != nil {
+					if err == io.EOF {
+						break
+					}
+					fatalf("Could not read grant %d: %v", count, err)
+				}
+				resealed := false
+				if *secretOpt == "" || grt.GetSpec().GetSymmetric().GetPublicID() == *secretOpt {
+					grt, resealed, err = grant.Rotate(comps.secretsManager, grt)
+					if err != nil {
+						fatalf("Could not reseal grant %d: %v", count, err)
+					}
+				}
+				count++
+				if resealed {
+					rotated++
+				}
+				err = encoder.Encode(grt)
+				if err != nil {
+					fatalf("Could not write grant %d: %v", count, err)
+				}
+			}
+			printf("Resealed %d of %d grants", rotated, count)
+		}
+	}
+}
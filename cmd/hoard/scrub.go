@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/monax/hoard/v8"
+	"github.com/monax/hoard/v8/stores"
+)
+
+func Scrub(load func() *components) func(cmd *cli.Cmd) {
+	return func(cmd *cli.Cmd) {
+		storeOpt := cmd.StringOpt("store", "",
+			"Name of the store to scrub, if omitted the default store is scrubbed")
+
+		rootsOpt := cmd.StringOpt("r roots", "",
+			"Path to a file of JSON grants whose refs give the expected size of each blob so that truncated "+
+				"blobs can be told apart from corrupted ones")
+
+		cmd.Spec = "[--store=<store name>] [--roots=<path to roots file>]"
+
+		cmd.Action = func() {
+			comps := load()
+			store := comps.store
+			if *storeOpt != "" {
+				var ok bool
+				store, ok = comps.routes[*storeOpt]
+				if !ok {
+					fatalf("%v", stores.ErrorStoreNotFound(*storeOpt))
+				}
+			}
+
+			var expectedSizes map[string]int64
+			if *rootsOpt != "" {
+				file, err := os.Open(*rootsOpt)
+				if err != nil {
+					fatalf("Could not open roots file: %v", err)
+				}
+				defer file.Close()
+				hrd := hoard.NewRoutingHoard(comps.store, comps.routes, comps.secretsManager, comps.logger)
+				roots, err := readRoots(hrd, file)
+				if err != nil {
+					fatalf("Could not read root grants: %v", err)
+				}
+				expectedSizes = hoard.CiphertextSizes(hrd, *storeOpt, roots)
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			problems := 0
+			checked, err := stores.Scrub(store, stores.MakeAddresser(sha256.New), expectedSizes,
+				func(report *stores.ScrubReport) error {
+					problems++
+					return encoder.Encode(report)
+				})
+			if err != nil {
+				fatalf("Could not scrub %s: %v", store.Name(), err)
+			}
+			if problems > 0 {
+				fatalf("Found %d problems in %d blobs checked in %s", problems, checked, store.Name())
+			}
+			printf("Checked %d blobs in %s and found no problems", checked, store.Name())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/secrets/keywrap"
+	"github.com/monax/hoard/v8/secrets/vault"
+)
+
+func SymmetricBackendFromSecretsConfig(secretsConfig *config.Secrets, fromEnv bool) (config.SymmetricBackend, error) {
+	if secretsConfig == nil || secretsConfig.Vault == nil {
+		return config.NewSymmetricVersionProvider(secretsConfig, fromEnv)
+	}
+	if len(secretsConfig.Symmetric) > 0 {
+		return nil, errors.New("symmetric secrets cannot be listed in config when they are read from vault")
+	}
+	return vault.NewClient(secretsConfig.Vault)
+}
+
+// Returns the key wrapper for envelope grants if one is configured, the Vault client serving symmetric secrets is
+// reused if the key wrapper is also Vault
+func KeyWrapperFromSecretsConfig(secretsConfig *config.Secrets, backend config.SymmetricBackend) (config.KeyWrapper,
+	error) {
+	if secretsConfig == nil || secretsConfig.KeyWrapping == nil {
+		return nil, nil
+	}
+	conf := secretsConfig.KeyWrapping
+	switch {
+	case conf.Vault:
+		if client, ok := backend.(*vault.Client); ok {
+			return client, nil
+		}
+		return nil, errors.New("key wrapping with vault requires a Vault section in Secrets")
+	case conf.URL != "":
+		return keywrap.NewHTTPWrapper(conf.URL, conf.Token, conf.CAFile)
+	case conf.Directory != "":
+		return keywrap.NewFileWrapper(conf.Directory)
+	}
+	return nil, errors.New("key wrapping config must provide a Directory, a URL, or Vault")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 
@@ -12,6 +13,17 @@ import (
 )
 
 func StoreFromStorageConfig(storageConfig *config.Storage, logger log.Logger) (stores.NamedStore, error) {
+	store, err := storeFromStorageConfig(storageConfig, logger)
+	if err != nil || !storageConfig.VerifyIntegrity {
+		return store, err
+	}
+	if storageConfig.StorageType == config.IPFS {
+		return nil, errors.New("integrity verification is not supported for ipfs storage")
+	}
+	return stores.NewVerifyingStore(sha256.New, store), nil
+}
+
+func storeFromStorageConfig(storageConfig *config.Storage, logger log.Logger) (stores.NamedStore, error) {
 	addressEncoding, err := stores.GetAddressEncoding(storageConfig.AddressEncoding)
 	if err != nil {
 		return nil, err
@@ -66,8 +78,44 @@ func StoreFromStorageConfig(storageConfig *config.Storage, logger log.Logger) (s
 		}
 		return cloud.NewStore(cloud.GCP, gcpConf.Bucket, gcpConf.Prefix, gcpConf.Region, addressEncoding, logger)
 
+	case config.Replicated:
+		replicatedConf := storageConfig.ReplicatedConfig
+		if replicatedConf == nil || len(replicatedConf.Replicas) == 0 {
+			return nil, errors.New("replicated storage configuration must list at least one replica")
+		}
+		replicas := make([]stores.NamedStore, len(replicatedConf.Replicas))
+		for i, replicaConf := range replicatedConf.Replicas {
+			replicas[i], err = StoreFromStorageConfig(replicaConf, logger)
+			if err != nil {
+				return nil, fmt.Errorf("could not configure replica %d: %w", i, err)
+			}
+		}
+		return stores.NewReplicatingStore(replicatedConf.WriteQuorum, logger, replicas...)
+
 	default:
 		return nil, fmt.Errorf("did not recognise storage type '%s'",
 			storageConfig.StorageType)
 	}
 }
+
+func StoresFromNamedStorageConfigs(namedStorageConfigs []*config.NamedStorage,
+	logger log.Logger) (map[string]stores.NamedStore, error) {
+	routes := make(map[string]stores.NamedStore, len(namedStorageConfigs))
+	for _, namedStorageConfig := range namedStorageConfigs {
+		if namedStorageConfig.Name == "" {
+			return nil, errors.New("named stores must be given a non-empty name")
+		}
+		if _, ok := routes[namedStorageConfig.Name]; ok {
+			return nil, fmt.Errorf("store name '%s' is used more than once", namedStorageConfig.Name)
+		}
+		if namedStorageConfig.Storage == nil {
+			return nil, fmt.Errorf("no storage configuration supplied for store '%s'", namedStorageConfig.Name)
+		}
+		store, err := StoreFromStorageConfig(namedStorageConfig.Storage, logger)
+		if err != nil {
+			return nil, fmt.Errorf("could not configure store '%s': %w", namedStorageConfig.Name, err)
+		}
+		routes[namedStorageConfig.Name] = store
+	}
+	return routes, nil
+}
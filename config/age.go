@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/monax/hoard/v8/encryption"
+)
+
+// AgeSecret lists the X25519 identities (age secret keys) with which Hoard can unseal age grants, the recipients of
+// these identities are used to seal age grants that name no recipients of their own
+type AgeSecret struct {
+	// Identities encoded as by age-keygen such as 'AGE-SECRET-KEY-1...'
+	Identities []string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// A file of identities as written by age-keygen, one per line with blank lines and lines starting '#' ignored
+	File string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
+
+// NewAgeIdentities parses the configured age identities and those read from the configured file
+func NewAgeIdentities(conf *Secrets) ([]*encryption.X25519Identity, error) {
+	if conf == nil || conf.Age == nil {
+		return nil, nil
+	}
+	encoded := conf.Age.Identities
+	if conf.Age.File != "" {
+		data, err := ioutil.ReadFile(conf.Age.File)
+		if err != nil {
+			return nil, fmt.Errorf("could not read age identities: %v", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			encoded = append(encoded, line)
+		}
+	}
+	identities := make([]*encryption.X25519Identity, len(encoded))
+	for i, s := range encoded {
+		id, err := encryption.ParseX25519Identity(s)
+		if err != nil {
+			return nil, err
+		}
+		identities[i] = id
+	}
+	return identities, nil
+}
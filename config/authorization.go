@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Matches any method or secret in a Policy
+const AuthorizeAll = "*"
+
+// Authorization restricts the methods and secrets that clients of the Hoard API may use. A client is granted the union
+// of the permissions of every policy that applies to it.
+type Authorization struct {
+	Policies []*Policy
+}
+
+// Policy grants permissions to the clients it applies to
+type Policy struct {
+	// Name used to identify the policy in logs
+	Name string
+	// Applies to clients presenting a verified TLS client certificate with one of these subject common names (requires
+	// the TLS ClientCAFile to be set)
+	Subjects []string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Applies to clients presenting one of these tokens as 'authorization: Bearer <token>' metadata
+	Tokens []string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Applies to every client whether or not it is identified
+	Anonymous bool `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Services (e.g. 'Cleartext') or methods (e.g. 'Storage/Stat') that may be called, or '*' for all of them
+	Methods []string
+	// PublicIDs of the symmetric secrets (or KeyIDs of the envelope keys, or recipients of the age identities) that may
+	// be used to seal or unseal grants, or '*' for all of them
+	SecretIDs []string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
+
+func NewAuthorization(policies ...*Policy) *Authorization {
+	return &Authorization{
+		Policies: policies,
+	}
+}
+
+// Validate checks that each policy applies to some client
+func (auth *Authorization) Validate() error {
+	names := make(map[string]struct{}, len(auth.Policies))
+	for i, policy := range auth.Policies {
+		if policy.Name == "" {
+			return fmt.Errorf("authorization policy %d must be given a name", i)
+		}
+		if _, ok := names[policy.Name]; ok {
+			return fmt.Errorf("authorization policy name '%s' is used more than once", policy.Name)
+		}
+		names[policy.Name] = struct{}{}
+		if !policy.Anonymous && len(policy.Subjects) == 0 && len(policy.Tokens) == 0 {
+			return fmt.Errorf("authorization policy '%s' must list Subjects or Tokens or be Anonymous", policy.Name)
+		}
+		for _, token := range policy.Tokens {
+			if token == "" {
+				return fmt.Errorf("authorization policy '%s' contains an empty token", policy.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Whether any policy identifies clients by their certificate subject
+func (auth *Authorization) HasSubjects() bool {
+	for _, policy := range auth.Policies {
+		if len(policy.Subjects) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationValidate(t *testing.T) {
+	assert.Error(t, NewAuthorization(&Policy{Methods: []string{"Grant"}}).Validate())
+	assert.Error(t, NewAuthorization(&Policy{Name: "nobody", Methods: []string{"Grant"}}).Validate())
+	assert.Error(t, NewAuthorization(
+		&Policy{Name: "dup", Anonymous: true},
+		&Policy{Name: "dup", Anonymous: true}).Validate())
+	assert.NoError(t, NewAuthorization(&Policy{Name: "anyone", Anonymous: true}).Validate())
+}
+
+func TestAuthorizationConfig(t *testing.T) {
+	conf := NewHoardConfig(DefaultListenAddress, DefaultChunkSize, NewDefaultStorage(), DefaultLogging)
+	conf.Authorization = NewAuthorization(&Policy{
+		Name:      "admin",
+		Subjects:  []string{"admin"},
+		Methods:   []string{AuthorizeAll},
+		SecretIDs: []string{"private"},
+	})
+
+	confOut, err := HoardConfigFromTOMLString(conf.TOMLString())
+	assert.NoError(t, err)
+	assert.Equal(t, conf.Authorization, confOut.Authorization)
+	assert.True(t, confOut.Authorization.HasSubjects())
+}
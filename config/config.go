@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	yaml "gopkg.in/yaml.v2"
@@ -13,15 +15,47 @@ const DefaultListenAddress = "tcp://:53431"
 
 const DefaultChunkSize = 3 * (1 << 20) // 3 MiB
 
+const DefaultDrainTimeout = 20 * time.Second
+
+const DefaultHealthCheckInterval = 10 * time.Second
+
+const DefaultPrefetchChunks = 4
+
+const DefaultEncryptWorkers = 4
+
 var DefaultHoardConfig = NewHoardConfig(DefaultListenAddress, DefaultChunkSize, NewDefaultStorage(), DefaultLogging)
 
 type HoardConfig struct {
 	ListenAddress string
+	// If provided the server will only accept TLS connections
+	TLS *TLS `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided clients may only call the methods and use the secrets granted to them by its policies
+	Authorization *Authorization `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
 	// Chunk size for data upload / download
 	ChunkSize int64
-	Storage   *Storage
-	Logging   *Logging
-	Secrets   *Secrets
+	// The number of chunks fetched and decrypted concurrently when streaming plaintext, 1 fetches them one at a time
+	PrefetchChunks int `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// The number of chunks encrypted and stored concurrently when receiving plaintext, 1 stores them one at a time
+	EncryptWorkers int `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// How plaintext is split into chunks unless its header selects a chunker, either 'fixed' (the default) or
+	// 'content-defined' for chunks of ChunkSize on average cut where a rolling hash matches to improve deduplication
+	Chunker string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	Storage *Storage
+	// Additional named back-ends that grants and headers may select instead of the default Storage
+	Stores  []*NamedStorage
+	Logging *Logging
+	Secrets *Secrets
+	// If provided REST endpoints are served over HTTP in addition to the gRPC API
+	Gateway *Gateway `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided Prometheus metrics are served over HTTP
+	Metrics *Metrics `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Seconds to wait for in-flight requests to finish when shutting down before they are cancelled
+	DrainTimeoutSeconds int64 `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Seconds between the checks that the stores are reachable which determine the health reported by the server
+	HealthCheckIntervalSeconds int64 `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If set the server logs how many of the chunks in each upload were already stored, which requires checking for
+	// each chunk before storing it
+	LogUploadStats bool `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
 }
 
 func NewHoardConfig(listenAddress string, chunkSize int64, storageConfig *Storage, loggingConfig *Logging) *HoardConfig {
@@ -33,6 +67,34 @@ func NewHoardConfig(listenAddress string, chunkSize int64, storageConfig *Storag
 	}
 }
 
+func (hoardConfig *HoardConfig) DrainTimeout() time.Duration {
+	if hoardConfig.DrainTimeoutSeconds <= 0 {
+		return DefaultDrainTimeout
+	}
+	return time.Duration(hoardConfig.DrainTimeoutSeconds) * time.Second
+}
+
+func (hoardConfig *HoardConfig) Prefetch() int {
+	if hoardConfig.PrefetchChunks <= 0 {
+		return DefaultPrefetchChunks
+	}
+	return hoardConfig.PrefetchChunks
+}
+
+func (hoardConfig *HoardConfig) Workers() int {
+	if hoardConfig.EncryptWorkers <= 0 {
+		return DefaultEncryptWorkers
+	}
+	return hoardConfig.EncryptWorkers
+}
+
+func (hoardConfig *HoardConfig) HealthCheckInterval() time.Duration {
+	if hoardConfig.HealthCheckIntervalSeconds <= 0 {
+		return DefaultHealthCheckInterval
+	}
+	return time.Duration(hoardConfig.HealthCheckIntervalSeconds) * time.Second
+}
+
 func HoardConfigFromYAMLString(yamlString string) (*HoardConfig, error) {
 	hoardConfig := new(HoardConfig)
 	buf := bytes.NewBufferString(yamlString)
@@ -93,3 +155,24 @@ func (hoardConfig *HoardConfig) YAMLString() string {
 	}
 	return buf.String()
 }
+
+// SplitListenURL splits a URL of the form '<net>://<laddr>' into the network and address expected by net.Listen
+// and net.Dial
+func SplitListenURL(listenOn string) (string, string, error) {
+	// net.Listen does not want a parsed url.URL so it seems to make more sense
+	// just to do a dumb split here to support the various networks
+	listenParts := strings.Split(listenOn, "://")
+	if len(listenParts) != 2 {
+		return "", "", fmt.Errorf("expected a Go net.Listen URL of the form "+
+			"'<net>://<laddr>', but got: '%s'", listenOn)
+	}
+	if listenParts[0] == "" {
+		return "", "", fmt.Errorf("expected the URL scheme to be present, "+
+			"but got '%s'", listenOn)
+	}
+	if listenParts[1] == "" {
+		return "", "", fmt.Errorf("expected the URL host to be present, "+
+			"but got '%s'", listenOn)
+	}
+	return listenParts[0], listenParts[1], nil
+}
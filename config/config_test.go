@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +33,28 @@ func assertHoardConfigSerialisation(t *testing.T,
 	assert.NotEmpty(t, hoardConfigString)
 	assert.Equal(t, hoardConfigString, serialise(hoardConfigRoundTrip))
 }
+
+func TestShutdownAndHealthConfig(t *testing.T) {
+	conf, err := HoardConfigFromYAMLString("listenaddress: tcp://:53431\n")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDrainTimeout, conf.DrainTimeout())
+	assert.Equal(t, DefaultHealthCheckInterval, conf.HealthCheckInterval())
+
+	conf, err = HoardConfigFromYAMLString("draintimeoutseconds: 5\nhealthcheckintervalseconds: 2\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, conf.DrainTimeout())
+	assert.Equal(t, 2*time.Second, conf.HealthCheckInterval())
+}
+
+func TestConcurrencyConfig(t *testing.T) {
+	conf, err := HoardConfigFromYAMLString("listenaddress: tcp://:53431\n")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPrefetchChunks, conf.Prefetch())
+
+	assert.Equal(t, DefaultEncryptWorkers, conf.Workers())
+
+	conf, err = HoardConfigFromTOMLString("PrefetchChunks = 1\nEncryptWorkers = 8\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, conf.Prefetch())
+	assert.Equal(t, 8, conf.Workers())
+}
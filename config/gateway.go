@@ -0,0 +1,13 @@
+package config
+
+// Gateway configures an HTTP listener serving REST endpoints for a subset of the API
+type Gateway struct {
+	// Address on which to listen encoded as a URL with the network protocol as the scheme, as for ListenAddress
+	ListenAddress string
+}
+
+func NewGateway(listenAddress string) *Gateway {
+	return &Gateway{
+		ListenAddress: listenAddress,
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+// KeyWrapping configures the key-management service that wraps the data keys of envelope grants, only one of
+// Directory, URL, or Vault should be given
+type KeyWrapping struct {
+	// A directory containing a file for each key, named by its KeyID, holding the base64-encoded 32 byte key
+	Directory string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// The base URL of an HTTP service that wraps and unwraps keys on POST requests to /wrap and /unwrap
+	URL string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided this bearer token is sent with each request to URL
+	Token string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided the PEM-encoded certificate authorities in this file are trusted to verify the service at URL
+	CAFile string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Wrap keys with the transit secrets engine described by the Vault section
+	Vault bool `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
@@ -0,0 +1,25 @@
+package config
+
+const DefaultMetricsPath = "/metrics"
+
+// Metrics configures an HTTP listener serving Prometheus metrics
+type Metrics struct {
+	// Address (host:port) on which to serve metrics
+	ListenAddress string
+	// HTTP path at which to serve metrics, defaults to DefaultMetricsPath
+	Path string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
+
+func NewMetrics(listenAddress string) *Metrics {
+	return &Metrics{
+		ListenAddress: listenAddress,
+		Path:          DefaultMetricsPath,
+	}
+}
+
+func (conf *Metrics) MetricsPath() string {
+	if conf.Path == "" {
+		return DefaultMetricsPath
+	}
+	return conf.Path
+}
@@ -0,0 +1,27 @@
+package config
+
+import "path"
+
+type ReplicatedConfig struct {
+	// The number of replicas that must accept a write for it to succeed, 0 means all replicas
+	WriteQuorum int
+	// Back-ends to replicate to, reads are served from the first replica (in order) that has the data
+	Replicas []*Storage
+}
+
+func NewReplicatedConfig(writeQuorum int, replicas ...*Storage) *Storage {
+	conf := NewDefaultStorage()
+	conf.StorageType = Replicated
+	conf.ReplicatedConfig = &ReplicatedConfig{
+		WriteQuorum: writeQuorum,
+		Replicas:    replicas,
+	}
+	return conf
+}
+
+func NewDefaultReplicatedConfig() *Storage {
+	primary := NewDefaultFileSystemConfig()
+	secondary := NewDefaultFileSystemConfig()
+	secondary.RootDirectory = path.Join(secondary.RootDirectory, "replica")
+	return NewReplicatedConfig(1, primary, secondary)
+}
@@ -0,0 +1,7 @@
+package config
+
+import "testing"
+
+func TestDefaultReplicatedConfig(t *testing.T) {
+	assertStorageConfigSerialisation(t, NewDefaultReplicatedConfig())
+}
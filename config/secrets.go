@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
+
+	"github.com/monax/hoard/v8/encryption"
 )
 
 // Secrets lists the configured secrets,
@@ -13,6 +17,12 @@ import (
 type Secrets struct {
 	Symmetric []*SymmetricSecret
 	OpenPGP   *OpenPGPSecret
+	// If provided symmetric secrets are read from Vault instead of being listed under Symmetric
+	Vault *Vault `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided envelope grants may be sealed with the keys of this key-management service
+	KeyWrapping *KeyWrapping `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided age grants may be unsealed with these identities
+	Age *AgeSecret `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
 }
 
 type SymmetricSecret struct {
@@ -20,8 +30,23 @@ type SymmetricSecret struct {
 	PublicID string
 	// We expect this to be base64 encoded
 	SecretKey SecretKey
-	// Needed for backwards compatability
+	// If no SecretKey is given a key is derived from this passphrase with scrypt using a random salt stored in each
+	// grant, which makes it safe to use a human-memorable passphrase
 	Passphrase string
+	// The scrypt cost exponent used to derive keys from Passphrase, each increment doubles the time and memory taken to
+	// seal or unseal a grant. Grants sealed at a higher work factor than the current one cannot be unsealed.
+	WorkFactor int `json:",omitempty" yaml:",omitempty" toml:",omitzero"`
+	// A secret may be listed once for each version of its key, the key with the highest version seals new grants and
+	// the others are retained to unseal existing ones
+	KeyVersion int `json:",omitempty" yaml:",omitempty" toml:",omitzero"`
+}
+
+// PassphraseWorkFactor returns the configured WorkFactor or the default if none is set
+func (sec SymmetricSecret) PassphraseWorkFactor() int {
+	if sec.WorkFactor <= 0 {
+		return encryption.DefaultWorkFactor
+	}
+	return sec.WorkFactor
 }
 
 // SecretKey allows us to encode yaml and toml as base64
@@ -42,6 +67,10 @@ func (sec *SymmetricSecret) UnmarshalTOML(in interface{}) error {
 	data, _ := in.(map[string]interface{})
 	sec.PublicID, _ = data["PublicID"].(string)
 	sec.Passphrase, _ = data["Passphrase"].(string)
+	workFactor, _ := data["WorkFactor"].(int64)
+	sec.WorkFactor = int(workFactor)
+	keyVersion, _ := data["KeyVersion"].(int64)
+	sec.KeyVersion = int(keyVersion)
 
 	secret, _ := data["SecretKey"].(string)
 	key, err := b64.StdEncoding.DecodeString(secret)
@@ -58,12 +87,16 @@ func (sec *SymmetricSecret) UnmarshalYAML(unmarshal func(interface{}) error) err
 		PublicID   string
 		SecretKey  string
 		Passphrase string
+		WorkFactor int
+		KeyVersion int
 	}{}
 	if err := unmarshal(secret); err != nil {
 		return err
 	}
 	sec.PublicID = secret.PublicID
 	sec.Passphrase = secret.Passphrase
+	sec.WorkFactor = secret.WorkFactor
+	sec.KeyVersion = secret.KeyVersion
 	key, err := b64.StdEncoding.DecodeString(secret.SecretKey)
 	sec.SecretKey = key
 	return err
@@ -79,11 +112,75 @@ type OpenPGPSecret struct {
 
 type SecretsManager struct {
 	Provider SymmetricProvider
+	// If provided every retained version of a symmetric secret is tried when unsealing, otherwise only the one
+	// returned by Provider
+	Versions SymmetricVersionProvider
 	OpenPGP  *OpenPGPSecret
+	// If provided envelope grants may be sealed and unsealed
+	KeyWrapper KeyWrapper
+	// The identities with which age grants are unsealed, and to whose recipients they are sealed by default
+	Age []*encryption.X25519Identity
+}
+
+// KeyWrapper encrypts (wraps) and decrypts (unwraps) keys with keys held by a key-management service so that the keys
+// that encrypt envelope grants are only stored in wrapped form
+type KeyWrapper interface {
+	Wrap(keyID string, key []byte) ([]byte, error)
+	Unwrap(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// SymmetricVersions returns the versions of a symmetric secret that may have sealed a grant, newest first
+func (sm SecretsManager) SymmetricVersions(secretID string) ([]SymmetricSecret, error) {
+	if sm.Versions != nil {
+		return sm.Versions(secretID)
+	}
+	secret, err := sm.Provider(secretID)
+	if err != nil {
+		return nil, err
+	}
+	return []SymmetricSecret{secret}, nil
+}
+
+// SymmetricBackend is a source of symmetric secrets such as those listed in config or an external secret store
+type SymmetricBackend interface {
+	// SymmetricVersions returns every retained version of a secret ordered by KeyVersion, newest first
+	SymmetricVersions(secretID string) ([]SymmetricSecret, error)
+}
+
+// NewSecretsManager reads symmetric secrets from a back-end, sealing with the newest version of each
+func NewSecretsManager(backend SymmetricBackend, openPGP *OpenPGPSecret) SecretsManager {
+	versions := SymmetricVersionProvider(backend.SymmetricVersions)
+	return SecretsManager{
+		Provider: versions.Active(),
+		Versions: versions,
+		OpenPGP:  openPGP,
+	}
 }
 
 type SymmetricProvider func(secretID string) (SymmetricSecret, error)
 
+// SymmetricVersionProvider returns every retained version of a secret ordered by KeyVersion, newest first
+type SymmetricVersionProvider func(secretID string) ([]SymmetricSecret, error)
+
+// SymmetricVersions makes a SymmetricVersionProvider a SymmetricBackend
+func (versions SymmetricVersionProvider) SymmetricVersions(secretID string) ([]SymmetricSecret, error) {
+	return versions(secretID)
+}
+
+// Active returns a provider of the newest version of each secret, which is the one used to seal grants
+func (versions SymmetricVersionProvider) Active() SymmetricProvider {
+	return func(secretID string) (SymmetricSecret, error) {
+		secrets, err := versions(secretID)
+		if err != nil {
+			return SymmetricSecret{}, err
+		}
+		if len(secrets) == 0 {
+			return SymmetricSecret{}, fmt.Errorf("symmetric secret with ID '%s' has no keys", secretID)
+		}
+		return secrets[0], nil
+	}
+}
+
 // NoopSecretManager is an empty secret manager
 var NoopSecretManager = SecretsManager{
 	Provider: NoopSymmetricProvider,
@@ -100,27 +197,84 @@ func NewSymmetricProvider(conf *Secrets, fromEnv bool) (SymmetricProvider, error
 	if conf == nil || len(conf.Symmetric) == 0 {
 		return NoopSymmetricProvider, nil
 	}
-	secs := make(map[string]SymmetricSecret, len(conf.Symmetric))
-	for _, s := range conf.Symmetric {
-		if fromEnv {
-			// sometimes we don't want to specify these in the config
-			secret := os.Getenv(s.PublicID)
-			s.SecretKey = []byte(secret)
-			s.Passphrase = secret
-		}
-		secs[s.PublicID] = SymmetricSecret{
-			Passphrase: s.Passphrase,
-			SecretKey:  s.SecretKey,
+	versions, err := NewSymmetricVersionProvider(conf, fromEnv)
+	if err != nil {
+		return nil, err
+	}
+	return versions.Active(), nil
+}
+
+// Marks a symmetric secret read from the environment as a base64 key rather than a passphrase
+const EnvSecretKeyPrefix = "base64:"
+
+// NewSymmetricVersionProvider creates a reader of every version of a set of symmetric secrets, where a secret may be
+// listed once for each KeyVersion
+func NewSymmetricVersionProvider(conf *Secrets, fromEnv bool) (SymmetricVersionProvider, error) {
+	secs := make(map[string][]SymmetricSecret)
+	if conf != nil {
+		for _, s := range conf.Symmetric {
+			var legacyKey []byte
+			if fromEnv {
+				// sometimes we don't want to specify these in the config
+				name := s.PublicID
+				if s.KeyVersion != 0 {
+					name = fmt.Sprintf("%s_%d", s.PublicID, s.KeyVersion)
+				}
+				secret := os.Getenv(name)
+				// a value marked as a base64 key is used as one, anything else is treated as a passphrase
+				s.SecretKey = nil
+				s.Passphrase = secret
+				if strings.HasPrefix(secret, EnvSecretKeyPrefix) {
+					key, err := b64.StdEncoding.DecodeString(strings.TrimPrefix(secret, EnvSecretKeyPrefix))
+					if err != nil {
+						return nil, fmt.Errorf("could not decode key for symmetric secret '%s' from environment "+
+							"variable %s: %v", s.PublicID, name, err)
+					}
+					s.SecretKey = key
+					s.Passphrase = ""
+				} else if len(secret) == encryption.KeySize {
+					// Grants sealed before passphrases were salted used a raw value of the size of a key as the key
+					legacyKey = []byte(secret)
+				}
+			}
+			if s.WorkFactor > encryption.MaxWorkFactor {
+				return nil, fmt.Errorf("work factor %d for symmetric secret '%s' exceeds the maximum of %d",
+					s.WorkFactor, s.PublicID, encryption.MaxWorkFactor)
+			}
+			for _, other := range secs[s.PublicID] {
+				if other.KeyVersion == s.KeyVersion {
+					return nil, fmt.Errorf("symmetric secret '%s' has more than one key with version %d",
+						s.PublicID, s.KeyVersion)
+				}
+			}
+			secs[s.PublicID] = append(secs[s.PublicID], SymmetricSecret{
+				Passphrase: s.Passphrase,
+				SecretKey:  s.SecretKey,
+				WorkFactor: s.WorkFactor,
+				KeyVersion: s.KeyVersion,
+			})
+			if legacyKey != nil {
+				// Listed after the passphrase so that it is only used to unseal grants, which must be unsalted to use it
+				secs[s.PublicID] = append(secs[s.PublicID], SymmetricSecret{
+					SecretKey:  legacyKey,
+					KeyVersion: s.KeyVersion,
+				})
+			}
 		}
 	}
-	return func(id string) (SymmetricSecret, error) {
+	for _, versions := range secs {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versions[i].KeyVersion > versions[j].KeyVersion
+		})
+	}
+	return func(id string) ([]SymmetricSecret, error) {
 		if id == "" {
-			return SymmetricSecret{}, fmt.Errorf("empty secret ID passed to provider")
+			return nil, fmt.Errorf("empty secret ID passed to provider")
 		}
 		if val, ok := secs[id]; ok {
 			return val, nil
 		}
-		return SymmetricSecret{}, fmt.Errorf("could not find symmetric secret with ID '%s'", id)
+		return nil, fmt.Errorf("could not find symmetric secret with ID '%s'", id)
 	}, nil
 }
 
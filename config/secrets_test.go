@@ -2,12 +2,16 @@ package config
 
 import (
 	"bytes"
+	b64 "encoding/base64"
 	"encoding/json"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 	"github.com/monax/hoard/v8/encryption"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -58,3 +62,104 @@ func TestSecretKeyMarshal(t *testing.T) {
 	err = toml.Unmarshal([]byte("PublicID = \"\"\nSecretKey = \"badkey=\"\n"), outSecret)
 	assert.Error(t, err)
 }
+
+func TestSymmetricProviderFromEnv(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	os.Setenv("HOARD_TEST_KEY", EnvSecretKeyPrefix+b64.StdEncoding.EncodeToString(key))
+	os.Setenv("HOARD_TEST_PASSPHRASE", "correct horse")
+	os.Setenv("HOARD_TEST_LONG_PASSPHRASE", "correct horse battery staple and then some")
+	defer os.Unsetenv("HOARD_TEST_KEY")
+	defer os.Unsetenv("HOARD_TEST_PASSPHRASE")
+	defer os.Unsetenv("HOARD_TEST_LONG_PASSPHRASE")
+
+	provider, err := NewSymmetricProvider(&Secrets{Symmetric: []*SymmetricSecret{
+		{PublicID: "HOARD_TEST_KEY"},
+		{PublicID: "HOARD_TEST_PASSPHRASE", WorkFactor: 10},
+		{PublicID: "HOARD_TEST_LONG_PASSPHRASE"},
+	}}, true)
+	require.NoError(t, err)
+
+	secret, err := provider("HOARD_TEST_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, key, []byte(secret.SecretKey))
+	assert.Empty(t, secret.Passphrase)
+	assert.Equal(t, encryption.DefaultWorkFactor, secret.PassphraseWorkFactor())
+
+	// Without the prefix a key will be derived from the value however long it is
+	secret, err = provider("HOARD_TEST_LONG_PASSPHRASE")
+	require.NoError(t, err)
+	assert.Empty(t, secret.SecretKey)
+	assert.Equal(t, "correct horse battery staple and then some", secret.Passphrase)
+
+	secret, err = provider("HOARD_TEST_PASSPHRASE")
+	require.NoError(t, err)
+	assert.Empty(t, secret.SecretKey)
+	assert.Equal(t, "correct horse", secret.Passphrase)
+	assert.Equal(t, 10, secret.PassphraseWorkFactor())
+
+	_, err = NewSymmetricProvider(&Secrets{Symmetric: []*SymmetricSecret{
+		{PublicID: "HOARD_TEST_PASSPHRASE", WorkFactor: encryption.MaxWorkFactor + 1},
+	}}, true)
+	assert.Error(t, err)
+
+	os.Setenv("HOARD_TEST_KEY", EnvSecretKeyPrefix+"not base64!")
+	_, err = NewSymmetricProvider(&Secrets{Symmetric: []*SymmetricSecret{{PublicID: "HOARD_TEST_KEY"}}}, true)
+	assert.Error(t, err)
+}
+
+func TestSymmetricVersionProvider(t *testing.T) {
+	versions, err := NewSymmetricVersionProvider(&Secrets{Symmetric: []*SymmetricSecret{
+		{PublicID: "test", Passphrase: "one", KeyVersion: 1},
+		{PublicID: "test", Passphrase: "three", KeyVersion: 3},
+		{PublicID: "test", Passphrase: "two", KeyVersion: 2},
+		{PublicID: "other", Passphrase: "other"},
+	}}, false)
+	require.NoError(t, err)
+
+	secrets, err := versions("test")
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+	assert.Equal(t, "three", secrets[0].Passphrase)
+	assert.Equal(t, "one", secrets[2].Passphrase)
+
+	active, err := versions.Active()("test")
+	require.NoError(t, err)
+	assert.Equal(t, 3, active.KeyVersion)
+
+	_, err = versions("missing")
+	assert.Error(t, err)
+
+	_, err = NewSymmetricVersionProvider(&Secrets{Symmetric: []*SymmetricSecret{
+		{PublicID: "test", Passphrase: "one", KeyVersion: 1},
+		{PublicID: "test", Passphrase: "two", KeyVersion: 1},
+	}}, false)
+	assert.Error(t, err)
+}
+
+func TestNewAgeIdentities(t *testing.T) {
+	first, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+	second, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	f, err := ioutil.TempFile("", "age_identities")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("# created: 2020-12-01T12:00:00Z\n# public key: " + second.Recipient().String() + "\n" +
+		second.String() + "\n\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	identities, err := NewAgeIdentities(&Secrets{Age: &AgeSecret{Identities: []string{first.String()}, File: f.Name()}})
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	assert.Equal(t, first.String(), identities[0].String())
+	assert.Equal(t, second.Recipient().String(), identities[1].Recipient().String())
+
+	identities, err = NewAgeIdentities(&Secrets{})
+	require.NoError(t, err)
+	assert.Empty(t, identities)
+
+	_, err = NewAgeIdentities(&Secrets{Age: &AgeSecret{Identities: []string{second.Recipient().String()}}})
+	assert.Error(t, err)
+}
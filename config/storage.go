@@ -26,6 +26,7 @@ const (
 	Azure       StorageType = "azure"
 	GCP         StorageType = "gcp"
 	IPFS        StorageType = "ipfs"
+	Replicated  StorageType = "replicated"
 )
 
 // Storage identifies the configured back-end
@@ -34,12 +35,30 @@ type Storage struct {
 	StorageType StorageType
 	// Address encoding name
 	AddressEncoding string
+	// Whether to check data read from the store against its address, reporting an integrity error if they differ
+	// (not supported by IPFS whose addresses are not the hash of the data)
+	VerifyIntegrity bool `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
 	// Embedding a pointer to each type of config struct allows us to access the
 	// relevant one, while at the same time those that are left as nil will be
 	// omitted from being serialised.
 	*FileSystemConfig
 	*Cloud
 	*IPFSConfig
+	*ReplicatedConfig
+}
+
+// NamedStorage is an additional back-end that can be selected by name from a grant spec or header
+type NamedStorage struct {
+	// The name used to select this store, it is recorded in the references to any data placed in it
+	Name    string
+	Storage *Storage
+}
+
+func NewNamedStorage(name string, storageConfig *Storage) *NamedStorage {
+	return &NamedStorage{
+		Name:    name,
+		Storage: storageConfig,
+	}
 }
 
 func NewStorage(storageType StorageType, addressEncoding string) *Storage {
@@ -57,6 +76,7 @@ func GetStorageTypes() []StorageType {
 		Azure,
 		GCP,
 		IPFS,
+		Replicated,
 	}
 }
 
@@ -74,6 +94,8 @@ func GetDefaultStorage(storageType StorageType) (*Storage, error) {
 		return NewDefaultCloud(storageType), nil
 	case GCP:
 		return NewDefaultCloud(storageType), nil
+	case Replicated:
+		return NewDefaultReplicatedConfig(), nil
 	default:
 		return nil, fmt.Errorf("did not recognise storage type '%s'", storageType)
 	}
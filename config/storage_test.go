@@ -20,3 +20,39 @@ func assertStorageConfigSerialisation(t *testing.T,
 	assert.NotEmpty(t, tomlString)
 	assert.Equal(t, tomlString, storageConfigOut.TOMLString())
 }
+
+func TestNamedStoresConfig(t *testing.T) {
+	conf := NewHoardConfig(DefaultListenAddress, DefaultChunkSize, NewDefaultStorage(), DefaultLogging)
+	conf.Stores = []*NamedStorage{
+		NewNamedStorage("public", NewDefaultIPFSConfig()),
+		NewNamedStorage("private", NewFileSystemConfig(DefaultAddressEncodingName, "/tmp/hoard")),
+	}
+
+	confOut, err := HoardConfigFromTOMLString(conf.TOMLString())
+	assert.NoError(t, err)
+	assert.Equal(t, conf.TOMLString(), confOut.TOMLString())
+	if assert.Len(t, confOut.Stores, 2) {
+		assert.Equal(t, "public", confOut.Stores[0].Name)
+		assert.Equal(t, IPFS, confOut.Stores[0].Storage.StorageType)
+		assert.Equal(t, "/tmp/hoard", confOut.Stores[1].Storage.RootDirectory)
+	}
+
+	confOut, err = HoardConfigFromJSONString(conf.JSONString())
+	assert.NoError(t, err)
+	assert.Equal(t, conf.JSONString(), confOut.JSONString())
+
+	confOut, err = HoardConfigFromYAMLString(conf.YAMLString())
+	assert.NoError(t, err)
+	assert.Equal(t, conf.YAMLString(), confOut.YAMLString())
+}
+
+func TestVerifyIntegrityConfig(t *testing.T) {
+	storageConfig := NewDefaultFileSystemConfig()
+	assert.NotContains(t, storageConfig.TOMLString(), "VerifyIntegrity")
+
+	storageConfig.VerifyIntegrity = true
+	assertStorageConfigSerialisation(t, storageConfig)
+	storageConfigOut, err := ConfigFromString(storageConfig.TOMLString())
+	assert.NoError(t, err)
+	assert.True(t, storageConfigOut.VerifyIntegrity)
+}
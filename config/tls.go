@@ -0,0 +1,69 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLS configures transport security for the Hoard server
+type TLS struct {
+	// Path to the PEM-encoded certificate (chain) presented by the server
+	CertFile string
+	// Path to the PEM-encoded private key of CertFile
+	KeyFile string
+	// If provided clients must present a certificate signed by one of the PEM-encoded certificate authorities in this
+	// file (mutual TLS)
+	ClientCAFile string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Accept clients that present no certificate even when ClientCAFile is set (for example those identified by a
+	// bearer token), any certificate that is presented must still be valid
+	ClientCertOptional bool `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
+
+func NewTLS(certFile, keyFile, clientCAFile string) *TLS {
+	return &TLS{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: clientCAFile,
+	}
+}
+
+// ServerConfig loads the certificates and keys referenced by the TLS config
+func (conf *TLS) ServerConfig() (*tls.Config, error) {
+	if conf.CertFile == "" || conf.KeyFile == "" {
+		return nil, fmt.Errorf("TLS config must provide both CertFile and KeyFile")
+	}
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load TLS key pair: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if conf.ClientCAFile != "" {
+		tlsConfig.ClientCAs, err = LoadCertPool(conf.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if conf.ClientCertOptional {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+	return tlsConfig, nil
+}
+
+// LoadCertPool reads the PEM-encoded certificates in certFile into a pool
+func LoadCertPool(certFile string) (*x509.CertPool, error) {
+	pemBytes, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("no PEM-encoded certificates found in '%s'", certFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfig(t *testing.T) {
+	conf := NewHoardConfig(DefaultListenAddress, DefaultChunkSize, NewDefaultStorage(), DefaultLogging)
+	conf.TLS = NewTLS("server.pem", "server-key.pem", "ca.pem")
+
+	confOut, err := HoardConfigFromTOMLString(conf.TOMLString())
+	require.NoError(t, err)
+	assert.Equal(t, conf.TLS, confOut.TLS)
+
+	confOut, err = HoardConfigFromYAMLString(conf.YAMLString())
+	require.NoError(t, err)
+	assert.Equal(t, conf.TLS, confOut.TLS)
+
+	conf.TLS = nil
+	assert.NotContains(t, conf.TOMLString(), "TLS")
+}
+
+func TestTLSServerConfig(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "tls_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	_, err = NewTLS("", "", "").ServerConfig()
+	assert.Error(t, err)
+
+	_, err = NewTLS(filepath.Join(tempDir, "missing.pem"), filepath.Join(tempDir, "missing-key.pem"), "").
+		ServerConfig()
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(tempDir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	_, err = LoadCertPool(notPEM)
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const DefaultVaultMount = "transit"
+
+const DefaultVaultCacheTTL = time.Minute
+
+// The environment variable read for a Vault token if none is configured, as used by the Vault CLI
+const VaultTokenEnvironmentVariable = "VAULT_TOKEN"
+
+// Vault configures a HashiCorp Vault transit secrets engine from which to read symmetric secrets, each PublicID names
+// a transit key that must have been created as exportable
+type Vault struct {
+	// The base URL of the Vault server, for example https://vault.example.com:8200
+	Address string
+	// The token with which to authenticate, if empty the VAULT_TOKEN environment variable is used
+	Token string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// The path at which the transit secrets engine is mounted, defaults to DefaultVaultMount
+	Mount string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// If provided the PEM-encoded certificate authorities in this file are trusted to verify the Vault server
+	CAFile string `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+	// Seconds for which keys read from Vault are cached before being read again, defaults to 60
+	CacheSeconds int64 `json:",omitempty" yaml:",omitempty" toml:",omitempty"`
+}
+
+func (conf *Vault) MountPath() string {
+	if conf.Mount == "" {
+		return DefaultVaultMount
+	}
+	return conf.Mount
+}
+
+func (conf *Vault) VaultToken() string {
+	if conf.Token == "" {
+		return os.Getenv(VaultTokenEnvironmentVariable)
+	}
+	return conf.Token
+}
+
+func (conf *Vault) CacheTTL() time.Duration {
+	if conf.CacheSeconds <= 0 {
+		return DefaultVaultCacheTTL
+	}
+	return time.Duration(conf.CacheSeconds) * time.Second
+}
@@ -15,6 +15,9 @@ import (
 const NonceSize = 12
 const KeySize = 32
 
+// The size of the authentication tag GCM appends to each ciphertext
+const TagSize = 16
+
 type BlockCipherMaker func(key []byte) (cipher.Block, error)
 
 type Blob struct {
@@ -54,6 +57,12 @@ func EncryptConvergent(data, salt []byte) (*Blob, error) {
 	})
 }
 
+// ConvergentCiphertextSize returns the size of the ciphertext EncryptConvergent produces from size bytes of plaintext
+// with the provided salt
+func ConvergentCiphertextSize(size int64, salt []byte) int64 {
+	return size + int64(len(salt)) + TagSize
+}
+
 // Decrypt data that was deterministically encrypted with the provided salt
 func DecryptConvergent(encryptedData, salt, secretKey []byte) ([]byte, error) {
 	data, err := decrypt(encryptedData, Args{
@@ -183,8 +192,23 @@ func additionalDataForSalt(salt []byte) []byte {
 // We bump it a little from the 100ms for interactive logins rule: https://blog.filippo.io/the-scrypt-parameters/
 const scryptSecurityWorkExponent = 16
 
+// DefaultWorkFactor is the scrypt cost exponent used by DeriveSecretKey
+const DefaultWorkFactor = scryptSecurityWorkExponent
+
+// The largest work factor we accept, scrypt needs 1 GiB of memory to derive a key at this cost
+const MaxWorkFactor = 20
+
 func DeriveSecretKey(secret, salt []byte) ([]byte, error) {
-	return scrypt.Key(secret, salt, 1<<scryptSecurityWorkExponent, 8, 1, KeySize)
+	return DeriveSecretKeyWithWorkFactor(secret, salt, DefaultWorkFactor)
+}
+
+// DeriveSecretKeyWithWorkFactor derives a key with scrypt where the cost parameter N is 2^workFactor, each increment
+// doubles the time and memory needed to derive the key
+func DeriveSecretKeyWithWorkFactor(secret, salt []byte, workFactor int) ([]byte, error) {
+	if workFactor < 1 || workFactor > MaxWorkFactor {
+		return nil, fmt.Errorf("scrypt work factor must be between 1 and %d but got %d", MaxWorkFactor, workFactor)
+	}
+	return scrypt.Key(secret, salt, 1<<uint(workFactor), 8, 1, KeySize)
 }
 
 func NewNonce(n int) ([]byte, error) {
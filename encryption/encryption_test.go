@@ -50,3 +50,19 @@ func TestAdditionalDataForSalt(t *testing.T) {
 	assert.Equal(t, "{\"SaltType\":\"prefix\",\"SaltLength\":21}",
 		string(additionalDataForSalt([]byte("I _am_ a magical fish"))))
 }
+
+func TestDeriveSecretKeyWithWorkFactor(t *testing.T) {
+	salt := []byte("salty like the sea")
+	key, err := DeriveSecretKeyWithWorkFactor([]byte("passphrase"), salt, 4)
+	assert.NoError(t, err)
+	assert.Len(t, key, KeySize)
+
+	other, err := DeriveSecretKeyWithWorkFactor([]byte("passphrase"), salt, 5)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	_, err = DeriveSecretKeyWithWorkFactor([]byte("passphrase"), salt, 0)
+	assert.Error(t, err)
+	_, err = DeriveSecretKeyWithWorkFactor([]byte("passphrase"), salt, MaxWorkFactor+1)
+	assert.Error(t, err)
+}
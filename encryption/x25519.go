@@ -0,0 +1,146 @@
+package encryption
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Keys are encoded as by age (https://age-encryption.org/v1) so they can be generated with age-keygen, and a key is
+// wrapped for a recipient exactly as the file key in an age X25519 recipient stanza
+
+const x25519RecipientHRP = "age"
+
+const x25519IdentityHRP = "age-secret-key-"
+
+const x25519Label = "age-encryption.org/v1/X25519"
+
+// X25519Recipient is a public key to which keys can be wrapped
+type X25519Recipient struct {
+	publicKey []byte
+}
+
+// X25519Identity is a private key with which keys wrapped to its recipient can be unwrapped
+type X25519Identity struct {
+	secretKey []byte
+	recipient *X25519Recipient
+}
+
+func GenerateX25519Identity() (*X25519Identity, error) {
+	secretKey := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return newX25519Identity(secretKey)
+}
+
+// ParseX25519Identity decodes an age secret key such as 'AGE-SECRET-KEY-1...'
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, secretKey, err := Bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed X25519 identity: %v", err)
+	}
+	if hrp != x25519IdentityHRP {
+		return nil, fmt.Errorf("malformed X25519 identity: unexpected type '%s'", hrp)
+	}
+	if len(secretKey) != curve25519.ScalarSize {
+		return nil, fmt.Errorf("malformed X25519 identity: must be %d bytes", curve25519.ScalarSize)
+	}
+	return newX25519Identity(secretKey)
+}
+
+func newX25519Identity(secretKey []byte) (*X25519Identity, error) {
+	publicKey, err := curve25519.X25519(secretKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{secretKey: secretKey, recipient: &X25519Recipient{publicKey: publicKey}}, nil
+}
+
+func (id *X25519Identity) Recipient() *X25519Recipient {
+	return id.recipient
+}
+
+func (id *X25519Identity) String() string {
+	s, _ := Bech32Encode(x25519IdentityHRP, id.secretKey)
+	return strings.ToUpper(s)
+}
+
+// Unwrap decrypts a key wrapped to this identity's recipient given the ephemeral share it was wrapped with
+func (id *X25519Identity) Unwrap(share, wrapped []byte) ([]byte, error) {
+	if len(share) != curve25519.PointSize {
+		return nil, fmt.Errorf("X25519 share must be %d bytes", curve25519.PointSize)
+	}
+	shared, err := curve25519.X25519(id.secretKey, share)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := x25519WrappingCipher(shared, share, id.recipient.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), wrapped, nil)
+}
+
+// ParseX25519Recipient decodes an age public key such as 'age1...'
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	hrp, publicKey, err := Bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed X25519 recipient: %v", err)
+	}
+	if hrp != x25519RecipientHRP {
+		return nil, fmt.Errorf("malformed X25519 recipient: unexpected type '%s'", hrp)
+	}
+	if len(publicKey) != curve25519.PointSize {
+		return nil, fmt.Errorf("malformed X25519 recipient: must be %d bytes", curve25519.PointSize)
+	}
+	return &X25519Recipient{publicKey: publicKey}, nil
+}
+
+func (rcp *X25519Recipient) String() string {
+	s, _ := Bech32Encode(x25519RecipientHRP, rcp.publicKey)
+	return s
+}
+
+// Wrap encrypts key to this recipient using a fresh ephemeral key pair, returning the ephemeral public key (share)
+// that must be passed to Unwrap along with the wrapped key
+func (rcp *X25519Recipient) Wrap(key []byte) (share []byte, wrapped []byte, err error) {
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	_, err = rand.Read(ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+	share, err = curve25519.X25519(ephemeral, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := curve25519.X25519(ephemeral, rcp.publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := x25519WrappingCipher(shared, share, rcp.publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return share, aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), key, nil), nil
+}
+
+// Each wrapping key is used once so the nonce is fixed
+func x25519WrappingCipher(shared, share, publicKey []byte) (cipher.AEAD, error) {
+	salt := make([]byte, 0, len(share)+len(publicKey))
+	salt = append(append(salt, share...), publicKey...)
+	wrappingKey := make([]byte, chacha20poly1305.KeySize)
+	_, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(x25519Label)), wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.New(wrappingKey)
+}
@@ -0,0 +1,62 @@
+package encryption
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBech32(t *testing.T) {
+	// Valid strings from BIP 173
+	for _, s := range []string{"A12UEL5L", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w"} {
+		hrp, data, err := Bech32Decode(s)
+		require.NoError(t, err, s)
+		encoded, err := Bech32Encode(hrp, data)
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToLower(s), encoded)
+	}
+
+	for _, s := range []string{"A12UEL5l", "pzry9x0s0muk", "1pzry9x0s0muk", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx"} {
+		_, _, err := Bech32Decode(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestX25519(t *testing.T) {
+	identity, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(identity.String(), "AGE-SECRET-KEY-1"))
+	assert.True(t, strings.HasPrefix(identity.Recipient().String(), "age1"))
+
+	parsed, err := ParseX25519Identity(identity.String())
+	require.NoError(t, err)
+	assert.Equal(t, identity.Recipient().String(), parsed.Recipient().String())
+	recipient, err := ParseX25519Recipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	share, wrapped, err := recipient.Wrap(key)
+	require.NoError(t, err)
+	unwrapped, err := parsed.Unwrap(share, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	other, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	_, err = other.Unwrap(share, wrapped)
+	assert.Error(t, err)
+
+	// A recipient from the age README
+	readme := "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"
+	recipient, err = ParseX25519Recipient(readme)
+	require.NoError(t, err)
+	assert.Equal(t, readme, recipient.String())
+
+	_, err = ParseX25519Recipient(identity.String())
+	assert.Error(t, err)
+	_, err = ParseX25519Identity(identity.Recipient().String())
+	assert.Error(t, err)
+}
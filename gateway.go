@@ -0,0 +1,476 @@
+package hoard
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/reference"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Request header carrying the JSON grant spec for PutSeal
+	GrantSpecHeader = "Hoard-Grant-Spec"
+	// Request header carrying the JSON api.Header for PutSeal and Put, and response header carrying the api.Header
+	// read by UnsealGet and Get. PutSeal also accepts a trailer carrying header metadata known only after the body.
+	PlaintextHeader = "Hoard-Header"
+	// Grants and references are small so bodies containing them are limited to the size of a gRPC message
+	maxJSONBodySize = GRPCMessageSizeLimit
+)
+
+// GatewayAuthorizer is called by HTTPGateway before handling each request with the name of the equivalent gRPC
+// method (e.g. 'Grant/PutSeal'). It returns an error to refuse the request, or a function that is passed each grant or
+// message carrying a grant spec read from the request, which returns an error to refuse the request part way through.
+type GatewayAuthorizer func(r *http.Request, method string) (check func(msg interface{}) error, err error)
+
+// HTTPGateway serves a subset of the Hoard API as REST endpoints for clients that cannot use gRPC streaming. It
+// plumbs HTTP requests into a StreamingService so that plaintext request and response bodies are streamed in chunks
+// (responses start once the request body has been read):
+//
+//	POST   /v1/putseal          plaintext body, JSON grant spec in the Hoard-Grant-Spec header -> JSON grant
+//	POST   /v1/unsealget        JSON grant -> plaintext
+//	POST   /v1/unsealgetrange   JSON grant -> plaintext from the 'offset' query parameter for 'length' bytes
+//	POST   /v1/put              plaintext body -> newline-delimited JSON references
+//	POST   /v1/get              JSON references -> plaintext
+//	GET    /v1/blobs/<address>  -> JSON stat info
+//	DELETE /v1/blobs/<address>  -> JSON address
+//
+// Addresses in paths are URL-safe base64-encoded and the store may be selected with the 'store' query parameter.
+// A JSON api.Header may be provided to PutSeal and Put in the Hoard-Header header.
+type HTTPGateway struct {
+	streaming *StreamingService
+	authorize GatewayAuthorizer
+	chunkSize int64
+	mux       *http.ServeMux
+}
+
+var _ http.Handler = (*HTTPGateway)(nil)
+
+// NewHTTPGateway creates a gateway to grantService. If authorize is nil every request is allowed.
+func NewHTTPGateway(grantService GrantService, chunkSize int64, authorize GatewayAuthorizer) *HTTPGateway {
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if authorize == nil {
+		authorize = func(*http.Request, string) (func(interface{}) error, error) {
+			return func(interface{}) error { return nil }, nil
+		}
+	}
+	gw := &HTTPGateway{
+		streaming: NewStreamingService(grantService, chunkSize),
+		authorize: authorize,
+		chunkSize: chunkSize,
+		mux:       http.NewServeMux(),
+	}
+	gw.mux.Handle("/v1/putseal", gw.handle(http.MethodPost, "Grant/PutSeal", gw.putSeal))
+	gw.mux.Handle("/v1/unsealget", gw.handle(http.MethodPost, "Grant/UnsealGet", gw.unsealGet))
+	gw.mux.Handle("/v1/unsealgetrange", gw.handle(http.MethodPost, "Grant/UnsealGetRange", gw.unsealGetRange))
+	gw.mux.Handle("/v1/put", gw.handle(http.MethodPost, "Cleartext/Put", gw.put))
+	gw.mux.Handle("/v1/get", gw.handle(http.MethodPost, "Cleartext/Get", gw.get))
+	stat := gw.handle(http.MethodGet, "Storage/Stat", gw.stat)
+	del := gw.handle(http.MethodDelete, "Storage/Delete", gw.delete)
+	gw.mux.HandleFunc("/v1/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			del.ServeHTTP(w, r)
+			return
+		}
+		stat.ServeHTTP(w, r)
+	})
+	return gw
+}
+
+// WithPrefetch sets the number of chunks fetched and decrypted concurrently, see StreamingService.WithPrefetch
+func (gw *HTTPGateway) WithPrefetch(window int) *HTTPGateway {
+	gw.streaming.WithPrefetch(window)
+	return gw
+}
+
+// WithEncryptWorkers sets the number of chunks encrypted concurrently, see StreamingService.WithEncryptWorkers
+func (gw *HTTPGateway) WithEncryptWorkers(workers int) *HTTPGateway {
+	gw.streaming.WithEncryptWorkers(workers)
+	return gw
+}
+
+// WithChunker sets how plaintext is split into chunks by default, see StreamingService.WithChunker
+func (gw *HTTPGateway) WithChunker(chunker api.Chunker) *HTTPGateway {
+	gw.streaming.WithChunker(chunker)
+	return gw
+}
+
+// WithUploadStats reports the chunks stored by each upload, see StreamingService.WithUploadStats
+func (gw *HTTPGateway) WithUploadStats(report func(stats *UploadStats)) *HTTPGateway {
+	gw.streaming.WithUploadStats(report)
+	return gw
+}
+
+func (gw *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	gw.mux.ServeHTTP(w, r)
+}
+
+type gatewayHandler func(w *gatewayResponse, r *http.Request, check func(msg interface{}) error) error
+
+// Wraps a handler with method checking, authorization, and error reporting
+func (gw *HTTPGateway) handle(httpMethod, method string, handler gatewayHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != httpMethod {
+			w.Header().Set("Allow", httpMethod)
+			writeGatewayError(w, http.StatusMethodNotAllowed, "%s requires %s", r.URL.Path, httpMethod)
+			return
+		}
+		check, err := gw.authorize(r, method)
+		if err == nil {
+			gr := &gatewayResponse{ResponseWriter: w}
+			err = handler(gr, r, check)
+			if err != nil && gr.started {
+				// We cannot change the status once the body has started so abort the response to signal the
+				// client that it is incomplete
+				panic(http.ErrAbortHandler)
+			}
+		}
+		if err != nil {
+			st := status.Convert(err)
+			writeGatewayError(w, httpStatus(st.Code()), "%s", st.Message())
+		}
+	})
+}
+
+func (gw *HTTPGateway) putSeal(w *gatewayResponse, r *http.Request, check func(msg interface{}) error) error {
+	if r.Header.Get(GrantSpecHeader) == "" {
+		return status.Errorf(codes.InvalidArgument, "%s header is required", GrantSpecHeader)
+	}
+	spec := new(grant.Spec)
+	err := decodeJSONHeader(r, GrantSpecHeader, spec)
+	if err != nil {
+		return err
+	}
+	head, err := plaintextHeader(r)
+	if err != nil {
+		return err
+	}
+	first := &api.PlaintextAndGrantSpec{GrantSpec: spec, Plaintext: &api.Plaintext{Head: head}}
+	err = check(first)
+	if err != nil {
+		return err
+	}
+	next := chunker(r.Body, gw.chunkSize)
+	trailed := false
+	return gw.streaming.PutSeal(func(grt *grant.Grant) error {
+		return w.writeJSON(grt)
+	}, func() (*api.PlaintextAndGrantSpec, error) {
+		if first != nil {
+			defer func() { first = nil }()
+			return first, nil
+		}
+		chunk, err := next()
+		if err == io.EOF && !trailed && r.Trailer.Get(PlaintextHeader) != "" {
+			// Trailers are only available once the body has been read
+			trailed = true
+			trailer := new(api.Header)
+			err = json.Unmarshal([]byte(r.Trailer.Get(PlaintextHeader)), trailer)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "could not decode %s trailer: %v",
+					PlaintextHeader, err)
+			}
+			return &api.PlaintextAndGrantSpec{Plaintext: &api.Plaintext{Head: trailer}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &api.PlaintextAndGrantSpec{Plaintext: &api.Plaintext{Body: chunk}}, nil
+	})
+}
+
+func (gw *HTTPGateway) unsealGet(w *gatewayResponse, r *http.Request, check func(msg interface{}) error) error {
+	grt := new(grant.Grant)
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(grt)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "could not decode grant: %v", err)
+	}
+	err = check(grt)
+	if err != nil {
+		return err
+	}
+	err = gw.streaming.UnsealGet(grt, w.writePlaintext)
+	if err != nil {
+		return err
+	}
+	return w.finish()
+}
+
+func (gw *HTTPGateway) unsealGetRange(w *gatewayResponse, r *http.Request, check func(msg interface{}) error) error {
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		return err
+	}
+	length, err := queryInt(r, "length")
+	if err != nil {
+		return err
+	}
+	grt := new(grant.Grant)
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize)).Decode(grt)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "could not decode grant: %v", err)
+	}
+	req := &api.UnsealGetRangeRequest{Grant: grt, Offset: offset, Length: length}
+	err = check(req)
+	if err != nil {
+		return err
+	}
+	err = gw.streaming.UnsealGetRange(req, w.writePlaintext)
+	if err != nil {
+		return err
+	}
+	return w.finish()
+}
+
+func (gw *HTTPGateway) put(w *gatewayResponse, r *http.Request, _ func(msg interface{}) error) error {
+	head, err := plaintextHeader(r)
+	if err != nil {
+		return err
+	}
+	first := &api.Plaintext{Head: head}
+	next := chunker(r.Body, gw.chunkSize)
+	// The request body cannot be read once the response has started so the references (which are small relative to
+	// the chunks they refer to) are held until it has all been read
+	var refs []*reference.Ref
+	err = gw.streaming.Put(func(ref *reference.Ref) error {
+		refs = append(refs, ref)
+		return nil
+	}, func() (*api.Plaintext, error) {
+		if first != nil {
+			defer func() { first = nil }()
+			return first, nil
+		}
+		chunk, err := next()
+		if err != nil {
+			return nil, err
+		}
+		return &api.Plaintext{Body: chunk}, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		err = w.writeJSON(ref)
+		if err != nil {
+			return err
+		}
+	}
+	return w.finish()
+}
+
+func (gw *HTTPGateway) get(w *gatewayResponse, r *http.Request, _ func(msg interface{}) error) error {
+	// The request body cannot be read once the response has started so read all the references first
+	var refs []*reference.Ref
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize))
+	for {
+		ref := new(reference.Ref)
+		err := decoder.Decode(ref)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "could not decode reference: %v", err)
+		}
+		refs = append(refs, ref)
+	}
+	err := gw.streaming.Get(w.writePlaintext, func() (*reference.Ref, error) {
+		if len(refs) == 0 {
+			return nil, io.EOF
+		}
+		ref := refs[0]
+		refs = refs[1:]
+		return ref, nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.finish()
+}
+
+func (gw *HTTPGateway) stat(w *gatewayResponse, r *http.Request, _ func(msg interface{}) error) error {
+	address, err := pathAddress(r)
+	if err != nil {
+		return err
+	}
+	statInfo, err := gw.streaming.Stat(address)
+	if err != nil {
+		return err
+	}
+	return w.writeJSON(statInfo)
+}
+
+func (gw *HTTPGateway) delete(w *gatewayResponse, r *http.Request, _ func(msg interface{}) error) error {
+	address, err := pathAddress(r)
+	if err != nil {
+		return err
+	}
+	err = gw.streaming.Delete(address)
+	if err != nil {
+		return err
+	}
+	return w.writeJSON(address)
+}
+
+// Tracks whether the response has been started and streams each write to the client
+type gatewayResponse struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (gr *gatewayResponse) start(contentType string) {
+	if !gr.started {
+		gr.Header().Set("Content-Type", contentType)
+		gr.WriteHeader(http.StatusOK)
+		gr.started = true
+	}
+}
+
+// Writes v as a line of JSON
+func (gr *gatewayResponse) writeJSON(v interface{}) error {
+	gr.start("application/json")
+	err := json.NewEncoder(gr).Encode(v)
+	if err != nil {
+		return err
+	}
+	gr.flush()
+	return nil
+}
+
+// Writes the body of plaintext, reporting its header in the response header if it arrives before the body
+func (gr *gatewayResponse) writePlaintext(plaintext *api.Plaintext) error {
+	if !gr.started && plaintext.GetHead() != nil {
+		headJSON, err := json.Marshal(plaintext.GetHead())
+		if err != nil {
+			return err
+		}
+		gr.Header().Set(PlaintextHeader, string(headJSON))
+	}
+	if len(plaintext.GetBody()) == 0 {
+		return nil
+	}
+	gr.start("application/octet-stream")
+	_, err := gr.Write(plaintext.GetBody())
+	if err != nil {
+		return err
+	}
+	gr.flush()
+	return nil
+}
+
+// Ensures an empty response is still successful
+func (gr *gatewayResponse) finish() error {
+	gr.start("application/octet-stream")
+	return nil
+}
+
+func (gr *gatewayResponse) flush() {
+	if flusher, ok := gr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Returns a function reading reader in chunks of at most chunkSize that returns io.EOF when it is exhausted
+func chunker(reader io.Reader, chunkSize int64) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		chunk := make([]byte, chunkSize)
+		n, err := io.ReadFull(reader, chunk)
+		if n > 0 {
+			return chunk[:n], nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+}
+
+func plaintextHeader(r *http.Request) (*api.Header, error) {
+	if r.Header.Get(PlaintextHeader) == "" {
+		return nil, nil
+	}
+	head := new(api.Header)
+	return head, decodeJSONHeader(r, PlaintextHeader, head)
+}
+
+func decodeJSONHeader(r *http.Request, header string, v interface{}) error {
+	value := r.Header.Get(header)
+	if value == "" {
+		return nil
+	}
+	err := json.Unmarshal([]byte(value), v)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "could not decode %s header: %v", header, err)
+	}
+	return nil
+}
+
+// Reads an integer query parameter that is zero if omitted
+func queryInt(r *http.Request, param string) (int64, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "expected an integer '%s' query parameter but got '%s'",
+			param, value)
+	}
+	return n, nil
+}
+
+func pathAddress(r *http.Request) (*api.Address, error) {
+	encoded := strings.TrimRight(strings.TrimPrefix(r.URL.Path, "/v1/blobs/"), "=")
+	address, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(address) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expected a URL-safe base64-encoded address in the path "+
+			"but got '%s'", encoded)
+	}
+	return &api.Address{Address: address, Store: r.URL.Query().Get("store")}, nil
+}
+
+func writeGatewayError(w http.ResponseWriter, httpStatus int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{fmt.Sprintf(format, args...)})
+}
+
+// Maps gRPC status codes to their nearest HTTP status
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
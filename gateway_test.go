@@ -0,0 +1,181 @@
+package hoard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/reference"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPGateway(t *testing.T) {
+	hrd := NewHoard(stores.NewMemoryStore(), config.NoopSecretManager, log.NewNopLogger())
+	// Use a small chunk size so data is streamed in many chunks
+	gw := httptest.NewServer(NewHTTPGateway(hrd, 64, nil))
+	defer gw.Close()
+
+	do := func(method, path string, body []byte, headers map[string]string) *http.Response {
+		req, err := http.NewRequest(method, gw.URL+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	readAll := func(resp *http.Response) []byte {
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("PutSealUnsealGet", func(t *testing.T) {
+		resp := do(http.MethodPost, "/v1/putseal", []byte(helpers.LongText), map[string]string{
+			GrantSpecHeader: `{"plaintext":{}}`,
+			PlaintextHeader: `{"Data":"` + base64.StdEncoding.EncodeToString([]byte("meta")) + `"}`,
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		grantJSON := readAll(resp)
+		grt := new(grant.Grant)
+		require.NoError(t, json.Unmarshal(grantJSON, grt))
+
+		resp = do(http.MethodPost, "/v1/unsealget", grantJSON, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.LongText, string(readAll(resp)))
+		head := new(api.Header)
+		require.NoError(t, json.Unmarshal([]byte(resp.Header.Get(PlaintextHeader)), head))
+		assert.Equal(t, []byte("meta"), head.Data)
+
+		resp = do(http.MethodPost, "/v1/unsealgetrange?offset=100&length=150", grantJSON, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.LongText[100:250], string(readAll(resp)))
+		assert.NotEmpty(t, resp.Header.Get(PlaintextHeader))
+
+		resp = do(http.MethodPost, "/v1/unsealgetrange?offset=100", grantJSON, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.LongText[100:], string(readAll(resp)))
+
+		resp = do(http.MethodPost, "/v1/unsealgetrange?offset=ten", grantJSON, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		readAll(resp)
+	})
+
+	t.Run("PutSealTrailer", func(t *testing.T) {
+		// The trailer is only sent with a chunked body
+		req, err := http.NewRequest(http.MethodPost, gw.URL+"/v1/putseal",
+			ioutil.NopCloser(strings.NewReader(helpers.LongText)))
+		require.NoError(t, err)
+		req.Header.Set(GrantSpecHeader, `{"plaintext":{}}`)
+		req.Header.Set(PlaintextHeader, `{"Salt":"`+base64.StdEncoding.EncodeToString([]byte("salt"))+`"}`)
+		req.Trailer = http.Header{PlaintextHeader: []string{
+			`{"Data":"` + base64.StdEncoding.EncodeToString([]byte("meta")) + `"}`}}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		grantJSON := readAll(resp)
+
+		resp = do(http.MethodPost, "/v1/unsealget", grantJSON, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.LongText, string(readAll(resp)))
+		head := new(api.Header)
+		require.NoError(t, json.Unmarshal([]byte(resp.Header.Get(PlaintextHeader)), head))
+		assert.Equal(t, &api.Header{Salt: []byte("salt"), Data: []byte("meta")}, head)
+	})
+
+	t.Run("PutGet", func(t *testing.T) {
+		resp := do(http.MethodPost, "/v1/put", []byte(helpers.LongText), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		refsJSON := readAll(resp)
+		// One reference per chunk
+		lines := strings.Split(strings.TrimSpace(string(refsJSON)), "\n")
+		assert.Equal(t, (len(helpers.LongText)+63)/64, len(lines))
+
+		resp = do(http.MethodPost, "/v1/get", refsJSON, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.LongText, string(readAll(resp)))
+
+		ref := new(reference.Ref)
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), ref))
+		blob := "/v1/blobs/" + base64.RawURLEncoding.EncodeToString(ref.Address)
+
+		resp = do(http.MethodGet, blob, nil, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		statInfo := new(stores.StatInfo)
+		require.NoError(t, json.Unmarshal(readAll(resp), statInfo))
+		assert.True(t, statInfo.Exists)
+
+		resp = do(http.MethodDelete, blob, nil, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		readAll(resp)
+
+		resp = do(http.MethodGet, blob, nil, nil)
+		statInfo = new(stores.StatInfo)
+		require.NoError(t, json.Unmarshal(readAll(resp), statInfo))
+		assert.False(t, statInfo.Exists)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		resp := do(http.MethodGet, "/v1/put", nil, nil)
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		readAll(resp)
+
+		resp = do(http.MethodPost, "/v1/putseal", []byte("data"), nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(readAll(resp)), GrantSpecHeader)
+
+		resp = do(http.MethodPost, "/v1/unsealget", []byte("not a grant"), nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		readAll(resp)
+
+		resp = do(http.MethodGet, "/v1/blobs/not*base64", nil, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		readAll(resp)
+	})
+}
+
+func TestHTTPGatewayAuthorize(t *testing.T) {
+	hrd := NewHoard(stores.NewMemoryStore(), config.NoopSecretManager, log.NewNopLogger())
+	gw := httptest.NewServer(NewHTTPGateway(hrd, 0, func(r *http.Request, method string) (func(interface{}) error,
+		error) {
+		if method != "Grant/PutSeal" {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+		}
+		return func(msg interface{}) error {
+			if msg.(*api.PlaintextAndGrantSpec).GetGrantSpec().GetSymmetric() != nil {
+				return status.Errorf(codes.PermissionDenied, "secret is not allowed")
+			}
+			return nil
+		}, nil
+	}))
+	defer gw.Close()
+
+	resp, err := http.Post(gw.URL+"/v1/put", "", strings.NewReader("data"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, gw.URL+"/v1/putseal", strings.NewReader("data"))
+	require.NoError(t, err)
+	req.Header.Set(GrantSpecHeader, `{"symmetric":{"publicid":"secret"}}`)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,166 @@
+package hoard
+
+import (
+	"fmt"
+
+	"github.com/monax/hoard/v8/reference"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/versions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ErrorNoGarbageRoots() error {
+	return status.Errorf(codes.FailedPrecondition, "refusing to collect garbage with no roots since every blob in "+
+		"the store would be deleted, pass all to do so anyway")
+}
+
+// CollectGarbage marks every address reachable from roots (following LINK refs to the refs they contain) and then
+// sweeps the store named storeName (the default store if empty), passing each unreachable address to garbage. Unless
+// dryRun is set each unreachable address is also deleted from the store. The store must support listing its
+// addresses. An empty roots would delete every blob in the store so is refused unless all is set (or dryRun, which
+// deletes nothing).
+//
+// Any root not included in roots will be treated as garbage, and blobs written while a collection is in progress may
+// be collected before a grant referencing them can be passed as a root, so it is only safe to run a collection when
+// there are no concurrent writes to the store.
+func CollectGarbage(grantService GrantService, storeName string, roots []*reference.Ref, dryRun, all bool,
+	garbage func(address []byte) error) error {
+	if len(roots) == 0 && !dryRun && !all {
+		return ErrorNoGarbageRoots()
+	}
+	store, err := grantService.Route(storeName)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]struct{})
+	err = walk(roots, grantService.Get, versions.LatestGrantVersion, func(ref *reference.Ref) error {
+		if ref.Store == storeName {
+			live[string(ref.Address)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not mark live references, aborting garbage collection: %w", err)
+	}
+
+	// Collect before deleting so we do not modify the store while it is being listed
+	var unreachable [][]byte
+	err = stores.ListAll(store, nil, nil, func(address []byte) error {
+		if _, ok := live[string(address)]; !ok {
+			unreachable = append(unreachable, address)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list addresses to sweep: %w", err)
+	}
+
+	for _, address := range unreachable {
+		if !dryRun {
+			err = store.Delete(address)
+			if err != nil {
+				return err
+			}
+		}
+		err = garbage(address)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRecursive deletes the data behind each of refs and, for LINK refs, the data behind every ref reachable from
+// them, calling deleted after each deletion. Data reachable more than once is only deleted once and data that is
+// reachable from any of keep is not deleted at all, so keep can be used to protect data shared with other grants.
+// Linked data is deleted before the LINK that references it so that a failed deletion can be retried.
+func DeleteRecursive(grantService GrantService, refs, keep []*reference.Ref,
+	deleted func(ref *reference.Ref) error) error {
+	kept := make(map[refKey]struct{})
+	err := walk(keep, grantService.Get, versions.LatestGrantVersion, func(ref *reference.Ref) error {
+		kept[keyOf(ref)] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not find references to keep: %w", err)
+	}
+
+	var reachable []*reference.Ref
+	err = walk(refs, grantService.Get, versions.LatestGrantVersion, func(ref *reference.Ref) error {
+		if _, ok := kept[keyOf(ref)]; !ok {
+			kept[keyOf(ref)] = struct{}{}
+			reachable = append(reachable, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not find references to delete: %w", err)
+	}
+
+	// Refs are visited before the refs they link to so delete in reverse
+	for i := len(reachable) - 1; i >= 0; i-- {
+		ref := reachable[i]
+		store, err := grantService.Route(ref.Store)
+		if err != nil {
+			return err
+		}
+		err = store.Delete(ref.Address)
+		if err != nil {
+			return err
+		}
+		err = deleted(ref)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Identifies the data behind a ref
+type refKey struct {
+	store   string
+	address string
+}
+
+func keyOf(ref *reference.Ref) refKey {
+	return refKey{store: ref.Store, address: string(ref.Address)}
+}
+
+// Calls visit on each of refs and on every ref reachable from them through LINK refs (whose plaintext is obtained
+// with get). A LINK ref reachable by more than one path is only followed once.
+func walk(refs []*reference.Ref, get func(*reference.Ref) ([]byte, error), version int32,
+	visit func(ref *reference.Ref) error) error {
+	followed := make(map[refKey]struct{})
+	var walkRefs func(refs []*reference.Ref) error
+	walkRefs = func(refs []*reference.Ref) error {
+		for _, ref := range refs {
+			err := visit(ref)
+			if err != nil {
+				return err
+			}
+			if ref.Type != reference.Ref_LINK {
+				continue
+			}
+			if _, ok := followed[keyOf(ref)]; ok {
+				continue
+			}
+			followed[keyOf(ref)] = struct{}{}
+			data, err := get(ref)
+			if err != nil {
+				return fmt.Errorf("could not get LINK ref at %X: %w", ref.Address, err)
+			}
+			linked, err := reference.RefsFromPlaintext(data, version)
+			if err != nil {
+				return err
+			}
+			err = walkRefs(linked)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walkRefs(refs)
+}
@@ -0,0 +1,191 @@
+package hoard
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/reference"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGarbageCollect(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := NewHoard(store, config.NoopSecretManager, log.NewNopLogger())
+	service := NewStreamingService(hrd, 64)
+
+	putSeal := func(text string) *grant.Grant {
+		var grt *grant.Grant
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, sendOnce(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{Head: &api.Header{Data: []byte("meta")}, Body: []byte(text)},
+			GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+		}))
+		require.NoError(t, err)
+		return grt
+	}
+
+	live := putSeal(helpers.LongText)
+	dead := putSeal(helpers.LongText + " and some more")
+	orphan, err := hrd.Put([]byte("orphan"), nil)
+	require.NoError(t, err)
+
+	countAddresses := func() int {
+		count := 0
+		require.NoError(t, stores.ListAll(hrd.Store(), nil, nil, func(address []byte) error {
+			count++
+			return nil
+		}))
+		return count
+	}
+	before := countAddresses()
+
+	gc := func(dryRun bool) [][]byte {
+		var garbage [][]byte
+		sent := false
+		err := service.GarbageCollect(func(address *api.Address) error {
+			garbage = append(garbage, address.Address)
+			return nil
+		}, func() (*api.GarbageCollectRequest, error) {
+			if sent {
+				return nil, io.EOF
+			}
+			sent = true
+			return &api.GarbageCollectRequest{
+				Options: &api.GarbageCollectOptions{DryRun: dryRun},
+				Grant:   live,
+			}, nil
+		})
+		require.NoError(t, err)
+		return garbage
+	}
+
+	garbage := gc(true)
+	assert.Contains(t, garbage, orphan.Address)
+	assert.Equal(t, before, countAddresses(), "dry run should not delete anything")
+
+	// The live grant should survive collection but the body chunks of the dead grant (and its LINK) should not
+	assert.ElementsMatch(t, garbage, gc(false))
+	assert.Equal(t, before-len(garbage), countAddresses())
+	assert.Empty(t, gc(false))
+
+	err = service.UnsealGet(live, func(pt *api.Plaintext) error { return nil })
+	assert.NoError(t, err)
+	err = service.UnsealGet(dead, func(pt *api.Plaintext) error { return nil })
+	assert.Error(t, err)
+
+	// Without roots everything is garbage so collection must be asked for explicitly
+	collectAll := func(options *api.GarbageCollectOptions) error {
+		sent := false
+		return service.GarbageCollect(func(address *api.Address) error {
+			return nil
+		}, func() (*api.GarbageCollectRequest, error) {
+			if sent || options == nil {
+				return nil, io.EOF
+			}
+			sent = true
+			return &api.GarbageCollectRequest{Options: options}, nil
+		})
+	}
+	before = countAddresses()
+	assert.Equal(t, ErrorNoGarbageRoots(), collectAll(nil))
+	assert.Equal(t, ErrorNoGarbageRoots(), collectAll(&api.GarbageCollectOptions{}))
+	require.NoError(t, collectAll(&api.GarbageCollectOptions{DryRun: true}))
+	assert.Equal(t, before, countAddresses())
+	require.NoError(t, collectAll(&api.GarbageCollectOptions{All: true}))
+	assert.Equal(t, 0, countAddresses())
+}
+
+func TestUnsealDelete(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := NewHoard(store, config.NoopSecretManager, log.NewNopLogger())
+	service := NewStreamingService(hrd, 64)
+
+	putSeal := func(spec *grant.Spec) *grant.Grant {
+		var grt *grant.Grant
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, sendOnce(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{Head: &api.Header{Data: []byte("meta")}, Body: []byte(helpers.LongText)},
+			GrantSpec: spec,
+		}))
+		require.NoError(t, err)
+		return grt
+	}
+	unsealGet := func(grt *grant.Grant) error {
+		return service.UnsealGet(grt, func(pt *api.Plaintext) error { return nil })
+	}
+	countAddresses := func() int {
+		count := 0
+		require.NoError(t, stores.ListAll(store, nil, nil, func(address []byte) error {
+			count++
+			return nil
+		}))
+		return count
+	}
+
+	first := putSeal(&grant.Spec{Plaintext: &grant.PlaintextSpec{}})
+	second := putSeal(&grant.Spec{Plaintext: &grant.PlaintextSpec{}})
+	firstRefs, err := hrd.Unseal(first)
+	require.NoError(t, err)
+	secondRefs, err := hrd.Unseal(second)
+	require.NoError(t, err)
+
+	// The grants share every chunk except their LINK so keeping the second should only delete the first's LINK
+	var deleted []*reference.Ref
+	err = DeleteRecursive(hrd, firstRefs, secondRefs, func(ref *reference.Ref) error {
+		deleted = append(deleted, ref)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, firstRefs, deleted)
+	assert.Error(t, unsealGet(first))
+	assert.NoError(t, unsealGet(second))
+
+	// Without grants to keep deletion is refused since the chunks may be shared, as they are with a third grant
+	third := putSeal(&grant.Spec{Plaintext: &grant.PlaintextSpec{}})
+	err = service.UnsealDeleteRecursive(&api.UnsealDeleteRequest{Grant: second}, func(address *api.Address) error {
+		return nil
+	})
+	assert.Equal(t, ErrorNoKeepGrants(), err)
+	assert.NoError(t, unsealGet(second))
+
+	// Deleting the third grant while keeping the second only deletes the third's LINK
+	deleted = nil
+	err = hrd.UnsealDeleteRecursive(third, []*grant.Grant{second}, false, func(ref *reference.Ref) error {
+		deleted = append(deleted, ref)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, reference.Ref_LINK, deleted[0].Type)
+	assert.Error(t, unsealGet(third))
+	assert.NoError(t, unsealGet(second))
+
+	// UnsealDelete deletes every chunk so deleting the last grant should leave nothing behind
+	var addresses []*api.Address
+	err = service.UnsealDelete(second, func(address *api.Address) error {
+		addresses = append(addresses, address)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, countAddresses())
+	require.NotEmpty(t, addresses)
+	assert.Equal(t, secondRefs[0].Address, addresses[len(addresses)-1].Address, "LINK should be deleted last")
+	assert.Error(t, unsealGet(second))
+
+	// Grants with a shared link nonce may share data with other grants
+	shared := putSeal(&grant.Spec{Plaintext: &grant.PlaintextSpec{}, LinkNonce: []byte("shared")})
+	err = hrd.UnsealDelete(shared, func(ref *reference.Ref) error { return nil })
+	assert.Error(t, err)
+	assert.NoError(t, unsealGet(shared))
+}
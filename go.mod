@@ -17,6 +17,7 @@ require (
 	github.com/golang/protobuf v1.4.3
 	github.com/jawher/mow.cli v1.2.0
 	github.com/monax/relic v2.0.0+incompatible
+	github.com/prometheus/client_golang v1.9.0
 	github.com/stretchr/testify v1.6.1
 	github.com/test-go/testify v1.1.4
 	gocloud.dev v0.20.0
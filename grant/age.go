@@ -0,0 +1,84 @@
+package grant
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/monax/hoard/v8/reference"
+)
+
+// AgeGrant encrypts the given references with a random data key that is wrapped for each of the spec's X25519
+// recipients as in an age file, or for the recipients of Hoard's own identities if the spec names none. The recipients
+// and wrapped keys are recorded in a copy of the spec.
+func AgeGrant(refs []*reference.Ref, spec *Spec, identities []*encryption.X25519Identity) (*Spec, []byte, error) {
+	encoded := spec.GetAge().GetRecipients()
+	if len(encoded) == 0 {
+		for _, id := range identities {
+			encoded = append(encoded, id.Recipient().String())
+		}
+	}
+	if len(encoded) == 0 {
+		return nil, nil, fmt.Errorf("AgeGrant cannot seal without recipients or identities configured")
+	}
+	recipients := make([]*encryption.X25519Recipient, len(encoded))
+	canonical := make([]string, len(encoded))
+	for i, s := range encoded {
+		rcp, err := encryption.ParseX25519Recipient(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		recipients[i] = rcp
+		canonical[i] = rcp.String()
+	}
+	dataKey, err := encryption.NewNonce(encryption.KeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("AgeGrant failed to generate random data key: %v", err)
+	}
+	encRefs, err := SymmetricGrant(refs, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	stanzas := make([]*AgeStanza, len(recipients))
+	for i, rcp := range recipients {
+		share, wrapped, err := rcp.Wrap(dataKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("AgeGrant failed to wrap data key: %v", err)
+		}
+		stanzas[i] = &AgeStanza{Share: share, WrappedKey: wrapped}
+	}
+	spec = proto.Clone(spec).(*Spec)
+	spec.Age.Recipients = canonical
+	spec.Age.Stanzas = stanzas
+	return spec, encRefs, nil
+}
+
+// AgeReference unwraps the data key of an age grant with the identity of the recipient it was wrapped for and decrypts
+// its references. Each wrapped key is only unwrapped with the identity of the recipient recorded alongside it so that
+// what a grant claims about its recipients can be relied upon when authorizing the use of our identities.
+func AgeReference(ciphertext []byte, spec *AgeSpec, identities []*encryption.X25519Identity,
+	version int32) ([]*reference.Ref, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("AgeReference cannot unseal without identities configured")
+	}
+	if len(spec.GetStanzas()) != len(spec.GetRecipients()) {
+		return nil, fmt.Errorf("AgeReference found %d wrapped keys for %d recipients", len(spec.GetStanzas()),
+			len(spec.GetRecipients()))
+	}
+	for i, stanza := range spec.GetStanzas() {
+		rcp, err := encryption.ParseX25519Recipient(spec.GetRecipients()[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range identities {
+			if id.Recipient().String() != rcp.String() {
+				continue
+			}
+			dataKey, err := id.Unwrap(stanza.GetShare(), stanza.GetWrappedKey())
+			if err == nil {
+				return SymmetricReference(ciphertext, dataKey, version)
+			}
+		}
+	}
+	return nil, fmt.Errorf("AgeReference found no data key wrapped for the configured identities")
+}
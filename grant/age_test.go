@@ -0,0 +1,59 @@
+package grant
+
+import (
+	"testing"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgeGrant(t *testing.T) {
+	refs := testReferences()
+	hoardID, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+	clientID, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+	secrets := config.SecretsManager{Age: []*encryption.X25519Identity{hoardID}}
+
+	t.Run("Recipients", func(t *testing.T) {
+		spec := &Spec{Age: &AgeSpec{Recipients: []string{clientID.Recipient().String(), hoardID.Recipient().String()}}}
+		grt, err := Seal(secrets, refs, spec)
+		require.NoError(t, err)
+		require.Len(t, grt.Spec.Age.Stanzas, 2)
+		assert.Nil(t, spec.Age.Stanzas)
+
+		// Either recipient's identity can unseal the grant
+		refsOut, err := Unseal(secrets, grt)
+		require.NoError(t, err)
+		assertRefsEqual(t, refs, refsOut)
+		refsOut, err = Unseal(config.SecretsManager{Age: []*encryption.X25519Identity{clientID}}, grt)
+		require.NoError(t, err)
+		assertRefsEqual(t, refs, refsOut)
+
+		other, err := encryption.GenerateX25519Identity()
+		require.NoError(t, err)
+		_, err = Unseal(config.SecretsManager{Age: []*encryption.X25519Identity{other}}, grt)
+		assert.Error(t, err)
+		_, err = Unseal(config.SecretsManager{}, grt)
+		assert.Error(t, err)
+	})
+
+	t.Run("DefaultRecipients", func(t *testing.T) {
+		grt, err := Seal(secrets, refs, &Spec{Age: &AgeSpec{}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{hoardID.Recipient().String()}, grt.Spec.Age.Recipients)
+		refsOut, err := Unseal(secrets, grt)
+		require.NoError(t, err)
+		assertRefsEqual(t, refs, refsOut)
+
+		_, err = Seal(config.SecretsManager{}, refs, &Spec{Age: &AgeSpec{}})
+		assert.Error(t, err)
+	})
+
+	t.Run("MalformedRecipient", func(t *testing.T) {
+		_, err := Seal(secrets, refs, &Spec{Age: &AgeSpec{Recipients: []string{hoardID.String()}}})
+		assert.Error(t, err)
+	})
+}
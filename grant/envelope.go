@@ -0,0 +1,47 @@
+package grant
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/monax/hoard/v8/reference"
+)
+
+// EnvelopeGrant encrypts the given references with a random data key that is wrapped by the key-management service,
+// the wrapped key is recorded in a copy of the spec so that Hoard never stores the data key itself
+func EnvelopeGrant(refs []*reference.Ref, spec *Spec, wrapper config.KeyWrapper) (*Spec, []byte, error) {
+	if wrapper == nil {
+		return nil, nil, fmt.Errorf("EnvelopeGrant cannot seal without a key wrapper configured")
+	}
+	dataKey, err := encryption.NewNonce(encryption.KeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("EnvelopeGrant failed to generate random data key: %v", err)
+	}
+	encRefs, err := SymmetricGrant(refs, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := wrapper.Wrap(spec.GetEnvelope().GetKeyID(), dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("EnvelopeGrant failed to wrap data key: %v", err)
+	}
+	spec = proto.Clone(spec).(*Spec)
+	spec.Envelope.WrappedKey = wrapped
+	return spec, encRefs, nil
+}
+
+// EnvelopeReference unwraps the data key of an envelope grant with the key-management service and decrypts its
+// references
+func EnvelopeReference(ciphertext []byte, spec *EnvelopeSpec, wrapper config.KeyWrapper,
+	version int32) ([]*reference.Ref, error) {
+	if wrapper == nil {
+		return nil, fmt.Errorf("EnvelopeReference cannot unseal without a key wrapper configured")
+	}
+	dataKey, err := wrapper.Unwrap(spec.GetKeyID(), spec.GetWrappedKey())
+	if err != nil {
+		return nil, fmt.Errorf("EnvelopeReference failed to unwrap data key: %v", err)
+	}
+	return SymmetricReference(ciphertext, dataKey, version)
+}
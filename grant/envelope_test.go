@@ -0,0 +1,64 @@
+package grant
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Wraps keys with AES-GCM under named keys held in memory
+type testWrapper map[string][]byte
+
+func (tw testWrapper) Wrap(keyID string, key []byte) ([]byte, error) {
+	kek, ok := tw[keyID]
+	if !ok {
+		return nil, fmt.Errorf("no key '%s'", keyID)
+	}
+	blob, err := encryption.Encrypt(key, make([]byte, encryption.NonceSize), kek)
+	if err != nil {
+		return nil, err
+	}
+	return blob.EncryptedData, nil
+}
+
+func (tw testWrapper) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := tw[keyID]
+	if !ok {
+		return nil, fmt.Errorf("no key '%s'", keyID)
+	}
+	return encryption.Decrypt(wrapped, make([]byte, encryption.NonceSize), kek)
+}
+
+func TestEnvelopeGrant(t *testing.T) {
+	refs := testReferences()
+	wrapper := testWrapper{"team": deriveSecret(t, []byte("kek"))}
+	secrets := config.SecretsManager{KeyWrapper: wrapper}
+
+	spec := &Spec{Envelope: &EnvelopeSpec{KeyID: "team"}}
+	grt, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.NotEmpty(t, grt.Spec.Envelope.WrappedKey)
+	assert.Nil(t, spec.Envelope.WrappedKey)
+
+	// Each grant has its own data key
+	other, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.NotEqual(t, grt.Spec.Envelope.WrappedKey, other.Spec.Envelope.WrappedKey)
+
+	refsOut, err := Unseal(secrets, grt)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+
+	// The data key cannot be recovered without the key-management service
+	_, err = Unseal(config.SecretsManager{}, grt)
+	assert.Error(t, err)
+	_, err = Unseal(config.SecretsManager{KeyWrapper: testWrapper{"team": deriveSecret(t, []byte("kek"))}}, grt)
+	assert.Error(t, err)
+
+	_, err = Seal(secrets, refs, &Spec{Envelope: &EnvelopeSpec{KeyID: "missing"}})
+	assert.Error(t, err)
+}
@@ -24,7 +24,12 @@ func Seal(secret config.SecretsManager, refs []*reference.Ref, spec *Spec) (*Gra
 		if err != nil {
 			return nil, err
 		}
-		encRef, err := SymmetricGrant(refs, secret.SecretKey)
+		var key []byte
+		grt.Spec, key, err = SymmetricSealKey(spec, secret)
+		if err != nil {
+			return nil, err
+		}
+		encRef, err := SymmetricGrant(refs, key)
 		if err != nil {
 			return nil, err
 		}
@@ -35,6 +40,16 @@ func Seal(secret config.SecretsManager, refs []*reference.Ref, spec *Spec) (*Gra
 			return nil, err
 		}
 		grt.EncryptedReferences = encRef
+	} else if s := spec.GetEnvelope(); s != nil {
+		grt.Spec, grt.EncryptedReferences, err = EnvelopeGrant(refs, spec, secret.KeyWrapper)
+		if err != nil {
+			return nil, err
+		}
+	} else if s := spec.GetAge(); s != nil {
+		grt.Spec, grt.EncryptedReferences, err = AgeGrant(refs, spec, secret.Age)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		return nil, fmt.Errorf("grant type %v not recognised", s)
 	}
@@ -50,14 +65,54 @@ func Unseal(secret config.SecretsManager, grt *Grant) ([]*reference.Ref, error)
 
 	}
 	if s := grt.Spec.GetSymmetric(); s != nil {
-		secret, err := secret.Provider(s.PublicID)
+		secrets, err := secret.SymmetricVersions(s.PublicID)
 		if err != nil {
 			return nil, err
 		}
-		return SymmetricReference(grt.EncryptedReferences, secret.SecretKey, grt.GetVersion())
+		return SymmetricUnseal(grt.EncryptedReferences, s, secrets, grt.GetVersion())
 	}
 	if s := grt.Spec.GetOpenPGP(); s != nil {
 		return OpenPGPReference(grt.EncryptedReferences, secret.OpenPGP, grt.GetVersion())
 	}
+	if s := grt.Spec.GetEnvelope(); s != nil {
+		return EnvelopeReference(grt.EncryptedReferences, s, secret.KeyWrapper, grt.GetVersion())
+	}
+	if s := grt.Spec.GetAge(); s != nil {
+		return AgeReference(grt.EncryptedReferences, s, secret.Age, grt.GetVersion())
+	}
 	return nil, fmt.Errorf("grant type not recognised")
 }
+
+// Rotate reseals a symmetric grant with the active version of its secret, returning whether it was resealed. Grants of
+// other types and those already sealed with the active version (deriving its key at the configured work factor if it
+// has a passphrase) are returned unchanged.
+func Rotate(secret config.SecretsManager, grt *Grant) (*Grant, bool, error) {
+	s := grt.GetSpec().GetSymmetric()
+	if s == nil {
+		return grt, false, nil
+	}
+	active, err := secret.Provider(s.PublicID)
+	if err != nil {
+		return nil, false, err
+	}
+	if int(s.KeyVersion) == active.KeyVersion && sealedWithKey(s, active) {
+		return grt, false, nil
+	}
+	refs, err := Unseal(secret, grt)
+	if err != nil {
+		return nil, false, err
+	}
+	grt, err = Seal(secret, refs, grt.Spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return grt, true, nil
+}
+
+// Whether a symmetric grant was sealed in the way the secret's key would be used to seal it now
+func sealedWithKey(s *SymmetricSpec, secret config.SymmetricSecret) bool {
+	if len(secret.SecretKey) > 0 {
+		return len(s.Salt) == 0
+	}
+	return len(s.Salt) > 0 && int(s.WorkFactor) == secret.PassphraseWorkFactor()
+}
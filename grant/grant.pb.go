@@ -86,10 +86,15 @@ type Spec struct {
 	// If provided then this nonce (rather than a random unique nonce) will be used when forming link-refs
 	// Grants sharing a link nonce will _share_ links (this allows some kinds of grants to be deterministic but
 	// prevents safe deletion of links)
-	LinkNonce            []byte   `protobuf:"bytes,4,opt,name=LinkNonce,json=linknonce,proto3" json:"linknonce"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LinkNonce []byte `protobuf:"bytes,4,opt,name=LinkNonce,json=linknonce,proto3" json:"linknonce"`
+	// The name of the configured store in which to place the data sealed by this grant, if empty the default store
+	// is used
+	Store                string        `protobuf:"bytes,5,opt,name=Store,json=store,proto3" json:"store,omitempty"`
+	Envelope             *EnvelopeSpec `protobuf:"bytes,6,opt,name=Envelope,json=envelope,proto3" json:"envelope,omitempty"`
+	Age                  *AgeSpec      `protobuf:"bytes,7,opt,name=Age,json=age,proto3" json:"age,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *Spec) Reset()         { *m = Spec{} }
@@ -144,6 +149,27 @@ func (m *Spec) GetLinkNonce() []byte {
 	return nil
 }
 
+func (m *Spec) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *Spec) GetEnvelope() *EnvelopeSpec {
+	if m != nil {
+		return m.Envelope
+	}
+	return nil
+}
+
+func (m *Spec) GetAge() *AgeSpec {
+	if m != nil {
+		return m.Age
+	}
+	return nil
+}
+
 type PlaintextSpec struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -176,7 +202,13 @@ var xxx_messageInfo_PlaintextSpec proto.InternalMessageInfo
 
 type SymmetricSpec struct {
 	// A non-secret identifier for a secret that is 'known' to Hoard (accessible via store or config)
-	PublicID             string   `protobuf:"bytes,1,opt,name=PublicID,json=publicid,proto3" json:"publicid"`
+	PublicID string `protobuf:"bytes,1,opt,name=PublicID,json=publicid,proto3" json:"publicid"`
+	// The random salt used to derive the key when the secret is a passphrase, set by Hoard when sealing
+	Salt []byte `protobuf:"bytes,2,opt,name=Salt,json=salt,proto3" json:"salt,omitempty"`
+	// The scrypt work factor used to derive the key when the secret is a passphrase, set by Hoard when sealing
+	WorkFactor int32 `protobuf:"varint,3,opt,name=WorkFactor,json=workfactor,proto3" json:"workfactor,omitempty"`
+	// The version of the secret's key used to seal the grant, if zero each retained version is tried when unsealing
+	KeyVersion           int32    `protobuf:"varint,4,opt,name=KeyVersion,json=keyversion,proto3" json:"keyversion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -213,6 +245,27 @@ func (m *SymmetricSpec) GetPublicID() string {
 	return ""
 }
 
+func (m *SymmetricSpec) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *SymmetricSpec) GetWorkFactor() int32 {
+	if m != nil {
+		return m.WorkFactor
+	}
+	return 0
+}
+
+func (m *SymmetricSpec) GetKeyVersion() int32 {
+	if m != nil {
+		return m.KeyVersion
+	}
+	return 0
+}
+
 type OpenPGPSpec struct {
 	PublicKey            string   `protobuf:"bytes,1,opt,name=PublicKey,json=publickey,proto3" json:"publickey"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -251,41 +304,205 @@ func (m *OpenPGPSpec) GetPublicKey() string {
 	return ""
 }
 
+type EnvelopeSpec struct {
+	// The identifier of the key held by the key-management service with which the data key is wrapped
+	KeyID string `protobuf:"bytes,1,opt,name=KeyID,json=keyid,proto3" json:"keyid"`
+	// The data key that encrypts the references as wrapped by the key-management service, set by Hoard when sealing
+	WrappedKey           []byte   `protobuf:"bytes,2,opt,name=WrappedKey,json=wrappedkey,proto3" json:"wrappedkey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnvelopeSpec) Reset()         { *m = EnvelopeSpec{} }
+func (m *EnvelopeSpec) String() string { return proto.CompactTextString(m) }
+func (*EnvelopeSpec) ProtoMessage()    {}
+func (*EnvelopeSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d80872b3060482, []int{5}
+}
+func (m *EnvelopeSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvelopeSpec.Unmarshal(m, b)
+}
+func (m *EnvelopeSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvelopeSpec.Marshal(b, m, deterministic)
+}
+func (m *EnvelopeSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvelopeSpec.Merge(m, src)
+}
+func (m *EnvelopeSpec) XXX_Size() int {
+	return xxx_messageInfo_EnvelopeSpec.Size(m)
+}
+func (m *EnvelopeSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvelopeSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvelopeSpec proto.InternalMessageInfo
+
+func (m *EnvelopeSpec) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *EnvelopeSpec) GetWrappedKey() []byte {
+	if m != nil {
+		return m.WrappedKey
+	}
+	return nil
+}
+
+type AgeSpec struct {
+	// The X25519 recipients (age1...) able to unseal the grant, if empty those of Hoard's own identities
+	Recipients []string `protobuf:"bytes,1,rep,name=Recipients,json=recipients,proto3" json:"recipients"`
+	// The data key that encrypts the references wrapped for each recipient in turn, set by Hoard when sealing
+	Stanzas              []*AgeStanza `protobuf:"bytes,2,rep,name=Stanzas,json=stanzas,proto3" json:"stanzas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *AgeSpec) Reset()         { *m = AgeSpec{} }
+func (m *AgeSpec) String() string { return proto.CompactTextString(m) }
+func (*AgeSpec) ProtoMessage()    {}
+func (*AgeSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d80872b3060482, []int{6}
+}
+func (m *AgeSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgeSpec.Unmarshal(m, b)
+}
+func (m *AgeSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgeSpec.Marshal(b, m, deterministic)
+}
+func (m *AgeSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgeSpec.Merge(m, src)
+}
+func (m *AgeSpec) XXX_Size() int {
+	return xxx_messageInfo_AgeSpec.Size(m)
+}
+func (m *AgeSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgeSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgeSpec proto.InternalMessageInfo
+
+func (m *AgeSpec) GetRecipients() []string {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+func (m *AgeSpec) GetStanzas() []*AgeStanza {
+	if m != nil {
+		return m.Stanzas
+	}
+	return nil
+}
+
+type AgeStanza struct {
+	// The ephemeral X25519 public key with which the data key was wrapped
+	Share                []byte   `protobuf:"bytes,1,opt,name=Share,json=share,proto3" json:"share"`
+	WrappedKey           []byte   `protobuf:"bytes,2,opt,name=WrappedKey,json=wrappedkey,proto3" json:"wrappedkey"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AgeStanza) Reset()         { *m = AgeStanza{} }
+func (m *AgeStanza) String() string { return proto.CompactTextString(m) }
+func (*AgeStanza) ProtoMessage()    {}
+func (*AgeStanza) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d80872b3060482, []int{7}
+}
+func (m *AgeStanza) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgeStanza.Unmarshal(m, b)
+}
+func (m *AgeStanza) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgeStanza.Marshal(b, m, deterministic)
+}
+func (m *AgeStanza) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgeStanza.Merge(m, src)
+}
+func (m *AgeStanza) XXX_Size() int {
+	return xxx_messageInfo_AgeStanza.Size(m)
+}
+func (m *AgeStanza) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgeStanza.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgeStanza proto.InternalMessageInfo
+
+func (m *AgeStanza) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *AgeStanza) GetWrappedKey() []byte {
+	if m != nil {
+		return m.WrappedKey
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Grant)(nil), "grant.Grant")
 	proto.RegisterType((*Spec)(nil), "grant.Spec")
 	proto.RegisterType((*PlaintextSpec)(nil), "grant.PlaintextSpec")
 	proto.RegisterType((*SymmetricSpec)(nil), "grant.SymmetricSpec")
 	proto.RegisterType((*OpenPGPSpec)(nil), "grant.OpenPGPSpec")
+	proto.RegisterType((*EnvelopeSpec)(nil), "grant.EnvelopeSpec")
+	proto.RegisterType((*AgeSpec)(nil), "grant.AgeSpec")
+	proto.RegisterType((*AgeStanza)(nil), "grant.AgeStanza")
 }
 
 func init() { proto.RegisterFile("grant.proto", fileDescriptor_d8d80872b3060482) }
 
 var fileDescriptor_d8d80872b3060482 = []byte{
-	// 391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xdf, 0x8a, 0xd3, 0x40,
-	0x14, 0xc6, 0x89, 0xb6, 0xb6, 0x99, 0x6c, 0x11, 0x66, 0x05, 0x83, 0x37, 0xa9, 0x01, 0x21, 0x22,
-	0x36, 0xb0, 0xde, 0xb8, 0xde, 0x19, 0x94, 0x65, 0x51, 0x34, 0xcc, 0x82, 0x17, 0xde, 0x25, 0xd3,
-	0xb3, 0xd9, 0xa1, 0xcd, 0xcc, 0x30, 0x49, 0x97, 0xe6, 0x7d, 0x7c, 0xae, 0x3c, 0x40, 0xae, 0x7d,
-	0x00, 0xc9, 0xc9, 0xbf, 0x16, 0xbc, 0x09, 0xdf, 0xf9, 0x1d, 0xce, 0x97, 0xef, 0x1c, 0x86, 0x38,
-	0x99, 0x49, 0x64, 0xb9, 0xd1, 0x46, 0x95, 0x8a, 0xce, 0xb1, 0x78, 0xf5, 0x3e, 0x13, 0xe5, 0xc3,
-	0x21, 0xdd, 0x70, 0x95, 0x87, 0x99, 0xca, 0x54, 0x88, 0xdd, 0xf4, 0x70, 0x8f, 0x15, 0x16, 0xa8,
-	0xba, 0x29, 0xff, 0x8f, 0x45, 0xe6, 0x37, 0xed, 0x20, 0x7d, 0x4b, 0x66, 0x77, 0x1a, 0xb8, 0x6b,
-	0xad, 0xad, 0xc0, 0xb9, 0x72, 0x36, 0x9d, 0x77, 0x8b, 0xa2, 0x65, 0x53, 0x7b, 0xb3, 0x42, 0x03,
-	0x67, 0xf8, 0xa5, 0xb7, 0xe4, 0xf2, 0xab, 0xe4, 0xa6, 0xd2, 0x25, 0x6c, 0x19, 0xdc, 0x83, 0x01,
-	0xc9, 0xa1, 0x70, 0x9f, 0xac, 0xad, 0xe0, 0x22, 0x7a, 0xd9, 0xd4, 0xde, 0x25, 0x0c, 0x6d, 0x33,
-	0xb6, 0xd9, 0xff, 0x20, 0x7d, 0x43, 0x16, 0xbf, 0xc0, 0x14, 0x42, 0x49, 0xf7, 0xe9, 0xda, 0x0a,
-	0xe6, 0x91, 0xd3, 0xd4, 0xde, 0xe2, 0xb1, 0x43, 0x6c, 0x10, 0xfe, 0x5f, 0xab, 0x4b, 0x47, 0x3f,
-	0x13, 0x3b, 0xde, 0x27, 0x42, 0x96, 0x70, 0x2c, 0xfb, 0xa8, 0x2f, 0xfa, 0xa8, 0x23, 0xc7, 0xcc,
-	0xab, 0xa6, 0xf6, 0x6c, 0x3d, 0x20, 0x36, 0xc9, 0xd6, 0xe2, 0xae, 0xca, 0x73, 0x28, 0x8d, 0xe0,
-	0x98, 0x79, 0xb2, 0x18, 0xf9, 0x64, 0x51, 0x0c, 0x88, 0x4d, 0x92, 0x5e, 0x93, 0xc5, 0x4f, 0x0d,
-	0x32, 0xbe, 0x89, 0x31, 0xb5, 0x73, 0x45, 0x7b, 0x83, 0x9e, 0xe2, 0x38, 0x6e, 0xa2, 0x34, 0x48,
-	0x9d, 0x69, 0x36, 0x08, 0xfa, 0x8e, 0xd8, 0xdf, 0x85, 0xdc, 0xfd, 0x50, 0x92, 0x83, 0x3b, 0xc3,
-	0x8b, 0xe1, 0x7f, 0xf6, 0x42, 0xee, 0x64, 0x0b, 0xd9, 0x24, 0xfd, 0xe7, 0x64, 0x75, 0xb6, 0x95,
-	0x7f, 0x4d, 0x56, 0x67, 0x19, 0x69, 0x40, 0x96, 0xf1, 0x21, 0xdd, 0x0b, 0x7e, 0xfb, 0x05, 0xcf,
-	0x61, 0x47, 0x17, 0x4d, 0xed, 0x2d, 0x35, 0x32, 0xb1, 0x65, 0xa3, 0xf2, 0x3f, 0x11, 0xe7, 0x24,
-	0x5d, 0x9b, 0xa3, 0x1b, 0xfc, 0x06, 0x55, 0x3f, 0xd9, 0x9d, 0x0c, 0xe1, 0x0e, 0x2a, 0x36, 0xc9,
-	0xe8, 0xf5, 0x6f, 0xef, 0xe4, 0x59, 0xe5, 0x4a, 0x26, 0xc7, 0xf0, 0x41, 0x25, 0x66, 0x1b, 0x3e,
-	0x7e, 0x0c, 0x71, 0xf3, 0xf4, 0x19, 0xbe, 0xa7, 0x0f, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x91,
-	0x6b, 0x2d, 0x52, 0x94, 0x02, 0x00, 0x00,
+	// 678 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x94, 0xcf, 0x6e, 0xdb, 0x38,
+	0x10, 0xc6, 0xa1, 0xd8, 0x8a, 0x2d, 0xda, 0x4e, 0x36, 0x74, 0x76, 0x57, 0xd8, 0x0b, 0xbd, 0x02,
+	0x76, 0xe1, 0x60, 0xb7, 0x36, 0x90, 0x5c, 0xd2, 0xde, 0x2c, 0x34, 0x0d, 0x82, 0x14, 0xad, 0x41,
+	0x03, 0x2d, 0x50, 0xf4, 0x22, 0xcb, 0x13, 0x45, 0x90, 0x4d, 0x12, 0x14, 0x93, 0x46, 0x45, 0x5f,
+	0xa5, 0xb7, 0xbe, 0x52, 0xaf, 0x7a, 0x00, 0x3d, 0x45, 0x21, 0xea, 0xaf, 0x81, 0x5c, 0xac, 0xe1,
+	0x8f, 0x9a, 0xd1, 0xf7, 0x71, 0xc6, 0x44, 0x83, 0x40, 0x7a, 0x4c, 0xcd, 0x84, 0xe4, 0x8a, 0x63,
+	0x53, 0x2f, 0xfe, 0x7a, 0x11, 0x84, 0xea, 0xfe, 0x61, 0x3d, 0xf3, 0xf9, 0x6e, 0x1e, 0xf0, 0x80,
+	0xcf, 0xf5, 0xee, 0xfa, 0xe1, 0x4e, 0xaf, 0xf4, 0x42, 0x47, 0x45, 0x96, 0xf3, 0xc3, 0x40, 0xe6,
+	0x75, 0x9e, 0x88, 0xcf, 0x50, 0x77, 0x25, 0xc0, 0xb7, 0x8d, 0x89, 0x31, 0x1d, 0x9c, 0x0f, 0x66,
+	0x45, 0xed, 0x1c, 0xb9, 0xfd, 0x2c, 0x25, 0xdd, 0x58, 0x80, 0x4f, 0xf5, 0x2f, 0xbe, 0x41, 0xe3,
+	0x2b, 0xe6, 0xcb, 0x44, 0x28, 0xd8, 0x50, 0xb8, 0x03, 0x09, 0xcc, 0x87, 0xd8, 0x3e, 0x98, 0x18,
+	0xd3, 0xa1, 0xfb, 0x67, 0x96, 0x92, 0x31, 0x54, 0xdb, 0xb2, 0xde, 0xa6, 0xcf, 0x41, 0xfc, 0x0f,
+	0xea, 0x7d, 0x00, 0x19, 0x87, 0x9c, 0xd9, 0x9d, 0x89, 0x31, 0x35, 0xdd, 0x41, 0x96, 0x92, 0xde,
+	0x63, 0x81, 0x68, 0x15, 0x38, 0xdf, 0x3b, 0x85, 0x3a, 0xbc, 0x40, 0xd6, 0x72, 0xeb, 0x85, 0x4c,
+	0xc1, 0x93, 0x2a, 0xa5, 0x9e, 0x96, 0x52, 0x6b, 0xae, 0x35, 0x8f, 0xb2, 0x94, 0x58, 0xa2, 0x42,
+	0xb4, 0x09, 0xf3, 0x12, 0xab, 0x64, 0xb7, 0x03, 0x25, 0x43, 0xdf, 0x3e, 0xd8, 0x2b, 0x51, 0xf3,
+	0xa6, 0x44, 0x5c, 0x21, 0xda, 0x84, 0xf8, 0x25, 0xea, 0xbd, 0x17, 0xc0, 0x96, 0xd7, 0x4b, 0xad,
+	0x7a, 0x70, 0x8e, 0xcb, 0x02, 0x25, 0xd5, 0xe9, 0xda, 0x09, 0x17, 0xc0, 0x44, 0x20, 0x68, 0x15,
+	0xe0, 0xff, 0x90, 0xf5, 0x36, 0x64, 0xd1, 0x3b, 0xce, 0x7c, 0xb0, 0xbb, 0xfa, 0xc4, 0xf4, 0x77,
+	0xb6, 0x21, 0x8b, 0x58, 0x0e, 0x69, 0x13, 0xe2, 0x33, 0x64, 0xae, 0x14, 0x97, 0x60, 0x9b, 0x13,
+	0x63, 0x6a, 0xb9, 0xe3, 0x2c, 0x25, 0xc7, 0x71, 0x0e, 0xfe, 0xe7, 0xbb, 0x50, 0xc1, 0x4e, 0xa8,
+	0x84, 0x9a, 0x1a, 0xe0, 0x6b, 0xd4, 0xbf, 0x62, 0x8f, 0xb0, 0xe5, 0x02, 0xec, 0x43, 0xad, 0x69,
+	0x5c, 0x6a, 0xaa, 0xb0, 0x16, 0xf5, 0x47, 0x96, 0x12, 0x0c, 0x25, 0x69, 0x55, 0xe9, 0x57, 0x0c,
+	0x5f, 0xa0, 0xce, 0x22, 0x00, 0xbb, 0xa7, 0x6b, 0x1c, 0x95, 0x35, 0x16, 0x41, 0x91, 0x7e, 0x92,
+	0xa5, 0x64, 0xe4, 0x05, 0xed, 0xcc, 0x8e, 0x17, 0x80, 0x73, 0x8c, 0x46, 0x7b, 0xc7, 0xef, 0xfc,
+	0x34, 0xd0, 0x68, 0xef, 0x34, 0xf1, 0x14, 0xf5, 0x97, 0x0f, 0xeb, 0x6d, 0xe8, 0xdf, 0xbc, 0xd6,
+	0x8d, 0xb3, 0xdc, 0x61, 0x96, 0x92, 0xbe, 0xd0, 0x2c, 0xdc, 0xd0, 0x3a, 0xc2, 0xff, 0xa2, 0xee,
+	0xca, 0xdb, 0xaa, 0x72, 0x9e, 0x70, 0x96, 0x92, 0xa3, 0xd8, 0xdb, 0xaa, 0xd6, 0x37, 0xbb, 0xf9,
+	0x1a, 0x5f, 0x22, 0xf4, 0x91, 0xcb, 0xe8, 0x8d, 0xe7, 0x2b, 0x2e, 0xcb, 0xf1, 0xb1, 0xb3, 0x94,
+	0x9c, 0x7e, 0xe1, 0x32, 0xba, 0xd3, 0xb4, 0x95, 0x83, 0x1a, 0x9a, 0x67, 0xde, 0x42, 0x52, 0x0d,
+	0x5e, 0xb7, 0xc9, 0x8c, 0x20, 0x29, 0x47, 0xae, 0x9d, 0xd9, 0x50, 0xe7, 0x15, 0x1a, 0xb4, 0x7a,
+	0x9c, 0x77, 0xb3, 0x30, 0x75, 0x0b, 0x49, 0xe9, 0xaa, 0x18, 0x3c, 0x0d, 0x23, 0x48, 0x68, 0x13,
+	0x3a, 0x21, 0x1a, 0xb6, 0x7b, 0x81, 0x09, 0x32, 0x6f, 0x21, 0xa9, 0x8f, 0xc3, 0xca, 0x52, 0x62,
+	0x46, 0x90, 0x84, 0x1b, 0x5a, 0x3c, 0xb4, 0x41, 0xe9, 0x09, 0x01, 0x9b, 0xbc, 0x7c, 0x71, 0x1c,
+	0x85, 0xc1, 0x82, 0x46, 0x90, 0xec, 0x19, 0xac, 0xa9, 0xf3, 0x0d, 0xf5, 0xca, 0x96, 0xe1, 0x19,
+	0x42, 0x14, 0xfc, 0x50, 0x84, 0xc0, 0x54, 0x6c, 0x1b, 0x93, 0xce, 0xd4, 0x72, 0x8f, 0xb2, 0x94,
+	0x20, 0x59, 0x53, 0xda, 0x8a, 0xf1, 0x02, 0xf5, 0x56, 0xca, 0x63, 0x5f, 0xbd, 0xfc, 0x0f, 0xdd,
+	0x99, 0x0e, 0xce, 0x7f, 0x6b, 0xcd, 0x80, 0xde, 0x70, 0x7f, 0xcf, 0x52, 0x72, 0x12, 0x17, 0x2f,
+	0xb5, 0x04, 0xf4, 0x4a, 0xe4, 0x7c, 0x46, 0x56, 0xfd, 0x72, 0xee, 0x72, 0x75, 0xef, 0x49, 0xd0,
+	0x2e, 0x87, 0x85, 0xcb, 0x38, 0x07, 0xb4, 0x78, 0xe0, 0xd9, 0x33, 0x2e, 0xb5, 0xc0, 0xc6, 0x4f,
+	0xdb, 0x9b, 0xfb, 0xf7, 0x27, 0xd2, 0xba, 0xe3, 0x76, 0x9c, 0x79, 0x4f, 0xf3, 0x7b, 0xee, 0xc9,
+	0xcd, 0xfc, 0xf1, 0x72, 0xae, 0xa5, 0xae, 0x0f, 0xf5, 0xe5, 0x76, 0xf1, 0x6b, 0x00, 0x67, 0xfb,
+	0x17, 0x91, 0x21, 0x05, 0x00, 0x00,
 }
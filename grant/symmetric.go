@@ -3,6 +3,8 @@ package grant
 import (
 	"fmt"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/monax/hoard/v8/config"
 	"github.com/monax/hoard/v8/encryption"
 	"github.com/monax/hoard/v8/reference"
 )
@@ -39,3 +41,76 @@ func SymmetricReference(ciphertext, secret []byte, version int32) ([]*reference.
 	}
 	return reference.RefsFromPlaintext(data, version)
 }
+
+// SymmetricSealKey returns the key with which to seal a symmetric grant and the spec to store with it. A secret with
+// only a passphrase has its key derived using a fresh random salt, which is recorded in a copy of the spec along with
+// the work factor so the key can be derived again on unseal. The version of the secret's key is recorded likewise.
+func SymmetricSealKey(spec *Spec, secret config.SymmetricSecret) (*Spec, []byte, error) {
+	key := secret.SecretKey
+	var salt []byte
+	workFactor := 0
+	if len(key) == 0 && secret.Passphrase != "" {
+		var err error
+		salt, err = encryption.NewNonce(encryption.NonceSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("SymmetricSealKey failed to generate random salt: %v", err)
+		}
+		workFactor = secret.PassphraseWorkFactor()
+		key, err = encryption.DeriveSecretKeyWithWorkFactor([]byte(secret.Passphrase), salt, workFactor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("SymmetricSealKey failed to derive key from passphrase: %v", err)
+		}
+	}
+	s := spec.GetSymmetric()
+	if len(s.GetSalt()) == 0 && len(salt) == 0 && int(s.GetWorkFactor()) == workFactor &&
+		int(s.GetKeyVersion()) == secret.KeyVersion {
+		return spec, key, nil
+	}
+	spec = proto.Clone(spec).(*Spec)
+	spec.Symmetric.Salt = salt
+	spec.Symmetric.WorkFactor = int32(workFactor)
+	spec.Symmetric.KeyVersion = int32(secret.KeyVersion)
+	return spec, key, nil
+}
+
+// SymmetricUnseal decrypts the references of a symmetric grant with the version of its secret recorded in the spec or,
+// if none is recorded, with each of the given versions in turn
+func SymmetricUnseal(ciphertext []byte, spec *SymmetricSpec, secrets []config.SymmetricSecret,
+	version int32) ([]*reference.Ref, error) {
+	err := fmt.Errorf("symmetric secret '%s' has no key with version %d", spec.GetPublicID(), spec.GetKeyVersion())
+	for _, secret := range secrets {
+		if spec.GetKeyVersion() != 0 && int32(secret.KeyVersion) != spec.GetKeyVersion() {
+			continue
+		}
+		var key []byte
+		key, err = SymmetricUnsealKey(spec, secret)
+		if err != nil {
+			continue
+		}
+		var refs []*reference.Ref
+		refs, err = SymmetricReference(ciphertext, key, version)
+		if err == nil {
+			return refs, nil
+		}
+	}
+	return nil, err
+}
+
+// SymmetricUnsealKey returns the key with which a symmetric grant was sealed, deriving it from the passphrase if the
+// spec carries a salt
+func SymmetricUnsealKey(spec *SymmetricSpec, secret config.SymmetricSecret) ([]byte, error) {
+	if len(spec.GetSalt()) == 0 {
+		return secret.SecretKey, nil
+	}
+	if secret.Passphrase == "" {
+		return nil, fmt.Errorf("grant was sealed with a passphrase but symmetric secret '%s' has none",
+			spec.GetPublicID())
+	}
+	// The work factor comes from the grant so we bound it by our own to stop a grant from exhausting our memory
+	workFactor := int(spec.GetWorkFactor())
+	if workFactor > secret.PassphraseWorkFactor() {
+		return nil, fmt.Errorf("grant work factor %d exceeds the work factor %d configured for symmetric secret '%s'",
+			workFactor, secret.PassphraseWorkFactor(), spec.GetPublicID())
+	}
+	return encryption.DeriveSecretKeyWithWorkFactor([]byte(secret.Passphrase), spec.GetSalt(), workFactor)
+}
@@ -1,13 +1,16 @@
 package grant
 
 import (
+	"os"
 	"testing"
 
 	"github.com/monax/hoard/v8/versions"
 
+	"github.com/monax/hoard/v8/config"
 	"github.com/monax/hoard/v8/encryption"
 	"github.com/monax/hoard/v8/reference"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSymmetricGrant(t *testing.T) {
@@ -32,3 +35,165 @@ func TestSymmetricGrant(t *testing.T) {
 	assert.NoError(t, err)
 	assertRefsEqual(t, ref, refOut)
 }
+
+func TestSymmetricPassphrase(t *testing.T) {
+	refs := testReferences()
+	passphrase := config.SymmetricSecret{Passphrase: "correct horse battery staple", WorkFactor: 4}
+	secrets := config.SecretsManager{
+		Provider: func(_ string) (config.SymmetricSecret, error) {
+			return passphrase, nil
+		},
+	}
+
+	spec := &Spec{Symmetric: &SymmetricSpec{PublicID: "test"}}
+	grt, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.Len(t, grt.Spec.Symmetric.Salt, encryption.NonceSize)
+	assert.Equal(t, int32(4), grt.Spec.Symmetric.WorkFactor)
+	// The caller's spec is left alone
+	assert.Nil(t, spec.Symmetric.Salt)
+
+	// Each grant gets its own salt
+	other, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.NotEqual(t, grt.Spec.Symmetric.Salt, other.Spec.Symmetric.Salt)
+
+	refsOut, err := Unseal(secrets, grt)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+
+	// Raising the work factor leaves existing grants readable
+	passphrase.WorkFactor = 5
+	refsOut, err = Unseal(secrets, grt)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+
+	passphrase.Passphrase = "incorrect horse battery staple"
+	_, err = Unseal(secrets, grt)
+	assert.Error(t, err)
+
+	// We will not derive at a greater cost than configured
+	passphrase.WorkFactor = 3
+	_, err = Unseal(secrets, grt)
+	assert.Error(t, err)
+}
+
+func TestSymmetricRotation(t *testing.T) {
+	refs := testReferences()
+	keys := []config.SymmetricSecret{{SecretKey: deriveSecret(t, []byte("one")), KeyVersion: 1}}
+	secrets := config.SecretsManager{
+		Versions: func(_ string) ([]config.SymmetricSecret, error) {
+			return keys, nil
+		},
+	}
+	secrets.Provider = secrets.Versions.Active()
+
+	spec := &Spec{Symmetric: &SymmetricSpec{PublicID: "test"}}
+	grtOne, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), grtOne.Spec.Symmetric.KeyVersion)
+
+	// Grants sealed before keys were versioned are unsealed by trying each retained key
+	unversioned, err := SymmetricGrant(refs, keys[0].SecretKey)
+	require.NoError(t, err)
+	grtOld := &Grant{Spec: spec, EncryptedReferences: unversioned, Version: versions.LatestGrantVersion}
+
+	keys = append([]config.SymmetricSecret{{Passphrase: "two", WorkFactor: 4, KeyVersion: 2}}, keys...)
+	grtTwo, err := Seal(secrets, refs, spec)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), grtTwo.Spec.Symmetric.KeyVersion)
+
+	for _, grt := range []*Grant{grtOne, grtOld, grtTwo} {
+		refsOut, err := Unseal(secrets, grt)
+		require.NoError(t, err)
+		assertRefsEqual(t, refs, refsOut)
+	}
+
+	rotated, ok, err := Rotate(secrets, grtOne)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), rotated.Spec.Symmetric.KeyVersion)
+	assert.NotEmpty(t, rotated.Spec.Symmetric.Salt)
+
+	_, ok, err = Rotate(secrets, rotated)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Once the old key is dropped only the rotated grant can be unsealed
+	keys = keys[:1]
+	_, err = Unseal(secrets, grtOne)
+	assert.Error(t, err)
+	refsOut, err := Unseal(secrets, rotated)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+}
+
+func TestSymmetricRotationUnversioned(t *testing.T) {
+	refs := testReferences()
+	secret := config.SymmetricSecret{Passphrase: "correct horse battery staple", WorkFactor: 4}
+	secrets := config.SecretsManager{
+		Provider: func(_ string) (config.SymmetricSecret, error) {
+			return secret, nil
+		},
+	}
+	secrets.Versions = func(_ string) ([]config.SymmetricSecret, error) {
+		return []config.SymmetricSecret{secret}, nil
+	}
+
+	grt, err := Seal(secrets, refs, &Spec{Symmetric: &SymmetricSpec{PublicID: "test"}})
+	require.NoError(t, err)
+
+	// A grant sealed with an unversioned secret is left alone while the secret is unchanged
+	rotated, ok, err := Rotate(secrets, grt)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, grt, rotated)
+
+	// But is resealed once the work factor is raised
+	secret.WorkFactor = 5
+	rotated, ok, err = Rotate(secrets, grt)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(5), rotated.Spec.Symmetric.WorkFactor)
+	_, ok, err = Rotate(secrets, rotated)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSymmetricLegacyEnvSecret(t *testing.T) {
+	refs := testReferences()
+	const publicID = "HOARD_TEST_LEGACY_SECRET"
+	value := "0123456789abcdef0123456789abcdef"
+	os.Setenv(publicID, value)
+	defer os.Unsetenv(publicID)
+
+	// Sealed as before passphrases were salted, with the raw value of the environment variable as the key
+	encryptedRefs, err := SymmetricGrant(refs, []byte(value))
+	require.NoError(t, err)
+	legacy := &Grant{
+		Spec:                &Spec{Symmetric: &SymmetricSpec{PublicID: publicID}},
+		EncryptedReferences: encryptedRefs,
+		Version:             versions.LatestGrantVersion,
+	}
+
+	conf := &config.Secrets{Symmetric: []*config.SymmetricSecret{{PublicID: publicID, WorkFactor: 4}}}
+	versionProvider, err := config.NewSymmetricVersionProvider(conf, true)
+	require.NoError(t, err)
+	secrets := config.SecretsManager{Provider: versionProvider.Active(), Versions: versionProvider}
+
+	refsOut, err := Unseal(secrets, legacy)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+
+	// New grants are sealed with a key derived from the value as a passphrase, to which legacy grants are rotated
+	rotated, ok, err := Rotate(secrets, legacy)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, rotated.Spec.Symmetric.Salt, encryption.NonceSize)
+	refsOut, err = Unseal(secrets, rotated)
+	require.NoError(t, err)
+	assertRefsEqual(t, refs, refsOut)
+	_, ok, err = Rotate(secrets, rotated)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
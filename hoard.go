@@ -1,6 +1,7 @@
 package hoard
 
 import (
+	"bytes"
 	"crypto/sha256"
 
 	"github.com/go-kit/kit/log"
@@ -9,6 +10,8 @@ import (
 	"github.com/monax/hoard/v8/grant"
 	"github.com/monax/hoard/v8/reference"
 	"github.com/monax/hoard/v8/stores"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type EncryptionService interface {
@@ -24,10 +27,16 @@ type ObjectService interface {
 	Get(ref *reference.Ref) (data []byte, err error)
 	// Encrypt data and put it in underlying storage
 	Put(data, salt []byte) (*reference.Ref, error)
+	// Encrypt data and put it in the named underlying storage
+	PutTo(storeName string, data, salt []byte) (*reference.Ref, error)
+	// Encrypt data and put it in the named underlying storage unless it is already stored, returning whether it was
+	PutNewTo(storeName string, data, salt []byte) (ref *reference.Ref, existed bool, err error)
 	// Delete underlying data obtained by address
 	Delete(address []byte) error
-	// Get the underlying ContentAddressedStore
+	// Get the underlying (default) ContentAddressedStore
 	Store() stores.ContentAddressedStore
+	// Get the underlying ContentAddressedStore with the given name, the default store if name is empty
+	Route(storeName string) (stores.ContentAddressedStore, error)
 }
 
 type GrantService interface {
@@ -36,6 +45,20 @@ type GrantService interface {
 	Seal(refs []*reference.Ref, spec *grant.Spec) (*grant.Grant, error)
 	// Unseal a grant by decrypting it and returning the reference
 	Unseal(grt *grant.Grant) ([]*reference.Ref, error)
+	// Unseal a grant and delete all the data reachable from its references
+	UnsealDelete(grt *grant.Grant, deleted func(ref *reference.Ref) error) error
+	// Unseal a grant and delete all the data reachable from its references that is not reachable from keep
+	UnsealDeleteRecursive(grt *grant.Grant, keep []*grant.Grant, force bool, deleted func(ref *reference.Ref) error) error
+}
+
+func ErrorSharedLinkNonce() error {
+	return status.Errorf(codes.FailedPrecondition, "refusing to delete grant sealed with a shared link nonce "+
+		"since its LINK refs may be shared with other grants")
+}
+
+func ErrorNoKeepGrants() error {
+	return status.Errorf(codes.FailedPrecondition, "refusing to delete grant without grants to keep since its chunks "+
+		"may be shared with other grants, pass force to delete every chunk anyway")
 }
 
 // This is our top level API object providing library acting as a deterministic
@@ -44,20 +67,31 @@ type GrantService interface {
 // hoard.proto interface.
 type Hoard struct {
 	name    string
-	store   stores.ContentAddressedStore
+	store   *stores.RoutingStore
 	secrets config.SecretsManager
 	logger  log.Logger
 }
 
 func NewHoard(store stores.NamedStore, secrets config.SecretsManager, logger log.Logger) *Hoard {
+	return NewRoutingHoard(store, nil, secrets, logger)
+}
+
+// NewRoutingHoard creates a Hoard that places data in defaultStore unless one of the named routes is selected
+// by a grant spec or header. The name of the store used is recorded in the reference so it can be retrieved.
+func NewRoutingHoard(defaultStore stores.NamedStore, routes map[string]stores.NamedStore, secrets config.SecretsManager,
+	logger log.Logger) *Hoard {
 	if logger == nil {
 		logger = log.NewNopLogger()
 	}
 
+	casRoutes := make(map[string]stores.ContentAddressedStore, len(routes))
+	for name, store := range routes {
+		casRoutes[name] = newContentAddressedStore(store, logger)
+	}
+
 	return &Hoard{
-		name: store.Name(),
-		store: stores.NewContentAddressedStore(stores.MakeAddresser(sha256.New),
-			stores.NewLoggingStore(stores.NewSyncStore(store), logger)),
+		name:    defaultStore.Name(),
+		store:   stores.NewRoutingStore(newContentAddressedStore(defaultStore, logger), casRoutes),
 		secrets: secrets,
 		logger:  log.With(logger, "scope", "NewHoard"),
 	}
@@ -75,9 +109,47 @@ func (hrd *Hoard) Unseal(grt *grant.Grant) ([]*reference.Ref, error) {
 	return grant.Unseal(hrd.secrets, grt)
 }
 
+// UnsealDelete unseals a grant and deletes the data behind each of its references, following any LINK refs to delete
+// every chunk. Chunks with identical plaintext and salt are shared by convergent encryption (as are the body chunks of
+// an annotated grant and its original) so to protect data shared with other grants use UnsealDeleteRecursive. Grants
+// sealed with a LinkNonce may share their LINK refs with grants that are not known so are refused.
+func (hrd *Hoard) UnsealDelete(grt *grant.Grant, deleted func(ref *reference.Ref) error) error {
+	return hrd.UnsealDeleteRecursive(grt, nil, true, deleted)
+}
+
+// UnsealDeleteRecursive is UnsealDelete except that any chunk reachable from the keep grants is not deleted. Since the
+// grants sharing data cannot be discovered from the store the request is refused unless keep grants are given or force
+// is set.
+func (hrd *Hoard) UnsealDeleteRecursive(grt *grant.Grant, keep []*grant.Grant, force bool,
+	deleted func(ref *reference.Ref) error) error {
+	if len(grt.GetSpec().GetLinkNonce()) > 0 {
+		return ErrorSharedLinkNonce()
+	}
+	if len(keep) == 0 && !force {
+		return ErrorNoKeepGrants()
+	}
+	refs, err := hrd.Unseal(grt)
+	if err != nil {
+		return err
+	}
+	var keepRefs []*reference.Ref
+	for _, k := range keep {
+		kept, err := hrd.Unseal(k)
+		if err != nil {
+			return err
+		}
+		keepRefs = append(keepRefs, kept...)
+	}
+	return DeleteRecursive(hrd, refs, keepRefs, deleted)
+}
+
 // Gets encrypted blob
 func (hrd *Hoard) Get(ref *reference.Ref) ([]byte, error) {
-	encryptedData, err := hrd.store.Get(ref.Address)
+	store, err := hrd.store.Route(ref.Store)
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, err := readBlob(store, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -89,17 +161,77 @@ func (hrd *Hoard) Get(ref *reference.Ref) ([]byte, error) {
 	return data, nil
 }
 
-// Encrypts data and storage it in underlying store and returns the address
-func (hrd *Hoard) Put(data, salt []byte) (*reference.Ref, error) {
-	blob, err := encryption.EncryptConvergent(data, salt)
+// The most memory reserved up front for a blob from the plaintext size recorded in its ref, which a client may set
+const maxBlobReservation = 64 << 20
+
+// Reads the whole blob behind ref through the store's reader when it has one. The buffer is sized from the plaintext
+// size recorded in ref so that a large chunk is read without growing (and so copying) it along the way.
+func readBlob(store stores.ReadStore, ref *reference.Ref) ([]byte, error) {
+	reader, err := stores.GetReader(store, ref.Address, 0, -1)
 	if err != nil {
 		return nil, err
 	}
-	address, err := hrd.store.Put(blob.EncryptedData)
+	defer reader.Close()
+	size := encryption.ConvergentCiphertextSize(0, ref.Salt)
+	if ref.Size_ > 0 {
+		size = encryption.ConvergentCiphertextSize(ref.Size_, ref.Salt)
+	}
+	if size > maxBlobReservation {
+		size = maxBlobReservation
+	}
+	buf := new(bytes.Buffer)
+	// ReadFrom only grows the buffer when it has less than MinRead bytes free
+	buf.Grow(int(size) + bytes.MinRead)
+	_, err = buf.ReadFrom(reader)
 	if err != nil {
 		return nil, err
 	}
-	return reference.New(address, blob.SecretKey, salt, int64(len(data))), nil
+	return buf.Bytes(), nil
+}
+
+// Encrypts data and storage it in underlying store and returns the address
+func (hrd *Hoard) Put(data, salt []byte) (*reference.Ref, error) {
+	return hrd.PutTo("", data, salt)
+}
+
+// Encrypts data and stores it in the named store returning a reference that records the store
+func (hrd *Hoard) PutTo(storeName string, data, salt []byte) (*reference.Ref, error) {
+	ref, _, err := hrd.putTo(storeName, data, salt, false)
+	return ref, err
+}
+
+// Encrypt data and put it in the named store unless it is already stored, returning whether it was
+func (hrd *Hoard) PutNewTo(storeName string, data, salt []byte) (*reference.Ref, bool, error) {
+	return hrd.putTo(storeName, data, salt, true)
+}
+
+func (hrd *Hoard) putTo(storeName string, data, salt []byte, checkExists bool) (*reference.Ref, bool, error) {
+	store, err := hrd.store.Route(storeName)
+	if err != nil {
+		return nil, false, err
+	}
+	blob, err := encryption.EncryptConvergent(data, salt)
+	if err != nil {
+		return nil, false, err
+	}
+	address := store.Address(blob.EncryptedData)
+	exists := false
+	if checkExists {
+		statInfo, err := store.Stat(address)
+		if err != nil {
+			return nil, false, err
+		}
+		exists = statInfo.Exists
+	}
+	if !exists {
+		address, err = store.Put(blob.EncryptedData)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	ref := reference.New(address, blob.SecretKey, salt, int64(len(data)))
+	ref.Store = storeName
+	return ref, exists, nil
 }
 
 func (hrd *Hoard) Delete(address []byte) error {
@@ -128,3 +260,17 @@ func (hrd *Hoard) Decrypt(ref *reference.Ref, encryptedData []byte) ([]byte, err
 func (hrd *Hoard) Store() stores.ContentAddressedStore {
 	return hrd.store
 }
+
+func (hrd *Hoard) Route(storeName string) (stores.ContentAddressedStore, error) {
+	return hrd.store.Route(storeName)
+}
+
+// Names of the stores that can be selected in addition to the default store
+func (hrd *Hoard) StoreNames() []string {
+	return hrd.store.Names()
+}
+
+func newContentAddressedStore(store stores.NamedStore, logger log.Logger) stores.ContentAddressedStore {
+	return stores.NewContentAddressedStore(stores.MakeAddresser(sha256.New),
+		stores.NewLoggingStore(stores.NewSyncStore(store), logger))
+}
@@ -1,13 +1,23 @@
 package hoard
 
 import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/api"
 	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/protodet"
 	"github.com/monax/hoard/v8/reference"
 	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/monax/hoard/v8/versions"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeterministicEncryptedStore(t *testing.T) {
@@ -40,8 +50,498 @@ func TestDeterministicEncryptedStore(t *testing.T) {
 	assert.False(t, statInfo.Exists)
 }
 
+func TestGetReadsStreamingStores(t *testing.T) {
+	store := &readerCountingStore{NamedStore: stores.NewMemoryStore()}
+	hrd := NewHoard(store, config.NoopSecretManager, log.NewNopLogger())
+	ref, err := hrd.Put([]byte(helpers.LongText), []byte("salt"))
+	require.NoError(t, err)
+
+	data, err := hrd.Get(ref)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(helpers.LongText), data)
+	assert.Equal(t, int64(1), store.readers)
+
+	// The size recorded in a ref only guides how much memory is reserved
+	for _, size := range []int64{-1, 0, 1, 1 << 62} {
+		data, err = hrd.Get(reference.New(ref.Address, ref.SecretKey, ref.Salt, size))
+		require.NoError(t, err)
+		assert.Equal(t, []byte(helpers.LongText), data)
+	}
+}
+
+// Counts the readers opened on a store
+type readerCountingStore struct {
+	stores.NamedStore
+	readers int64
+}
+
+func (rs *readerCountingStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	atomic.AddInt64(&rs.readers, 1)
+	return stores.GetReader(rs.NamedStore, address, offset, length)
+}
+
 func pad(s string, n int) []byte {
 	b := make([]byte, n)
 	copy(b, []byte(s))
 	return b
 }
+
+func TestRoutingHoard(t *testing.T) {
+	defaultStore := stores.NewMemoryStore()
+	privateStore := stores.NewMemoryStore()
+	hrd := NewRoutingHoard(defaultStore, map[string]stores.NamedStore{"private": privateStore},
+		config.NoopSecretManager, log.NewNopLogger())
+	assert.Equal(t, []string{"private"}, hrd.StoreNames())
+
+	bunsIn := []byte("secret buns")
+	ref, err := hrd.PutTo("private", bunsIn, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "private", ref.Store)
+
+	statInfo, err := privateStore.Stat(ref.Address)
+	require.NoError(t, err)
+	assert.True(t, statInfo.Exists)
+	statInfo, err = defaultStore.Stat(ref.Address)
+	require.NoError(t, err)
+	assert.False(t, statInfo.Exists)
+
+	bunsOut, err := hrd.Get(ref)
+	require.NoError(t, err)
+	assert.Equal(t, bunsIn, bunsOut)
+
+	ref, err = hrd.Put(bunsIn, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", ref.Store)
+	statInfo, err = defaultStore.Stat(ref.Address)
+	require.NoError(t, err)
+	assert.True(t, statInfo.Exists)
+
+	_, err = hrd.PutTo("public", bunsIn, nil)
+	assert.Error(t, err)
+
+	ref.Store = "public"
+	_, err = hrd.Get(ref)
+	assert.Error(t, err)
+}
+
+func TestPutSealToNamedStore(t *testing.T) {
+	privateStore := stores.NewMemoryStore()
+	hrd := NewRoutingHoard(stores.NewMemoryStore(), map[string]stores.NamedStore{"private": privateStore},
+		config.NoopSecretManager, log.NewNopLogger())
+	service := NewStreamingService(hrd, DefaultChunkSize)
+
+	data := []byte(helpers.LongText)
+	var grt *grant.Grant
+	err := service.PutSeal(func(g *grant.Grant) error {
+		grt = g
+		return nil
+	}, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: &api.Header{Salt: []byte("salt")}, Body: data},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}, Store: "private"},
+	}))
+	require.NoError(t, err)
+
+	refs, err := hrd.Unseal(grt)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "private", refs[0].Store)
+	statInfo, err := privateStore.Stat(refs[0].Address)
+	require.NoError(t, err)
+	assert.True(t, statInfo.Exists)
+
+	plaintext := new(api.Plaintext)
+	err = service.UnsealGet(grt, func(pt *api.Plaintext) error {
+		plaintext.Body = append(plaintext.Body, pt.GetBody()...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, plaintext.Body)
+
+	// Spec and header must not disagree
+	err = service.PutSeal(func(g *grant.Grant) error { return nil }, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: &api.Header{Store: "public"}, Body: data},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}, Store: "private"},
+	}))
+	assert.Error(t, err)
+}
+
+func sendOnce(ptgs *api.PlaintextAndGrantSpec) func() (*api.PlaintextAndGrantSpec, error) {
+	return func() (*api.PlaintextAndGrantSpec, error) {
+		if ptgs == nil {
+			return nil, io.EOF
+		}
+		defer func() { ptgs = nil }()
+		return ptgs, nil
+	}
+}
+
+func TestUnsealGetRange(t *testing.T) {
+	hrd := &countingGrantService{GrantService: NewHoard(stores.NewMemoryStore(), config.NoopSecretManager, nil)}
+	service := NewStreamingService(hrd, 10)
+
+	data := []byte(helpers.LongText)[:95]
+	head := &api.Header{Salt: []byte("salt"), Data: []byte("meta")}
+	var grt *grant.Grant
+	err := service.PutSeal(func(g *grant.Grant) error {
+		grt = g
+		return nil
+	}, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: head, Body: data},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+	}))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		offset, length int64
+		expected       []byte
+		// Including the LINK and HEADER chunks
+		fetched int64
+	}{
+		{"All", 0, 0, data, 12},
+		{"Middle", 25, 10, data[25:35], 4},
+		{"WholeChunk", 30, 10, data[30:40], 3},
+		{"ToEnd", 88, 0, data[88:], 4},
+		{"PastEnd", 90, 100, data[90:], 3},
+		{"AfterEnd", 95, 0, nil, 2},
+	}
+	for _, window := range []int{1, 4} {
+		service.WithPrefetch(window)
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s/Prefetch%d", tc.name, window), func(t *testing.T) {
+				atomic.StoreInt64(&hrd.gets, 0)
+				var gotHead *api.Header
+				var body []byte
+				err := service.UnsealGetRange(&api.UnsealGetRangeRequest{
+					Grant:  grt,
+					Offset: tc.offset,
+					Length: tc.length,
+				}, func(pt *api.Plaintext) error {
+					if pt.GetHead() != nil {
+						gotHead = pt.GetHead()
+					}
+					body = append(body, pt.GetBody()...)
+					return nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, head.Data, gotHead.GetData())
+				assert.Equal(t, tc.expected, body)
+				assert.Equal(t, tc.fetched, atomic.LoadInt64(&hrd.gets))
+			})
+		}
+	}
+
+	err = service.UnsealGetRange(&api.UnsealGetRangeRequest{Grant: grt, Offset: -1},
+		func(pt *api.Plaintext) error { return nil })
+	assert.Error(t, err)
+}
+
+// Counts the chunks fetched
+type countingGrantService struct {
+	GrantService
+	gets int64
+}
+
+func (cgs *countingGrantService) Get(ref *reference.Ref) ([]byte, error) {
+	atomic.AddInt64(&cgs.gets, 1)
+	return cgs.GrantService.Get(ref)
+}
+
+func TestPutSealEncryptWorkers(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := NewHoard(store, config.NoopSecretManager, nil)
+	data := []byte(helpers.LongText)
+	putSeal := func(service *StreamingService) (*grant.Grant, error) {
+		var grt *grant.Grant
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, sendOnce(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{Head: &api.Header{Salt: []byte("salt")}, Body: data},
+			GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+		}))
+		return grt, err
+	}
+
+	grt, err := putSeal(NewStreamingService(hrd, 64).WithEncryptWorkers(8))
+	require.NoError(t, err)
+	// Chunks are linked in order so the grant is identical to one produced without workers (other than the LINK ref
+	// which has a unique nonce)
+	parallelRefs, err := hrd.Unseal(grt)
+	require.NoError(t, err)
+	parallelLinked, err := hrd.Get(parallelRefs[0])
+	require.NoError(t, err)
+
+	grt, err = putSeal(NewStreamingService(hrd, 64))
+	require.NoError(t, err)
+	refs, err := hrd.Unseal(grt)
+	require.NoError(t, err)
+	linked, err := hrd.Get(refs[0])
+	require.NoError(t, err)
+	assertLinkedRefsEqual(t, linked, parallelLinked)
+
+	var body []byte
+	err = NewStreamingService(hrd, 64).UnsealGet(grt, func(pt *api.Plaintext) error {
+		body = append(body, pt.GetBody()...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, body)
+
+	// Storage failures are reported rather than sealing a partial grant
+	_, err = putSeal(NewStreamingService(NewHoard(&failingStore{NamedStore: stores.NewMemoryStore(), after: 5},
+		config.NoopSecretManager, nil), 64).WithEncryptWorkers(8))
+	assert.Error(t, err)
+}
+
+func assertLinkedRefsEqual(t *testing.T, expected, actual []byte) {
+	expectedRefs, err := reference.RefsFromPlaintext(expected, versions.LatestGrantVersion)
+	require.NoError(t, err)
+	actualRefs, err := reference.RefsFromPlaintext(actual, versions.LatestGrantVersion)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRefs, actualRefs)
+}
+
+// Fails every Put after the first few
+type failingStore struct {
+	stores.NamedStore
+	after int64
+	puts  int64
+}
+
+func (fs *failingStore) Put(address []byte, data []byte) ([]byte, error) {
+	if atomic.AddInt64(&fs.puts, 1) > fs.after {
+		return nil, fmt.Errorf("store unavailable")
+	}
+	return fs.NamedStore.Put(address, data)
+}
+
+func TestPutSealContentDefined(t *testing.T) {
+	hrd := NewHoard(stores.NewMemoryStore(), config.NoopSecretManager, nil)
+	var stats *UploadStats
+	service := NewStreamingService(hrd, 1024).WithEncryptWorkers(4).WithUploadStats(func(s *UploadStats) {
+		stats = s
+	})
+	data := make([]byte, 64*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+	putSeal := func(data []byte, chunker api.Chunker) *grant.Grant {
+		var grt *grant.Grant
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, sendOnce(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{Head: &api.Header{Salt: []byte("salt"), Chunker: chunker}, Body: data},
+			GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+		}))
+		require.NoError(t, err)
+		return grt
+	}
+
+	putSeal(data, api.Chunker_CONTENT_DEFINED)
+	assert.Equal(t, int64(len(data))+headerSize(t, api.Chunker_CONTENT_DEFINED), stats.Bytes)
+	assert.Equal(t, int64(0), stats.DuplicateChunks)
+
+	// Only the chunks around the insertion are stored again
+	edited := append([]byte{data[0], 'x'}, data[1:]...)
+	grt := putSeal(edited, api.Chunker_CONTENT_DEFINED)
+	assert.Equal(t, int64(len(edited))+headerSize(t, api.Chunker_CONTENT_DEFINED), stats.Bytes)
+	assert.GreaterOrEqual(t, stats.DuplicateChunks, stats.Chunks-3)
+
+	var body []byte
+	err := service.UnsealGet(grt, func(pt *api.Plaintext) error {
+		if pt.GetHead() != nil {
+			assert.Equal(t, api.Chunker_CONTENT_DEFINED, pt.GetHead().GetChunker())
+		}
+		body = append(body, pt.GetBody()...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, edited, body)
+
+	// Whereas fixed size chunks all shift so only the header is deduplicated
+	putSeal(data, api.Chunker_FIXED)
+	putSeal(edited, api.Chunker_FIXED)
+	assert.Equal(t, int64(1), stats.DuplicateChunks)
+
+	err = service.PutSeal(func(*grant.Grant) error { return nil }, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: &api.Header{Chunker: 7}, Body: data},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+	}))
+	assert.Error(t, err)
+}
+
+func headerSize(t *testing.T, chunker api.Chunker) int64 {
+	bs, err := protodet.Marshal(&api.Header{Salt: []byte("salt"), Chunker: chunker})
+	require.NoError(t, err)
+	return int64(len(bs))
+}
+
+func TestPutSealTrailer(t *testing.T) {
+	hrd := NewHoard(stores.NewMemoryStore(), config.NoopSecretManager, nil)
+	service := NewStreamingService(hrd, 64).WithEncryptWorkers(4)
+	data := []byte(helpers.LongText)
+	putSeal := func(msgs ...*api.Plaintext) (*grant.Grant, error) {
+		var grt *grant.Grant
+		msgs[0].Head.Salt = []byte("salt")
+		first := &api.PlaintextAndGrantSpec{Plaintext: msgs[0], GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}}}
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, func() (*api.PlaintextAndGrantSpec, error) {
+			if first != nil {
+				defer func() { first = nil }()
+				return first, nil
+			}
+			if len(msgs) == 1 {
+				return nil, io.EOF
+			}
+			msgs = msgs[1:]
+			return &api.PlaintextAndGrantSpec{Plaintext: msgs[0]}, nil
+		})
+		return grt, err
+	}
+	linked := func(grt *grant.Grant) []byte {
+		refs, err := hrd.Unseal(grt)
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		data, err := hrd.Get(refs[0])
+		require.NoError(t, err)
+		return data
+	}
+
+	grt, err := putSeal(&api.Plaintext{Head: &api.Header{}, Body: data[:100]}, &api.Plaintext{Body: data[100:]},
+		&api.Plaintext{Head: &api.Header{Data: []byte("meta")}})
+	require.NoError(t, err)
+
+	// The header is normalised to the front as if the metadata had been sent first
+	expected, err := putSeal(&api.Plaintext{Head: &api.Header{Data: []byte("meta")}, Body: data})
+	require.NoError(t, err)
+	assertLinkedRefsEqual(t, linked(expected), linked(grt))
+
+	var plaintexts []*api.Plaintext
+	err = service.UnsealGet(grt, func(pt *api.Plaintext) error {
+		plaintexts = append(plaintexts, pt)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &api.Header{Salt: []byte("salt"), Data: []byte("meta")}, plaintexts[0].Head)
+
+	_, err = putSeal(&api.Plaintext{Head: &api.Header{}, Body: data},
+		&api.Plaintext{Head: &api.Header{Data: []byte("meta"), ChunkSize: 10}})
+	assert.Error(t, err, "only metadata may be trailed")
+
+	_, err = putSeal(&api.Plaintext{Head: &api.Header{}, Body: data},
+		&api.Plaintext{Head: &api.Header{Data: []byte("meta")}}, &api.Plaintext{Body: data})
+	assert.Error(t, err, "the trailer must be last")
+
+	err = service.Put(func(*reference.Ref) error { return nil }, func() func() (*api.Plaintext, error) {
+		msgs := []*api.Plaintext{{Body: data}, {Head: &api.Header{Data: []byte("meta")}}}
+		return func() (*api.Plaintext, error) {
+			if len(msgs) == 0 {
+				return nil, io.EOF
+			}
+			defer func() { msgs = msgs[1:] }()
+			return msgs[0], nil
+		}
+	}())
+	assert.Error(t, err, "only PutSeal accepts a trailer")
+}
+
+func TestAnnotate(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := NewHoard(store, config.NoopSecretManager, nil)
+	service := NewStreamingService(hrd, 64)
+	data := []byte(helpers.LongText)
+	unsealGet := func(grt *grant.Grant) (*api.Header, []byte) {
+		var head *api.Header
+		var body []byte
+		err := service.UnsealGet(grt, func(pt *api.Plaintext) error {
+			if pt.GetHead() != nil {
+				head = pt.Head
+			}
+			body = append(body, pt.GetBody()...)
+			return nil
+		})
+		require.NoError(t, err)
+		return head, body
+	}
+
+	for _, head := range []*api.Header{nil, {Salt: []byte("salt"), Data: []byte("old")}} {
+		var grt *grant.Grant
+		err := service.PutSeal(func(g *grant.Grant) error {
+			grt = g
+			return nil
+		}, sendOnce(&api.PlaintextAndGrantSpec{
+			Plaintext: &api.Plaintext{Head: head, Body: data},
+			GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+		}))
+		require.NoError(t, err)
+		blobs := countBlobs(t, store)
+
+		annotated, err := service.Annotate(&api.AnnotateRequest{Grant: grt, Head: &api.Header{Data: []byte("new")}})
+		require.NoError(t, err)
+		annotatedHead, body := unsealGet(annotated)
+		assert.Equal(t, data, body)
+		assert.Equal(t, []byte("new"), annotatedHead.Data)
+		assert.Equal(t, head.GetSalt(), annotatedHead.Salt)
+		// Only a new header and LINK ref are stored
+		assert.Equal(t, blobs+2, countBlobs(t, store))
+
+		// The original grant is unchanged
+		originalHead, body := unsealGet(grt)
+		assert.Equal(t, data, body)
+		assert.Equal(t, head.GetData(), originalHead.GetData())
+	}
+
+	_, err := service.Annotate(&api.AnnotateRequest{Grant: &grant.Grant{}, Head: &api.Header{ChunkSize: 10}})
+	assert.Error(t, err, "only metadata may be annotated")
+}
+
+func countBlobs(t *testing.T, store stores.Store) int {
+	var n int
+	require.NoError(t, stores.ListAll(store, nil, nil, func([]byte) error {
+		n++
+		return nil
+	}))
+	return n
+}
+
+func TestStatGrant(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := &countingGrantService{GrantService: NewHoard(store, config.NoopSecretManager, nil)}
+	service := NewStreamingService(hrd, 64)
+	data := []byte(helpers.LongText)
+	head := &api.Header{Salt: []byte("salt"), Data: []byte("meta")}
+	var grt *grant.Grant
+	err := service.PutSeal(func(g *grant.Grant) error {
+		grt = g
+		return nil
+	}, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: head, Body: data},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+	}))
+	require.NoError(t, err)
+
+	stat, err := service.StatGrant(grt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), stat.Size_)
+	assert.Equal(t, int64((len(data)+63)/64), stat.Chunks)
+	assert.Equal(t, head.Data, stat.Head.Data)
+	assert.Equal(t, int32(versions.LatestGrantVersion), stat.Version)
+	assert.True(t, stat.Complete)
+	// Only the LINK and HEADER refs are fetched
+	assert.Equal(t, int64(2), hrd.gets)
+
+	refs, err := hrd.Unseal(grt)
+	require.NoError(t, err)
+	linked, err := hrd.Get(refs[0])
+	require.NoError(t, err)
+	refs, err = reference.RefsFromPlaintext(linked, versions.LatestGrantVersion)
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(refs[len(refs)-1].Address))
+
+	stat, err = service.StatGrant(grt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), stat.Size_)
+	assert.False(t, stat.Complete)
+}
@@ -14,10 +14,14 @@ import * as stores_pb from "./stores_pb";
 interface IGrantService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
     putSeal: IGrantService_IPutSeal;
     unsealGet: IGrantService_IUnsealGet;
+    unsealGetRange: IGrantService_IUnsealGetRange;
     seal: IGrantService_ISeal;
     unseal: IGrantService_IUnseal;
     reseal: IGrantService_IReseal;
     unsealDelete: IGrantService_IUnsealDelete;
+    unsealDeleteRecursive: IGrantService_IUnsealDeleteRecursive;
+    annotate: IGrantService_IAnnotate;
+    statGrant: IGrantService_IStatGrant;
 }
 
 interface IGrantService_IPutSeal extends grpc.MethodDefinition<api_pb.PlaintextAndGrantSpec, grant_pb.Grant> {
@@ -38,6 +42,15 @@ interface IGrantService_IUnsealGet extends grpc.MethodDefinition<grant_pb.Grant,
     responseSerialize: grpc.serialize<api_pb.Plaintext>;
     responseDeserialize: grpc.deserialize<api_pb.Plaintext>;
 }
+interface IGrantService_IUnsealGetRange extends grpc.MethodDefinition<api_pb.UnsealGetRangeRequest, api_pb.Plaintext> {
+    path: "/api.Grant/UnsealGetRange";
+    requestStream: false;
+    responseStream: true;
+    requestSerialize: grpc.serialize<api_pb.UnsealGetRangeRequest>;
+    requestDeserialize: grpc.deserialize<api_pb.UnsealGetRangeRequest>;
+    responseSerialize: grpc.serialize<api_pb.Plaintext>;
+    responseDeserialize: grpc.deserialize<api_pb.Plaintext>;
+}
 interface IGrantService_ISeal extends grpc.MethodDefinition<api_pb.ReferenceAndGrantSpec, grant_pb.Grant> {
     path: "/api.Grant/Seal";
     requestStream: true;
@@ -74,16 +87,47 @@ interface IGrantService_IUnsealDelete extends grpc.MethodDefinition<grant_pb.Gra
     responseSerialize: grpc.serialize<api_pb.Address>;
     responseDeserialize: grpc.deserialize<api_pb.Address>;
 }
+interface IGrantService_IUnsealDeleteRecursive extends grpc.MethodDefinition<api_pb.UnsealDeleteRequest, api_pb.Address> {
+    path: "/api.Grant/UnsealDeleteRecursive";
+    requestStream: false;
+    responseStream: true;
+    requestSerialize: grpc.serialize<api_pb.UnsealDeleteRequest>;
+    requestDeserialize: grpc.deserialize<api_pb.UnsealDeleteRequest>;
+    responseSerialize: grpc.serialize<api_pb.Address>;
+    responseDeserialize: grpc.deserialize<api_pb.Address>;
+}
+interface IGrantService_IAnnotate extends grpc.MethodDefinition<api_pb.AnnotateRequest, grant_pb.Grant> {
+    path: "/api.Grant/Annotate";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<api_pb.AnnotateRequest>;
+    requestDeserialize: grpc.deserialize<api_pb.AnnotateRequest>;
+    responseSerialize: grpc.serialize<grant_pb.Grant>;
+    responseDeserialize: grpc.deserialize<grant_pb.Grant>;
+}
+interface IGrantService_IStatGrant extends grpc.MethodDefinition<grant_pb.Grant, api_pb.GrantStat> {
+    path: "/api.Grant/StatGrant";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<grant_pb.Grant>;
+    requestDeserialize: grpc.deserialize<grant_pb.Grant>;
+    responseSerialize: grpc.serialize<api_pb.GrantStat>;
+    responseDeserialize: grpc.deserialize<api_pb.GrantStat>;
+}
 
 export const GrantService: IGrantService;
 
 export interface IGrantServer extends grpc.UntypedServiceImplementation {
     putSeal: handleClientStreamingCall<api_pb.PlaintextAndGrantSpec, grant_pb.Grant>;
     unsealGet: grpc.handleServerStreamingCall<grant_pb.Grant, api_pb.Plaintext>;
+    unsealGetRange: grpc.handleServerStreamingCall<api_pb.UnsealGetRangeRequest, api_pb.Plaintext>;
     seal: handleClientStreamingCall<api_pb.ReferenceAndGrantSpec, grant_pb.Grant>;
     unseal: grpc.handleServerStreamingCall<grant_pb.Grant, reference_pb.Ref>;
     reseal: grpc.handleUnaryCall<api_pb.GrantAndGrantSpec, grant_pb.Grant>;
     unsealDelete: grpc.handleServerStreamingCall<grant_pb.Grant, api_pb.Address>;
+    unsealDeleteRecursive: grpc.handleServerStreamingCall<api_pb.UnsealDeleteRequest, api_pb.Address>;
+    annotate: grpc.handleUnaryCall<api_pb.AnnotateRequest, grant_pb.Grant>;
+    statGrant: grpc.handleUnaryCall<grant_pb.Grant, api_pb.GrantStat>;
 }
 
 export interface IGrantClient {
@@ -93,6 +137,8 @@ export interface IGrantClient {
     putSeal(metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.PlaintextAndGrantSpec>;
     unsealGet(request: grant_pb.Grant, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
     unsealGet(request: grant_pb.Grant, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
+    unsealGetRange(request: api_pb.UnsealGetRangeRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
+    unsealGetRange(request: api_pb.UnsealGetRangeRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
     seal(callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
     seal(metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
     seal(options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
@@ -104,6 +150,14 @@ export interface IGrantClient {
     reseal(request: api_pb.GrantAndGrantSpec, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
     unsealDelete(request: grant_pb.Grant, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
     unsealDelete(request: grant_pb.Grant, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    unsealDeleteRecursive(request: api_pb.UnsealDeleteRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    unsealDeleteRecursive(request: api_pb.UnsealDeleteRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    annotate(request: api_pb.AnnotateRequest, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    annotate(request: api_pb.AnnotateRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    annotate(request: api_pb.AnnotateRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    statGrant(request: grant_pb.Grant, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
+    statGrant(request: grant_pb.Grant, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
+    statGrant(request: grant_pb.Grant, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
 }
 
 export class GrantClient extends grpc.Client implements IGrantClient {
@@ -114,6 +168,8 @@ export class GrantClient extends grpc.Client implements IGrantClient {
     public putSeal(metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.PlaintextAndGrantSpec>;
     public unsealGet(request: grant_pb.Grant, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
     public unsealGet(request: grant_pb.Grant, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
+    public unsealGetRange(request: api_pb.UnsealGetRangeRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
+    public unsealGetRange(request: api_pb.UnsealGetRangeRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Plaintext>;
     public seal(callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
     public seal(metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
     public seal(options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientWritableStream<api_pb.ReferenceAndGrantSpec>;
@@ -125,6 +181,14 @@ export class GrantClient extends grpc.Client implements IGrantClient {
     public reseal(request: api_pb.GrantAndGrantSpec, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
     public unsealDelete(request: grant_pb.Grant, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
     public unsealDelete(request: grant_pb.Grant, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    public unsealDeleteRecursive(request: api_pb.UnsealDeleteRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    public unsealDeleteRecursive(request: api_pb.UnsealDeleteRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    public annotate(request: api_pb.AnnotateRequest, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    public annotate(request: api_pb.AnnotateRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    public annotate(request: api_pb.AnnotateRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: grant_pb.Grant) => void): grpc.ClientUnaryCall;
+    public statGrant(request: grant_pb.Grant, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
+    public statGrant(request: grant_pb.Grant, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
+    public statGrant(request: grant_pb.Grant, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: api_pb.GrantStat) => void): grpc.ClientUnaryCall;
 }
 
 interface ICleartextService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
@@ -228,6 +292,8 @@ interface IStorageService extends grpc.ServiceDefinition<grpc.UntypedServiceImpl
     pull: IStorageService_IPull;
     stat: IStorageService_IStat;
     delete: IStorageService_IDelete;
+    list: IStorageService_IList;
+    garbageCollect: IStorageService_IGarbageCollect;
 }
 
 interface IStorageService_IPush extends grpc.MethodDefinition<api_pb.Ciphertext, api_pb.Address> {
@@ -266,6 +332,24 @@ interface IStorageService_IDelete extends grpc.MethodDefinition<api_pb.Address,
     responseSerialize: grpc.serialize<api_pb.Address>;
     responseDeserialize: grpc.deserialize<api_pb.Address>;
 }
+interface IStorageService_IList extends grpc.MethodDefinition<api_pb.ListRequest, api_pb.Address> {
+    path: "/api.Storage/List";
+    requestStream: false;
+    responseStream: true;
+    requestSerialize: grpc.serialize<api_pb.ListRequest>;
+    requestDeserialize: grpc.deserialize<api_pb.ListRequest>;
+    responseSerialize: grpc.serialize<api_pb.Address>;
+    responseDeserialize: grpc.deserialize<api_pb.Address>;
+}
+interface IStorageService_IGarbageCollect extends grpc.MethodDefinition<api_pb.GarbageCollectRequest, api_pb.Address> {
+    path: "/api.Storage/GarbageCollect";
+    requestStream: true;
+    responseStream: true;
+    requestSerialize: grpc.serialize<api_pb.GarbageCollectRequest>;
+    requestDeserialize: grpc.deserialize<api_pb.GarbageCollectRequest>;
+    responseSerialize: grpc.serialize<api_pb.Address>;
+    responseDeserialize: grpc.deserialize<api_pb.Address>;
+}
 
 export const StorageService: IStorageService;
 
@@ -274,6 +358,8 @@ export interface IStorageServer extends grpc.UntypedServiceImplementation {
     pull: grpc.handleBidiStreamingCall<api_pb.Address, api_pb.Ciphertext>;
     stat: grpc.handleUnaryCall<api_pb.Address, stores_pb.StatInfo>;
     delete: grpc.handleUnaryCall<api_pb.Address, api_pb.Address>;
+    list: grpc.handleServerStreamingCall<api_pb.ListRequest, api_pb.Address>;
+    garbageCollect: grpc.handleBidiStreamingCall<api_pb.GarbageCollectRequest, api_pb.Address>;
 }
 
 export interface IStorageClient {
@@ -289,6 +375,11 @@ export interface IStorageClient {
     delete(request: api_pb.Address, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
     delete(request: api_pb.Address, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
     delete(request: api_pb.Address, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
+    list(request: api_pb.ListRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    list(request: api_pb.ListRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    garbageCollect(): grpc.ClientDuplexStream<api_pb.GarbageCollectRequest, api_pb.Address>;
+    garbageCollect(options: Partial<grpc.CallOptions>): grpc.ClientDuplexStream<api_pb.GarbageCollectRequest, api_pb.Address>;
+    garbageCollect(metadata: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientDuplexStream<api_pb.GarbageCollectRequest, api_pb.Address>;
 }
 
 export class StorageClient extends grpc.Client implements IStorageClient {
@@ -303,4 +394,8 @@ export class StorageClient extends grpc.Client implements IStorageClient {
     public delete(request: api_pb.Address, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
     public delete(request: api_pb.Address, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
     public delete(request: api_pb.Address, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: api_pb.Address) => void): grpc.ClientUnaryCall;
+    public list(request: api_pb.ListRequest, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    public list(request: api_pb.ListRequest, metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientReadableStream<api_pb.Address>;
+    public garbageCollect(options?: Partial<grpc.CallOptions>): grpc.ClientDuplexStream<api_pb.GarbageCollectRequest, api_pb.Address>;
+    public garbageCollect(metadata?: grpc.Metadata, options?: Partial<grpc.CallOptions>): grpc.ClientDuplexStream<api_pb.GarbageCollectRequest, api_pb.Address>;
 }
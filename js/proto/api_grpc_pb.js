@@ -18,6 +18,17 @@ function deserialize_api_Address(buffer_arg) {
   return api_pb.Address.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_api_AnnotateRequest(arg) {
+  if (!(arg instanceof api_pb.AnnotateRequest)) {
+    throw new Error('Expected argument of type api.AnnotateRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_AnnotateRequest(buffer_arg) {
+  return api_pb.AnnotateRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_api_Ciphertext(arg) {
   if (!(arg instanceof api_pb.Ciphertext)) {
     throw new Error('Expected argument of type api.Ciphertext');
@@ -29,6 +40,17 @@ function deserialize_api_Ciphertext(buffer_arg) {
   return api_pb.Ciphertext.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_api_GarbageCollectRequest(arg) {
+  if (!(arg instanceof api_pb.GarbageCollectRequest)) {
+    throw new Error('Expected argument of type api.GarbageCollectRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_GarbageCollectRequest(buffer_arg) {
+  return api_pb.GarbageCollectRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_api_GrantAndGrantSpec(arg) {
   if (!(arg instanceof api_pb.GrantAndGrantSpec)) {
     throw new Error('Expected argument of type api.GrantAndGrantSpec');
@@ -40,6 +62,28 @@ function deserialize_api_GrantAndGrantSpec(buffer_arg) {
   return api_pb.GrantAndGrantSpec.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_api_GrantStat(arg) {
+  if (!(arg instanceof api_pb.GrantStat)) {
+    throw new Error('Expected argument of type api.GrantStat');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_GrantStat(buffer_arg) {
+  return api_pb.GrantStat.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_api_ListRequest(arg) {
+  if (!(arg instanceof api_pb.ListRequest)) {
+    throw new Error('Expected argument of type api.ListRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_ListRequest(buffer_arg) {
+  return api_pb.ListRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_api_Plaintext(arg) {
   if (!(arg instanceof api_pb.Plaintext)) {
     throw new Error('Expected argument of type api.Plaintext');
@@ -84,6 +128,28 @@ function deserialize_api_ReferenceAndGrantSpec(buffer_arg) {
   return api_pb.ReferenceAndGrantSpec.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_api_UnsealDeleteRequest(arg) {
+  if (!(arg instanceof api_pb.UnsealDeleteRequest)) {
+    throw new Error('Expected argument of type api.UnsealDeleteRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_UnsealDeleteRequest(buffer_arg) {
+  return api_pb.UnsealDeleteRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_api_UnsealGetRangeRequest(arg) {
+  if (!(arg instanceof api_pb.UnsealGetRangeRequest)) {
+    throw new Error('Expected argument of type api.UnsealGetRangeRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_api_UnsealGetRangeRequest(buffer_arg) {
+  return api_pb.UnsealGetRangeRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_grant_Grant(arg) {
   if (!(arg instanceof grant_pb.Grant)) {
     throw new Error('Expected argument of type grant.Grant');
@@ -119,7 +185,8 @@ function deserialize_stores_StatInfo(buffer_arg) {
 
 
 var GrantService = exports.GrantService = {
-  // Put a Plaintext and returned the sealed Reference as a Grant
+  // Put a Plaintext and returned the sealed Reference as a Grant. Header metadata (Header.Data) that is only known
+// once the body has been sent may be sent in a final message containing just a Header.
 putSeal: {
     path: '/api.Grant/PutSeal',
     requestStream: true,
@@ -143,6 +210,19 @@ unsealGet: {
     responseSerialize: serialize_api_Plaintext,
     responseDeserialize: deserialize_api_Plaintext,
   },
+  // Unseal a Grant and return only the part of its Plaintext body in the requested range (along with any header).
+// Only the chunks overlapping the range are fetched and decrypted.
+unsealGetRange: {
+    path: '/api.Grant/UnsealGetRange',
+    requestStream: false,
+    responseStream: true,
+    requestType: api_pb.UnsealGetRangeRequest,
+    responseType: api_pb.Plaintext,
+    requestSerialize: serialize_api_UnsealGetRangeRequest,
+    requestDeserialize: deserialize_api_UnsealGetRangeRequest,
+    responseSerialize: serialize_api_Plaintext,
+    responseDeserialize: deserialize_api_Plaintext,
+  },
   // Seal a Reference to create a Grant
 seal: {
     path: '/api.Grant/Seal',
@@ -180,7 +260,11 @@ reseal: {
     responseSerialize: serialize_grant_Grant,
     responseDeserialize: deserialize_grant_Grant,
   },
-  // Unseal a Grant and follow the Reference to delete the Plaintext
+  // Unseal a Grant and follow the References (including any linked References) to delete every chunk of the
+// Plaintext, returning the address of each chunk deleted. Chunks with the same plaintext and salt are shared by
+// convergent encryption with other Grants, whose data is lost along with them, so use UnsealDeleteRecursive to
+// keep any chunks that may be shared. Grants sealed with a LinkNonce are refused since their LINK may be shared
+// with Grants that are not known.
 unsealDelete: {
     path: '/api.Grant/UnsealDelete',
     requestStream: false,
@@ -192,6 +276,45 @@ unsealDelete: {
     responseSerialize: serialize_api_Address,
     responseDeserialize: deserialize_api_Address,
   },
+  // UnsealDelete a Grant except for any chunk reachable from the Keep grants. The request is refused unless Keep
+// grants are given or Force is set.
+unsealDeleteRecursive: {
+    path: '/api.Grant/UnsealDeleteRecursive',
+    requestStream: false,
+    responseStream: true,
+    requestType: api_pb.UnsealDeleteRequest,
+    responseType: api_pb.Address,
+    requestSerialize: serialize_api_UnsealDeleteRequest,
+    requestDeserialize: deserialize_api_UnsealDeleteRequest,
+    responseSerialize: serialize_api_Address,
+    responseDeserialize: deserialize_api_Address,
+  },
+  // Add or replace the metadata (Header.Data) in the Header of the Plaintext behind a Grant, returning a new Grant
+// (sealed with the same spec) that shares its body chunks. Other Header fields cannot be changed.
+annotate: {
+    path: '/api.Grant/Annotate',
+    requestStream: false,
+    responseStream: false,
+    requestType: api_pb.AnnotateRequest,
+    responseType: grant_pb.Grant,
+    requestSerialize: serialize_api_AnnotateRequest,
+    requestDeserialize: deserialize_api_AnnotateRequest,
+    responseSerialize: serialize_grant_Grant,
+    responseDeserialize: deserialize_grant_Grant,
+  },
+  // Unseal a Grant and describe the Plaintext behind it (its size, number of chunks, and Header) and whether all of
+// its chunks are stored. Only the Header and any LINK References are fetched.
+statGrant: {
+    path: '/api.Grant/StatGrant',
+    requestStream: false,
+    responseStream: false,
+    requestType: grant_pb.Grant,
+    responseType: api_pb.GrantStat,
+    requestSerialize: serialize_grant_Grant,
+    requestDeserialize: deserialize_grant_Grant,
+    responseSerialize: serialize_api_GrantStat,
+    responseDeserialize: deserialize_api_GrantStat,
+  },
 };
 
 exports.GrantClient = grpc.makeGenericClientConstructor(GrantService);
@@ -308,6 +431,31 @@ delete: {
     responseSerialize: serialize_api_Address,
     responseDeserialize: deserialize_api_Address,
   },
+  // List the addresses of the blobs held by a store
+list: {
+    path: '/api.Storage/List',
+    requestStream: false,
+    responseStream: true,
+    requestType: api_pb.ListRequest,
+    responseType: api_pb.Address,
+    requestSerialize: serialize_api_ListRequest,
+    requestDeserialize: deserialize_api_ListRequest,
+    responseSerialize: serialize_api_Address,
+    responseDeserialize: deserialize_api_Address,
+  },
+  // Find blobs in a store that are not reachable from any of the grants or references provided and delete them
+// (unless DryRun is set) returning the address of each unreachable blob
+garbageCollect: {
+    path: '/api.Storage/GarbageCollect',
+    requestStream: true,
+    responseStream: true,
+    requestType: api_pb.GarbageCollectRequest,
+    responseType: api_pb.Address,
+    requestSerialize: serialize_api_GarbageCollectRequest,
+    requestDeserialize: deserialize_api_GarbageCollectRequest,
+    responseSerialize: serialize_api_Address,
+    responseDeserialize: deserialize_api_Address,
+  },
 };
 
 exports.StorageClient = grpc.makeGenericClientConstructor(StorageService);
@@ -38,6 +38,99 @@ export namespace GrantAndGrantSpec {
     }
 }
 
+export class UnsealGetRangeRequest extends jspb.Message { 
+
+    hasGrant(): boolean;
+    clearGrant(): void;
+    getGrant(): grant_pb.Grant | undefined;
+    setGrant(value?: grant_pb.Grant): UnsealGetRangeRequest;
+    getOffset(): number;
+    setOffset(value: number): UnsealGetRangeRequest;
+    getLength(): number;
+    setLength(value: number): UnsealGetRangeRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): UnsealGetRangeRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: UnsealGetRangeRequest): UnsealGetRangeRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: UnsealGetRangeRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): UnsealGetRangeRequest;
+    static deserializeBinaryFromReader(message: UnsealGetRangeRequest, reader: jspb.BinaryReader): UnsealGetRangeRequest;
+}
+
+export namespace UnsealGetRangeRequest {
+    export type AsObject = {
+        grant?: grant_pb.Grant.AsObject,
+        offset: number,
+        length: number,
+    }
+}
+
+export class AnnotateRequest extends jspb.Message { 
+
+    hasGrant(): boolean;
+    clearGrant(): void;
+    getGrant(): grant_pb.Grant | undefined;
+    setGrant(value?: grant_pb.Grant): AnnotateRequest;
+
+    hasHead(): boolean;
+    clearHead(): void;
+    getHead(): Header | undefined;
+    setHead(value?: Header): AnnotateRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): AnnotateRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: AnnotateRequest): AnnotateRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: AnnotateRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): AnnotateRequest;
+    static deserializeBinaryFromReader(message: AnnotateRequest, reader: jspb.BinaryReader): AnnotateRequest;
+}
+
+export namespace AnnotateRequest {
+    export type AsObject = {
+        grant?: grant_pb.Grant.AsObject,
+        head?: Header.AsObject,
+    }
+}
+
+export class GrantStat extends jspb.Message { 
+    getSize(): number;
+    setSize(value: number): GrantStat;
+    getChunks(): number;
+    setChunks(value: number): GrantStat;
+
+    hasHead(): boolean;
+    clearHead(): void;
+    getHead(): Header | undefined;
+    setHead(value?: Header): GrantStat;
+    getVersion(): number;
+    setVersion(value: number): GrantStat;
+    getComplete(): boolean;
+    setComplete(value: boolean): GrantStat;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GrantStat.AsObject;
+    static toObject(includeInstance: boolean, msg: GrantStat): GrantStat.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: GrantStat, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GrantStat;
+    static deserializeBinaryFromReader(message: GrantStat, reader: jspb.BinaryReader): GrantStat;
+}
+
+export namespace GrantStat {
+    export type AsObject = {
+        size: number,
+        chunks: number,
+        head?: Header.AsObject,
+        version: number,
+        complete: boolean,
+    }
+}
+
 export class PlaintextAndGrantSpec extends jspb.Message { 
 
     hasPlaintext(): boolean;
@@ -107,6 +200,10 @@ export class Header extends jspb.Message {
     setData(value: Uint8Array | string): Header;
     getChunksize(): number;
     setChunksize(value: number): Header;
+    getStore(): string;
+    setStore(value: string): Header;
+    getChunker(): Chunker;
+    setChunker(value: Chunker): Header;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Header.AsObject;
@@ -123,6 +220,8 @@ export namespace Header {
         salt: Uint8Array | string,
         data: Uint8Array | string,
         chunksize: number,
+        store: string,
+        chunker: Chunker,
     }
 }
 
@@ -205,11 +304,107 @@ export namespace ReferenceAndCiphertext {
     }
 }
 
+export class ListRequest extends jspb.Message { 
+    getStore(): string;
+    setStore(value: string): ListRequest;
+    getPrefix(): Uint8Array | string;
+    getPrefix_asU8(): Uint8Array;
+    getPrefix_asB64(): string;
+    setPrefix(value: Uint8Array | string): ListRequest;
+    getAfter(): Uint8Array | string;
+    getAfter_asU8(): Uint8Array;
+    getAfter_asB64(): string;
+    setAfter(value: Uint8Array | string): ListRequest;
+    getLimit(): number;
+    setLimit(value: number): ListRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ListRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: ListRequest): ListRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ListRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ListRequest;
+    static deserializeBinaryFromReader(message: ListRequest, reader: jspb.BinaryReader): ListRequest;
+}
+
+export namespace ListRequest {
+    export type AsObject = {
+        store: string,
+        prefix: Uint8Array | string,
+        after: Uint8Array | string,
+        limit: number,
+    }
+}
+
+export class GarbageCollectRequest extends jspb.Message { 
+
+    hasOptions(): boolean;
+    clearOptions(): void;
+    getOptions(): GarbageCollectOptions | undefined;
+    setOptions(value?: GarbageCollectOptions): GarbageCollectRequest;
+
+    hasGrant(): boolean;
+    clearGrant(): void;
+    getGrant(): grant_pb.Grant | undefined;
+    setGrant(value?: grant_pb.Grant): GarbageCollectRequest;
+
+    hasReference(): boolean;
+    clearReference(): void;
+    getReference(): reference_pb.Ref | undefined;
+    setReference(value?: reference_pb.Ref): GarbageCollectRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GarbageCollectRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: GarbageCollectRequest): GarbageCollectRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: GarbageCollectRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GarbageCollectRequest;
+    static deserializeBinaryFromReader(message: GarbageCollectRequest, reader: jspb.BinaryReader): GarbageCollectRequest;
+}
+
+export namespace GarbageCollectRequest {
+    export type AsObject = {
+        options?: GarbageCollectOptions.AsObject,
+        grant?: grant_pb.Grant.AsObject,
+        reference?: reference_pb.Ref.AsObject,
+    }
+}
+
+export class GarbageCollectOptions extends jspb.Message { 
+    getDryrun(): boolean;
+    setDryrun(value: boolean): GarbageCollectOptions;
+    getStore(): string;
+    setStore(value: string): GarbageCollectOptions;
+    getAll(): boolean;
+    setAll(value: boolean): GarbageCollectOptions;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GarbageCollectOptions.AsObject;
+    static toObject(includeInstance: boolean, msg: GarbageCollectOptions): GarbageCollectOptions.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: GarbageCollectOptions, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GarbageCollectOptions;
+    static deserializeBinaryFromReader(message: GarbageCollectOptions, reader: jspb.BinaryReader): GarbageCollectOptions;
+}
+
+export namespace GarbageCollectOptions {
+    export type AsObject = {
+        dryrun: boolean,
+        store: string,
+        all: boolean,
+    }
+}
+
 export class Address extends jspb.Message { 
     getAddress(): Uint8Array | string;
     getAddress_asU8(): Uint8Array;
     getAddress_asB64(): string;
     setAddress(value: Uint8Array | string): Address;
+    getStore(): string;
+    setStore(value: string): Address;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Address.AsObject;
@@ -224,5 +419,43 @@ export class Address extends jspb.Message {
 export namespace Address {
     export type AsObject = {
         address: Uint8Array | string,
+        store: string,
     }
 }
+
+export class UnsealDeleteRequest extends jspb.Message { 
+
+    hasGrant(): boolean;
+    clearGrant(): void;
+    getGrant(): grant_pb.Grant | undefined;
+    setGrant(value?: grant_pb.Grant): UnsealDeleteRequest;
+    clearKeepList(): void;
+    getKeepList(): Array<grant_pb.Grant>;
+    setKeepList(value: Array<grant_pb.Grant>): UnsealDeleteRequest;
+    addKeep(value?: grant_pb.Grant, index?: number): grant_pb.Grant;
+    getForce(): boolean;
+    setForce(value: boolean): UnsealDeleteRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): UnsealDeleteRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: UnsealDeleteRequest): UnsealDeleteRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: UnsealDeleteRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): UnsealDeleteRequest;
+    static deserializeBinaryFromReader(message: UnsealDeleteRequest, reader: jspb.BinaryReader): UnsealDeleteRequest;
+}
+
+export namespace UnsealDeleteRequest {
+    export type AsObject = {
+        grant?: grant_pb.Grant.AsObject,
+        keepList: Array<grant_pb.Grant.AsObject>,
+        force: boolean,
+    }
+}
+
+export enum Chunker {
+    DEFAULT = 0,
+    FIXED = 1,
+    CONTENT_DEFINED = 2,
+}
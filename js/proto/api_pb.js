@@ -22,13 +22,21 @@ goog.object.extend(proto, reference_pb);
 var stores_pb = require('./stores_pb.js');
 goog.object.extend(proto, stores_pb);
 goog.exportSymbol('proto.api.Address', null, global);
+goog.exportSymbol('proto.api.AnnotateRequest', null, global);
+goog.exportSymbol('proto.api.Chunker', null, global);
 goog.exportSymbol('proto.api.Ciphertext', null, global);
+goog.exportSymbol('proto.api.GarbageCollectOptions', null, global);
+goog.exportSymbol('proto.api.GarbageCollectRequest', null, global);
 goog.exportSymbol('proto.api.GrantAndGrantSpec', null, global);
+goog.exportSymbol('proto.api.GrantStat', null, global);
 goog.exportSymbol('proto.api.Header', null, global);
+goog.exportSymbol('proto.api.ListRequest', null, global);
 goog.exportSymbol('proto.api.Plaintext', null, global);
 goog.exportSymbol('proto.api.PlaintextAndGrantSpec', null, global);
 goog.exportSymbol('proto.api.ReferenceAndCiphertext', null, global);
 goog.exportSymbol('proto.api.ReferenceAndGrantSpec', null, global);
+goog.exportSymbol('proto.api.UnsealDeleteRequest', null, global);
+goog.exportSymbol('proto.api.UnsealGetRangeRequest', null, global);
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -50,6 +58,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.api.GrantAndGrantSpec.displayName = 'proto.api.GrantAndGrantSpec';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.UnsealGetRangeRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.UnsealGetRangeRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.UnsealGetRangeRequest.displayName = 'proto.api.UnsealGetRangeRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.AnnotateRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.AnnotateRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.AnnotateRequest.displayName = 'proto.api.AnnotateRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.GrantStat = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.GrantStat, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.GrantStat.displayName = 'proto.api.GrantStat';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -176,6 +247,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.api.ReferenceAndCiphertext.displayName = 'proto.api.ReferenceAndCiphertext';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.ListRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.ListRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.ListRequest.displayName = 'proto.api.ListRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.GarbageCollectRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.GarbageCollectRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.GarbageCollectRequest.displayName = 'proto.api.GarbageCollectRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.GarbageCollectOptions = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.api.GarbageCollectOptions, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.GarbageCollectOptions.displayName = 'proto.api.GarbageCollectOptions';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -197,6 +331,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.api.Address.displayName = 'proto.api.Address';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.api.UnsealDeleteRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.api.UnsealDeleteRequest.repeatedFields_, null);
+};
+goog.inherits(proto.api.UnsealDeleteRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.api.UnsealDeleteRequest.displayName = 'proto.api.UnsealDeleteRequest';
+}
 
 
 
@@ -415,8 +570,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.PlaintextAndGrantSpec.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.PlaintextAndGrantSpec.toObject(opt_includeInstance, this);
+proto.api.UnsealGetRangeRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.UnsealGetRangeRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -425,14 +580,15 @@ proto.api.PlaintextAndGrantSpec.prototype.toObject = function(opt_includeInstanc
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.PlaintextAndGrantSpec} msg The msg instance to transform.
+ * @param {!proto.api.UnsealGetRangeRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.PlaintextAndGrantSpec.toObject = function(includeInstance, msg) {
+proto.api.UnsealGetRangeRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    plaintext: (f = msg.getPlaintext()) && proto.api.Plaintext.toObject(includeInstance, f),
-    grantspec: (f = msg.getGrantspec()) && grant_pb.Spec.toObject(includeInstance, f)
+    grant: (f = msg.getGrant()) && grant_pb.Grant.toObject(includeInstance, f),
+    offset: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    length: jspb.Message.getFieldWithDefault(msg, 3, 0)
   };
 
   if (includeInstance) {
@@ -446,23 +602,23 @@ proto.api.PlaintextAndGrantSpec.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.PlaintextAndGrantSpec}
+ * @return {!proto.api.UnsealGetRangeRequest}
  */
-proto.api.PlaintextAndGrantSpec.deserializeBinary = function(bytes) {
+proto.api.UnsealGetRangeRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.PlaintextAndGrantSpec;
-  return proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.UnsealGetRangeRequest;
+  return proto.api.UnsealGetRangeRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.PlaintextAndGrantSpec} msg The message object to deserialize into.
+ * @param {!proto.api.UnsealGetRangeRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.PlaintextAndGrantSpec}
+ * @return {!proto.api.UnsealGetRangeRequest}
  */
-proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.UnsealGetRangeRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -470,14 +626,17 @@ proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader = function(msg, read
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = new proto.api.Plaintext;
-      reader.readMessage(value,proto.api.Plaintext.deserializeBinaryFromReader);
-      msg.setPlaintext(value);
+      var value = new grant_pb.Grant;
+      reader.readMessage(value,grant_pb.Grant.deserializeBinaryFromReader);
+      msg.setGrant(value);
       break;
     case 2:
-      var value = new grant_pb.Spec;
-      reader.readMessage(value,grant_pb.Spec.deserializeBinaryFromReader);
-      msg.setGrantspec(value);
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setOffset(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setLength(value);
       break;
     default:
       reader.skipField();
@@ -492,9 +651,9 @@ proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader = function(msg, read
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.PlaintextAndGrantSpec.prototype.serializeBinary = function() {
+proto.api.UnsealGetRangeRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.PlaintextAndGrantSpec.serializeBinaryToWriter(this, writer);
+  proto.api.UnsealGetRangeRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -502,56 +661,62 @@ proto.api.PlaintextAndGrantSpec.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.PlaintextAndGrantSpec} message
+ * @param {!proto.api.UnsealGetRangeRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.PlaintextAndGrantSpec.serializeBinaryToWriter = function(message, writer) {
+proto.api.UnsealGetRangeRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPlaintext();
+  f = message.getGrant();
   if (f != null) {
     writer.writeMessage(
       1,
       f,
-      proto.api.Plaintext.serializeBinaryToWriter
+      grant_pb.Grant.serializeBinaryToWriter
     );
   }
-  f = message.getGrantspec();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getOffset();
+  if (f !== 0) {
+    writer.writeInt64(
       2,
-      f,
-      grant_pb.Spec.serializeBinaryToWriter
+      f
+    );
+  }
+  f = message.getLength();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
     );
   }
 };
 
 
 /**
- * optional Plaintext Plaintext = 1;
- * @return {?proto.api.Plaintext}
+ * optional grant.Grant Grant = 1;
+ * @return {?proto.grant.Grant}
  */
-proto.api.PlaintextAndGrantSpec.prototype.getPlaintext = function() {
-  return /** @type{?proto.api.Plaintext} */ (
-    jspb.Message.getWrapperField(this, proto.api.Plaintext, 1));
+proto.api.UnsealGetRangeRequest.prototype.getGrant = function() {
+  return /** @type{?proto.grant.Grant} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Grant, 1));
 };
 
 
 /**
- * @param {?proto.api.Plaintext|undefined} value
- * @return {!proto.api.PlaintextAndGrantSpec} returns this
+ * @param {?proto.grant.Grant|undefined} value
+ * @return {!proto.api.UnsealGetRangeRequest} returns this
 */
-proto.api.PlaintextAndGrantSpec.prototype.setPlaintext = function(value) {
+proto.api.UnsealGetRangeRequest.prototype.setGrant = function(value) {
   return jspb.Message.setWrapperField(this, 1, value);
 };
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.api.PlaintextAndGrantSpec} returns this
+ * @return {!proto.api.UnsealGetRangeRequest} returns this
  */
-proto.api.PlaintextAndGrantSpec.prototype.clearPlaintext = function() {
-  return this.setPlaintext(undefined);
+proto.api.UnsealGetRangeRequest.prototype.clearGrant = function() {
+  return this.setGrant(undefined);
 };
 
 
@@ -559,45 +724,44 @@ proto.api.PlaintextAndGrantSpec.prototype.clearPlaintext = function() {
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.api.PlaintextAndGrantSpec.prototype.hasPlaintext = function() {
+proto.api.UnsealGetRangeRequest.prototype.hasGrant = function() {
   return jspb.Message.getField(this, 1) != null;
 };
 
 
 /**
- * optional grant.Spec GrantSpec = 2;
- * @return {?proto.grant.Spec}
+ * optional int64 Offset = 2;
+ * @return {number}
  */
-proto.api.PlaintextAndGrantSpec.prototype.getGrantspec = function() {
-  return /** @type{?proto.grant.Spec} */ (
-    jspb.Message.getWrapperField(this, grant_pb.Spec, 2));
+proto.api.UnsealGetRangeRequest.prototype.getOffset = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 
 /**
- * @param {?proto.grant.Spec|undefined} value
- * @return {!proto.api.PlaintextAndGrantSpec} returns this
-*/
-proto.api.PlaintextAndGrantSpec.prototype.setGrantspec = function(value) {
-  return jspb.Message.setWrapperField(this, 2, value);
+ * @param {number} value
+ * @return {!proto.api.UnsealGetRangeRequest} returns this
+ */
+proto.api.UnsealGetRangeRequest.prototype.setOffset = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.api.PlaintextAndGrantSpec} returns this
+ * optional int64 Length = 3;
+ * @return {number}
  */
-proto.api.PlaintextAndGrantSpec.prototype.clearGrantspec = function() {
-  return this.setGrantspec(undefined);
+proto.api.UnsealGetRangeRequest.prototype.getLength = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {number} value
+ * @return {!proto.api.UnsealGetRangeRequest} returns this
  */
-proto.api.PlaintextAndGrantSpec.prototype.hasGrantspec = function() {
-  return jspb.Message.getField(this, 2) != null;
+proto.api.UnsealGetRangeRequest.prototype.setLength = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
 };
 
 
@@ -617,8 +781,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.ReferenceAndGrantSpec.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.ReferenceAndGrantSpec.toObject(opt_includeInstance, this);
+proto.api.AnnotateRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.AnnotateRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -627,14 +791,14 @@ proto.api.ReferenceAndGrantSpec.prototype.toObject = function(opt_includeInstanc
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.ReferenceAndGrantSpec} msg The msg instance to transform.
+ * @param {!proto.api.AnnotateRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.ReferenceAndGrantSpec.toObject = function(includeInstance, msg) {
+proto.api.AnnotateRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    reference: (f = msg.getReference()) && reference_pb.Ref.toObject(includeInstance, f),
-    grantspec: (f = msg.getGrantspec()) && grant_pb.Spec.toObject(includeInstance, f)
+    grant: (f = msg.getGrant()) && grant_pb.Grant.toObject(includeInstance, f),
+    head: (f = msg.getHead()) && proto.api.Header.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -648,23 +812,23 @@ proto.api.ReferenceAndGrantSpec.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.ReferenceAndGrantSpec}
+ * @return {!proto.api.AnnotateRequest}
  */
-proto.api.ReferenceAndGrantSpec.deserializeBinary = function(bytes) {
+proto.api.AnnotateRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.ReferenceAndGrantSpec;
-  return proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.AnnotateRequest;
+  return proto.api.AnnotateRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.ReferenceAndGrantSpec} msg The message object to deserialize into.
+ * @param {!proto.api.AnnotateRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.ReferenceAndGrantSpec}
+ * @return {!proto.api.AnnotateRequest}
  */
-proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.AnnotateRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -672,14 +836,14 @@ proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader = function(msg, read
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = new reference_pb.Ref;
-      reader.readMessage(value,reference_pb.Ref.deserializeBinaryFromReader);
-      msg.setReference(value);
+      var value = new grant_pb.Grant;
+      reader.readMessage(value,grant_pb.Grant.deserializeBinaryFromReader);
+      msg.setGrant(value);
       break;
     case 2:
-      var value = new grant_pb.Spec;
-      reader.readMessage(value,grant_pb.Spec.deserializeBinaryFromReader);
-      msg.setGrantspec(value);
+      var value = new proto.api.Header;
+      reader.readMessage(value,proto.api.Header.deserializeBinaryFromReader);
+      msg.setHead(value);
       break;
     default:
       reader.skipField();
@@ -694,9 +858,9 @@ proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader = function(msg, read
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.ReferenceAndGrantSpec.prototype.serializeBinary = function() {
+proto.api.AnnotateRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.ReferenceAndGrantSpec.serializeBinaryToWriter(this, writer);
+  proto.api.AnnotateRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -704,56 +868,56 @@ proto.api.ReferenceAndGrantSpec.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.ReferenceAndGrantSpec} message
+ * @param {!proto.api.AnnotateRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.ReferenceAndGrantSpec.serializeBinaryToWriter = function(message, writer) {
+proto.api.AnnotateRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getReference();
+  f = message.getGrant();
   if (f != null) {
     writer.writeMessage(
       1,
       f,
-      reference_pb.Ref.serializeBinaryToWriter
+      grant_pb.Grant.serializeBinaryToWriter
     );
   }
-  f = message.getGrantspec();
+  f = message.getHead();
   if (f != null) {
     writer.writeMessage(
       2,
       f,
-      grant_pb.Spec.serializeBinaryToWriter
+      proto.api.Header.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional reference.Ref Reference = 1;
- * @return {?proto.reference.Ref}
+ * optional grant.Grant Grant = 1;
+ * @return {?proto.grant.Grant}
  */
-proto.api.ReferenceAndGrantSpec.prototype.getReference = function() {
-  return /** @type{?proto.reference.Ref} */ (
-    jspb.Message.getWrapperField(this, reference_pb.Ref, 1));
+proto.api.AnnotateRequest.prototype.getGrant = function() {
+  return /** @type{?proto.grant.Grant} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Grant, 1));
 };
 
 
 /**
- * @param {?proto.reference.Ref|undefined} value
- * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ * @param {?proto.grant.Grant|undefined} value
+ * @return {!proto.api.AnnotateRequest} returns this
 */
-proto.api.ReferenceAndGrantSpec.prototype.setReference = function(value) {
+proto.api.AnnotateRequest.prototype.setGrant = function(value) {
   return jspb.Message.setWrapperField(this, 1, value);
 };
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ * @return {!proto.api.AnnotateRequest} returns this
  */
-proto.api.ReferenceAndGrantSpec.prototype.clearReference = function() {
-  return this.setReference(undefined);
+proto.api.AnnotateRequest.prototype.clearGrant = function() {
+  return this.setGrant(undefined);
 };
 
 
@@ -761,36 +925,36 @@ proto.api.ReferenceAndGrantSpec.prototype.clearReference = function() {
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.api.ReferenceAndGrantSpec.prototype.hasReference = function() {
+proto.api.AnnotateRequest.prototype.hasGrant = function() {
   return jspb.Message.getField(this, 1) != null;
 };
 
 
 /**
- * optional grant.Spec GrantSpec = 2;
- * @return {?proto.grant.Spec}
+ * optional Header Head = 2;
+ * @return {?proto.api.Header}
  */
-proto.api.ReferenceAndGrantSpec.prototype.getGrantspec = function() {
-  return /** @type{?proto.grant.Spec} */ (
-    jspb.Message.getWrapperField(this, grant_pb.Spec, 2));
+proto.api.AnnotateRequest.prototype.getHead = function() {
+  return /** @type{?proto.api.Header} */ (
+    jspb.Message.getWrapperField(this, proto.api.Header, 2));
 };
 
 
 /**
- * @param {?proto.grant.Spec|undefined} value
- * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ * @param {?proto.api.Header|undefined} value
+ * @return {!proto.api.AnnotateRequest} returns this
 */
-proto.api.ReferenceAndGrantSpec.prototype.setGrantspec = function(value) {
+proto.api.AnnotateRequest.prototype.setHead = function(value) {
   return jspb.Message.setWrapperField(this, 2, value);
 };
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ * @return {!proto.api.AnnotateRequest} returns this
  */
-proto.api.ReferenceAndGrantSpec.prototype.clearGrantspec = function() {
-  return this.setGrantspec(undefined);
+proto.api.AnnotateRequest.prototype.clearHead = function() {
+  return this.setHead(undefined);
 };
 
 
@@ -798,7 +962,7 @@ proto.api.ReferenceAndGrantSpec.prototype.clearGrantspec = function() {
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.api.ReferenceAndGrantSpec.prototype.hasGrantspec = function() {
+proto.api.AnnotateRequest.prototype.hasHead = function() {
   return jspb.Message.getField(this, 2) != null;
 };
 
@@ -819,8 +983,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.Header.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.Header.toObject(opt_includeInstance, this);
+proto.api.GrantStat.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.GrantStat.toObject(opt_includeInstance, this);
 };
 
 
@@ -829,15 +993,17 @@ proto.api.Header.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.Header} msg The msg instance to transform.
+ * @param {!proto.api.GrantStat} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Header.toObject = function(includeInstance, msg) {
+proto.api.GrantStat.toObject = function(includeInstance, msg) {
   var f, obj = {
-    salt: msg.getSalt_asB64(),
-    data: msg.getData_asB64(),
-    chunksize: jspb.Message.getFieldWithDefault(msg, 3, 0)
+    size: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    chunks: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    head: (f = msg.getHead()) && proto.api.Header.toObject(includeInstance, f),
+    version: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    complete: jspb.Message.getBooleanFieldWithDefault(msg, 5, false)
   };
 
   if (includeInstance) {
@@ -851,23 +1017,23 @@ proto.api.Header.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.Header}
+ * @return {!proto.api.GrantStat}
  */
-proto.api.Header.deserializeBinary = function(bytes) {
+proto.api.GrantStat.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.Header;
-  return proto.api.Header.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.GrantStat;
+  return proto.api.GrantStat.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.Header} msg The message object to deserialize into.
+ * @param {!proto.api.GrantStat} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.Header}
+ * @return {!proto.api.GrantStat}
  */
-proto.api.Header.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.GrantStat.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -875,16 +1041,25 @@ proto.api.Header.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setSalt(value);
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
       break;
     case 2:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setData(value);
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setChunks(value);
       break;
     case 3:
-      var value = /** @type {number} */ (reader.readInt64());
-      msg.setChunksize(value);
+      var value = new proto.api.Header;
+      reader.readMessage(value,proto.api.Header.deserializeBinaryFromReader);
+      msg.setHead(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setVersion(value);
+      break;
+    case 5:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setComplete(value);
       break;
     default:
       reader.skipField();
@@ -899,9 +1074,9 @@ proto.api.Header.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.Header.prototype.serializeBinary = function() {
+proto.api.GrantStat.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.Header.serializeBinaryToWriter(this, writer);
+  proto.api.GrantStat.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -909,30 +1084,45 @@ proto.api.Header.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.Header} message
+ * @param {!proto.api.GrantStat} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Header.serializeBinaryToWriter = function(message, writer) {
+proto.api.GrantStat.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getSalt_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
       1,
       f
     );
   }
-  f = message.getData_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
+  f = message.getChunks();
+  if (f !== 0) {
+    writer.writeInt64(
       2,
       f
     );
   }
-  f = message.getChunksize();
-  if (f !== 0) {
-    writer.writeInt64(
+  f = message.getHead();
+  if (f != null) {
+    writer.writeMessage(
       3,
+      f,
+      proto.api.Header.serializeBinaryToWriter
+    );
+  }
+  f = message.getVersion();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+  f = message.getComplete();
+  if (f) {
+    writer.writeBool(
+      5,
       f
     );
   }
@@ -940,104 +1130,111 @@ proto.api.Header.serializeBinaryToWriter = function(message, writer) {
 
 
 /**
- * optional bytes Salt = 1;
- * @return {!(string|Uint8Array)}
+ * optional int64 Size = 1;
+ * @return {number}
  */
-proto.api.Header.prototype.getSalt = function() {
-  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.api.GrantStat.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 
 /**
- * optional bytes Salt = 1;
- * This is a type-conversion wrapper around `getSalt()`
- * @return {string}
+ * @param {number} value
+ * @return {!proto.api.GrantStat} returns this
  */
-proto.api.Header.prototype.getSalt_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getSalt()));
+proto.api.GrantStat.prototype.setSize = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
 };
 
 
 /**
- * optional bytes Salt = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getSalt()`
- * @return {!Uint8Array}
+ * optional int64 Chunks = 2;
+ * @return {number}
  */
-proto.api.Header.prototype.getSalt_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getSalt()));
+proto.api.GrantStat.prototype.getChunks = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 
 /**
- * @param {!(string|Uint8Array)} value
- * @return {!proto.api.Header} returns this
+ * @param {number} value
+ * @return {!proto.api.GrantStat} returns this
  */
-proto.api.Header.prototype.setSalt = function(value) {
-  return jspb.Message.setProto3BytesField(this, 1, value);
+proto.api.GrantStat.prototype.setChunks = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
 };
 
 
 /**
- * optional bytes Data = 2;
- * @return {!(string|Uint8Array)}
+ * optional Header Head = 3;
+ * @return {?proto.api.Header}
  */
-proto.api.Header.prototype.getData = function() {
-  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.api.GrantStat.prototype.getHead = function() {
+  return /** @type{?proto.api.Header} */ (
+    jspb.Message.getWrapperField(this, proto.api.Header, 3));
 };
 
 
 /**
- * optional bytes Data = 2;
- * This is a type-conversion wrapper around `getData()`
- * @return {string}
- */
-proto.api.Header.prototype.getData_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getData()));
+ * @param {?proto.api.Header|undefined} value
+ * @return {!proto.api.GrantStat} returns this
+*/
+proto.api.GrantStat.prototype.setHead = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
 };
 
 
 /**
- * optional bytes Data = 2;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getData()`
- * @return {!Uint8Array}
+ * Clears the message field making it undefined.
+ * @return {!proto.api.GrantStat} returns this
  */
-proto.api.Header.prototype.getData_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getData()));
+proto.api.GrantStat.prototype.clearHead = function() {
+  return this.setHead(undefined);
 };
 
 
 /**
- * @param {!(string|Uint8Array)} value
- * @return {!proto.api.Header} returns this
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.api.Header.prototype.setData = function(value) {
-  return jspb.Message.setProto3BytesField(this, 2, value);
+proto.api.GrantStat.prototype.hasHead = function() {
+  return jspb.Message.getField(this, 3) != null;
 };
 
 
 /**
- * optional int64 ChunkSize = 3;
+ * optional int32 Version = 4;
  * @return {number}
  */
-proto.api.Header.prototype.getChunksize = function() {
-  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+proto.api.GrantStat.prototype.getVersion = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
 };
 
 
 /**
  * @param {number} value
- * @return {!proto.api.Header} returns this
+ * @return {!proto.api.GrantStat} returns this
  */
-proto.api.Header.prototype.setChunksize = function(value) {
-  return jspb.Message.setProto3IntField(this, 3, value);
+proto.api.GrantStat.prototype.setVersion = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional bool Complete = 5;
+ * @return {boolean}
+ */
+proto.api.GrantStat.prototype.getComplete = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 5, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.api.GrantStat} returns this
+ */
+proto.api.GrantStat.prototype.setComplete = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 5, value);
 };
 
 
@@ -1057,8 +1254,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.Plaintext.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.Plaintext.toObject(opt_includeInstance, this);
+proto.api.PlaintextAndGrantSpec.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.PlaintextAndGrantSpec.toObject(opt_includeInstance, this);
 };
 
 
@@ -1067,14 +1264,14 @@ proto.api.Plaintext.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.Plaintext} msg The msg instance to transform.
+ * @param {!proto.api.PlaintextAndGrantSpec} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Plaintext.toObject = function(includeInstance, msg) {
+proto.api.PlaintextAndGrantSpec.toObject = function(includeInstance, msg) {
   var f, obj = {
-    body: msg.getBody_asB64(),
-    head: (f = msg.getHead()) && proto.api.Header.toObject(includeInstance, f)
+    plaintext: (f = msg.getPlaintext()) && proto.api.Plaintext.toObject(includeInstance, f),
+    grantspec: (f = msg.getGrantspec()) && grant_pb.Spec.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1088,23 +1285,23 @@ proto.api.Plaintext.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.Plaintext}
+ * @return {!proto.api.PlaintextAndGrantSpec}
  */
-proto.api.Plaintext.deserializeBinary = function(bytes) {
+proto.api.PlaintextAndGrantSpec.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.Plaintext;
-  return proto.api.Plaintext.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.PlaintextAndGrantSpec;
+  return proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.Plaintext} msg The message object to deserialize into.
+ * @param {!proto.api.PlaintextAndGrantSpec} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.Plaintext}
+ * @return {!proto.api.PlaintextAndGrantSpec}
  */
-proto.api.Plaintext.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.PlaintextAndGrantSpec.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1112,13 +1309,14 @@ proto.api.Plaintext.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setBody(value);
+      var value = new proto.api.Plaintext;
+      reader.readMessage(value,proto.api.Plaintext.deserializeBinaryFromReader);
+      msg.setPlaintext(value);
       break;
-    case 3:
-      var value = new proto.api.Header;
-      reader.readMessage(value,proto.api.Header.deserializeBinaryFromReader);
-      msg.setHead(value);
+    case 2:
+      var value = new grant_pb.Spec;
+      reader.readMessage(value,grant_pb.Spec.deserializeBinaryFromReader);
+      msg.setGrantspec(value);
       break;
     default:
       reader.skipField();
@@ -1133,9 +1331,9 @@ proto.api.Plaintext.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.Plaintext.prototype.serializeBinary = function() {
+proto.api.PlaintextAndGrantSpec.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.Plaintext.serializeBinaryToWriter(this, writer);
+  proto.api.PlaintextAndGrantSpec.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1143,97 +1341,1675 @@ proto.api.Plaintext.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.Plaintext} message
+ * @param {!proto.api.PlaintextAndGrantSpec} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Plaintext.serializeBinaryToWriter = function(message, writer) {
+proto.api.PlaintextAndGrantSpec.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getBody_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
+  f = message.getPlaintext();
+  if (f != null) {
+    writer.writeMessage(
       1,
-      f
+      f,
+      proto.api.Plaintext.serializeBinaryToWriter
     );
   }
-  f = message.getHead();
+  f = message.getGrantspec();
   if (f != null) {
     writer.writeMessage(
-      3,
+      2,
       f,
-      proto.api.Header.serializeBinaryToWriter
+      grant_pb.Spec.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional bytes Body = 1;
- * @return {!(string|Uint8Array)}
+ * optional Plaintext Plaintext = 1;
+ * @return {?proto.api.Plaintext}
  */
-proto.api.Plaintext.prototype.getBody = function() {
-  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.api.PlaintextAndGrantSpec.prototype.getPlaintext = function() {
+  return /** @type{?proto.api.Plaintext} */ (
+    jspb.Message.getWrapperField(this, proto.api.Plaintext, 1));
 };
 
 
 /**
- * optional bytes Body = 1;
- * This is a type-conversion wrapper around `getBody()`
- * @return {string}
- */
-proto.api.Plaintext.prototype.getBody_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getBody()));
+ * @param {?proto.api.Plaintext|undefined} value
+ * @return {!proto.api.PlaintextAndGrantSpec} returns this
+*/
+proto.api.PlaintextAndGrantSpec.prototype.setPlaintext = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
 };
 
 
 /**
- * optional bytes Body = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getBody()`
- * @return {!Uint8Array}
+ * Clears the message field making it undefined.
+ * @return {!proto.api.PlaintextAndGrantSpec} returns this
+ */
+proto.api.PlaintextAndGrantSpec.prototype.clearPlaintext = function() {
+  return this.setPlaintext(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.PlaintextAndGrantSpec.prototype.hasPlaintext = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional grant.Spec GrantSpec = 2;
+ * @return {?proto.grant.Spec}
+ */
+proto.api.PlaintextAndGrantSpec.prototype.getGrantspec = function() {
+  return /** @type{?proto.grant.Spec} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Spec, 2));
+};
+
+
+/**
+ * @param {?proto.grant.Spec|undefined} value
+ * @return {!proto.api.PlaintextAndGrantSpec} returns this
+*/
+proto.api.PlaintextAndGrantSpec.prototype.setGrantspec = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.PlaintextAndGrantSpec} returns this
+ */
+proto.api.PlaintextAndGrantSpec.prototype.clearGrantspec = function() {
+  return this.setGrantspec(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.PlaintextAndGrantSpec.prototype.hasGrantspec = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.ReferenceAndGrantSpec.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.ReferenceAndGrantSpec} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ReferenceAndGrantSpec.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    reference: (f = msg.getReference()) && reference_pb.Ref.toObject(includeInstance, f),
+    grantspec: (f = msg.getGrantspec()) && grant_pb.Spec.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.ReferenceAndGrantSpec}
+ */
+proto.api.ReferenceAndGrantSpec.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.ReferenceAndGrantSpec;
+  return proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.ReferenceAndGrantSpec} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.ReferenceAndGrantSpec}
+ */
+proto.api.ReferenceAndGrantSpec.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new reference_pb.Ref;
+      reader.readMessage(value,reference_pb.Ref.deserializeBinaryFromReader);
+      msg.setReference(value);
+      break;
+    case 2:
+      var value = new grant_pb.Spec;
+      reader.readMessage(value,grant_pb.Spec.deserializeBinaryFromReader);
+      msg.setGrantspec(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.ReferenceAndGrantSpec.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.ReferenceAndGrantSpec} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ReferenceAndGrantSpec.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getReference();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      reference_pb.Ref.serializeBinaryToWriter
+    );
+  }
+  f = message.getGrantspec();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      grant_pb.Spec.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional reference.Ref Reference = 1;
+ * @return {?proto.reference.Ref}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.getReference = function() {
+  return /** @type{?proto.reference.Ref} */ (
+    jspb.Message.getWrapperField(this, reference_pb.Ref, 1));
+};
+
+
+/**
+ * @param {?proto.reference.Ref|undefined} value
+ * @return {!proto.api.ReferenceAndGrantSpec} returns this
+*/
+proto.api.ReferenceAndGrantSpec.prototype.setReference = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ */
+proto.api.ReferenceAndGrantSpec.prototype.clearReference = function() {
+  return this.setReference(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.hasReference = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional grant.Spec GrantSpec = 2;
+ * @return {?proto.grant.Spec}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.getGrantspec = function() {
+  return /** @type{?proto.grant.Spec} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Spec, 2));
+};
+
+
+/**
+ * @param {?proto.grant.Spec|undefined} value
+ * @return {!proto.api.ReferenceAndGrantSpec} returns this
+*/
+proto.api.ReferenceAndGrantSpec.prototype.setGrantspec = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.ReferenceAndGrantSpec} returns this
+ */
+proto.api.ReferenceAndGrantSpec.prototype.clearGrantspec = function() {
+  return this.setGrantspec(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.ReferenceAndGrantSpec.prototype.hasGrantspec = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.Header.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.Header.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.Header} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Header.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    salt: msg.getSalt_asB64(),
+    data: msg.getData_asB64(),
+    chunksize: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    store: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    chunker: jspb.Message.getFieldWithDefault(msg, 5, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.Header}
+ */
+proto.api.Header.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.Header;
+  return proto.api.Header.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.Header} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.Header}
+ */
+proto.api.Header.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setSalt(value);
+      break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setChunksize(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
+      break;
+    case 5:
+      var value = /** @type {!proto.api.Chunker} */ (reader.readEnum());
+      msg.setChunker(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.Header.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.Header.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.Header} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Header.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getSalt_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+  f = message.getData_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+  f = message.getChunksize();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getChunker();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      5,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bytes Salt = 1;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.Header.prototype.getSalt = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes Salt = 1;
+ * This is a type-conversion wrapper around `getSalt()`
+ * @return {string}
+ */
+proto.api.Header.prototype.getSalt_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getSalt()));
+};
+
+
+/**
+ * optional bytes Salt = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getSalt()`
+ * @return {!Uint8Array}
+ */
+proto.api.Header.prototype.getSalt_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getSalt()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.Header} returns this
+ */
+proto.api.Header.prototype.setSalt = function(value) {
+  return jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional bytes Data = 2;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.Header.prototype.getData = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes Data = 2;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.api.Header.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
+};
+
+
+/**
+ * optional bytes Data = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
+ */
+proto.api.Header.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.Header} returns this
+ */
+proto.api.Header.prototype.setData = function(value) {
+  return jspb.Message.setProto3BytesField(this, 2, value);
+};
+
+
+/**
+ * optional int64 ChunkSize = 3;
+ * @return {number}
+ */
+proto.api.Header.prototype.getChunksize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.api.Header} returns this
+ */
+proto.api.Header.prototype.setChunksize = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional string Store = 4;
+ * @return {string}
+ */
+proto.api.Header.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.api.Header} returns this
+ */
+proto.api.Header.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional Chunker Chunker = 5;
+ * @return {!proto.api.Chunker}
+ */
+proto.api.Header.prototype.getChunker = function() {
+  return /** @type {!proto.api.Chunker} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/**
+ * @param {!proto.api.Chunker} value
+ * @return {!proto.api.Header} returns this
+ */
+proto.api.Header.prototype.setChunker = function(value) {
+  return jspb.Message.setProto3EnumField(this, 5, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.Plaintext.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.Plaintext.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.Plaintext} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Plaintext.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    body: msg.getBody_asB64(),
+    head: (f = msg.getHead()) && proto.api.Header.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.Plaintext}
+ */
+proto.api.Plaintext.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.Plaintext;
+  return proto.api.Plaintext.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.Plaintext} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.Plaintext}
+ */
+proto.api.Plaintext.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setBody(value);
+      break;
+    case 3:
+      var value = new proto.api.Header;
+      reader.readMessage(value,proto.api.Header.deserializeBinaryFromReader);
+      msg.setHead(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.Plaintext.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.Plaintext.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.Plaintext} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Plaintext.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getBody_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+  f = message.getHead();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      proto.api.Header.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional bytes Body = 1;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.Plaintext.prototype.getBody = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes Body = 1;
+ * This is a type-conversion wrapper around `getBody()`
+ * @return {string}
+ */
+proto.api.Plaintext.prototype.getBody_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getBody()));
+};
+
+
+/**
+ * optional bytes Body = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getBody()`
+ * @return {!Uint8Array}
  */
 proto.api.Plaintext.prototype.getBody_asU8 = function() {
   return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getBody()));
+      this.getBody()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.Plaintext} returns this
+ */
+proto.api.Plaintext.prototype.setBody = function(value) {
+  return jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional Header Head = 3;
+ * @return {?proto.api.Header}
+ */
+proto.api.Plaintext.prototype.getHead = function() {
+  return /** @type{?proto.api.Header} */ (
+    jspb.Message.getWrapperField(this, proto.api.Header, 3));
+};
+
+
+/**
+ * @param {?proto.api.Header|undefined} value
+ * @return {!proto.api.Plaintext} returns this
+*/
+proto.api.Plaintext.prototype.setHead = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.Plaintext} returns this
+ */
+proto.api.Plaintext.prototype.clearHead = function() {
+  return this.setHead(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.Plaintext.prototype.hasHead = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.Ciphertext.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.Ciphertext.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.Ciphertext} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Ciphertext.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    encrypteddata: msg.getEncrypteddata_asB64()
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.Ciphertext}
+ */
+proto.api.Ciphertext.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.Ciphertext;
+  return proto.api.Ciphertext.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.Ciphertext} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.Ciphertext}
+ */
+proto.api.Ciphertext.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setEncrypteddata(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.Ciphertext.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.Ciphertext.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.Ciphertext} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.Ciphertext.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getEncrypteddata_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bytes EncryptedData = 1;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.Ciphertext.prototype.getEncrypteddata = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes EncryptedData = 1;
+ * This is a type-conversion wrapper around `getEncrypteddata()`
+ * @return {string}
+ */
+proto.api.Ciphertext.prototype.getEncrypteddata_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getEncrypteddata()));
+};
+
+
+/**
+ * optional bytes EncryptedData = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getEncrypteddata()`
+ * @return {!Uint8Array}
+ */
+proto.api.Ciphertext.prototype.getEncrypteddata_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getEncrypteddata()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.Ciphertext} returns this
+ */
+proto.api.Ciphertext.prototype.setEncrypteddata = function(value) {
+  return jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.ReferenceAndCiphertext.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.ReferenceAndCiphertext.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.ReferenceAndCiphertext} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ReferenceAndCiphertext.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    reference: (f = msg.getReference()) && reference_pb.Ref.toObject(includeInstance, f),
+    ciphertext: (f = msg.getCiphertext()) && proto.api.Ciphertext.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.ReferenceAndCiphertext}
+ */
+proto.api.ReferenceAndCiphertext.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.ReferenceAndCiphertext;
+  return proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.ReferenceAndCiphertext} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.ReferenceAndCiphertext}
+ */
+proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new reference_pb.Ref;
+      reader.readMessage(value,reference_pb.Ref.deserializeBinaryFromReader);
+      msg.setReference(value);
+      break;
+    case 2:
+      var value = new proto.api.Ciphertext;
+      reader.readMessage(value,proto.api.Ciphertext.deserializeBinaryFromReader);
+      msg.setCiphertext(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.ReferenceAndCiphertext.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.ReferenceAndCiphertext.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.ReferenceAndCiphertext} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ReferenceAndCiphertext.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getReference();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      reference_pb.Ref.serializeBinaryToWriter
+    );
+  }
+  f = message.getCiphertext();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.api.Ciphertext.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional reference.Ref Reference = 1;
+ * @return {?proto.reference.Ref}
+ */
+proto.api.ReferenceAndCiphertext.prototype.getReference = function() {
+  return /** @type{?proto.reference.Ref} */ (
+    jspb.Message.getWrapperField(this, reference_pb.Ref, 1));
+};
+
+
+/**
+ * @param {?proto.reference.Ref|undefined} value
+ * @return {!proto.api.ReferenceAndCiphertext} returns this
+*/
+proto.api.ReferenceAndCiphertext.prototype.setReference = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.ReferenceAndCiphertext} returns this
+ */
+proto.api.ReferenceAndCiphertext.prototype.clearReference = function() {
+  return this.setReference(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.ReferenceAndCiphertext.prototype.hasReference = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional Ciphertext Ciphertext = 2;
+ * @return {?proto.api.Ciphertext}
+ */
+proto.api.ReferenceAndCiphertext.prototype.getCiphertext = function() {
+  return /** @type{?proto.api.Ciphertext} */ (
+    jspb.Message.getWrapperField(this, proto.api.Ciphertext, 2));
+};
+
+
+/**
+ * @param {?proto.api.Ciphertext|undefined} value
+ * @return {!proto.api.ReferenceAndCiphertext} returns this
+*/
+proto.api.ReferenceAndCiphertext.prototype.setCiphertext = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.ReferenceAndCiphertext} returns this
+ */
+proto.api.ReferenceAndCiphertext.prototype.clearCiphertext = function() {
+  return this.setCiphertext(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.ReferenceAndCiphertext.prototype.hasCiphertext = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.ListRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.ListRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.ListRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ListRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    store: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    prefix: msg.getPrefix_asB64(),
+    after: msg.getAfter_asB64(),
+    limit: jspb.Message.getFieldWithDefault(msg, 4, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.ListRequest}
+ */
+proto.api.ListRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.ListRequest;
+  return proto.api.ListRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.ListRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.ListRequest}
+ */
+proto.api.ListRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
+      break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setPrefix(value);
+      break;
+    case 3:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setAfter(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setLimit(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.ListRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.ListRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.ListRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.ListRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getPrefix_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+  f = message.getAfter_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      3,
+      f
+    );
+  }
+  f = message.getLimit();
+  if (f !== 0) {
+    writer.writeInt64(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string Store = 1;
+ * @return {string}
+ */
+proto.api.ListRequest.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.api.ListRequest} returns this
+ */
+proto.api.ListRequest.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional bytes Prefix = 2;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.ListRequest.prototype.getPrefix = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes Prefix = 2;
+ * This is a type-conversion wrapper around `getPrefix()`
+ * @return {string}
+ */
+proto.api.ListRequest.prototype.getPrefix_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getPrefix()));
+};
+
+
+/**
+ * optional bytes Prefix = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getPrefix()`
+ * @return {!Uint8Array}
+ */
+proto.api.ListRequest.prototype.getPrefix_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getPrefix()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.ListRequest} returns this
+ */
+proto.api.ListRequest.prototype.setPrefix = function(value) {
+  return jspb.Message.setProto3BytesField(this, 2, value);
+};
+
+
+/**
+ * optional bytes After = 3;
+ * @return {!(string|Uint8Array)}
+ */
+proto.api.ListRequest.prototype.getAfter = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * optional bytes After = 3;
+ * This is a type-conversion wrapper around `getAfter()`
+ * @return {string}
+ */
+proto.api.ListRequest.prototype.getAfter_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getAfter()));
+};
+
+
+/**
+ * optional bytes After = 3;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getAfter()`
+ * @return {!Uint8Array}
+ */
+proto.api.ListRequest.prototype.getAfter_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getAfter()));
 };
 
 
 /**
  * @param {!(string|Uint8Array)} value
- * @return {!proto.api.Plaintext} returns this
+ * @return {!proto.api.ListRequest} returns this
  */
-proto.api.Plaintext.prototype.setBody = function(value) {
-  return jspb.Message.setProto3BytesField(this, 1, value);
+proto.api.ListRequest.prototype.setAfter = function(value) {
+  return jspb.Message.setProto3BytesField(this, 3, value);
 };
 
 
 /**
- * optional Header Head = 3;
- * @return {?proto.api.Header}
+ * optional int64 Limit = 4;
+ * @return {number}
  */
-proto.api.Plaintext.prototype.getHead = function() {
-  return /** @type{?proto.api.Header} */ (
-    jspb.Message.getWrapperField(this, proto.api.Header, 3));
+proto.api.ListRequest.prototype.getLimit = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
 };
 
 
 /**
- * @param {?proto.api.Header|undefined} value
- * @return {!proto.api.Plaintext} returns this
+ * @param {number} value
+ * @return {!proto.api.ListRequest} returns this
+ */
+proto.api.ListRequest.prototype.setLimit = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.api.GarbageCollectRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.GarbageCollectRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.api.GarbageCollectRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.GarbageCollectRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    options: (f = msg.getOptions()) && proto.api.GarbageCollectOptions.toObject(includeInstance, f),
+    grant: (f = msg.getGrant()) && grant_pb.Grant.toObject(includeInstance, f),
+    reference: (f = msg.getReference()) && reference_pb.Ref.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.api.GarbageCollectRequest}
+ */
+proto.api.GarbageCollectRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.api.GarbageCollectRequest;
+  return proto.api.GarbageCollectRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.api.GarbageCollectRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.api.GarbageCollectRequest}
+ */
+proto.api.GarbageCollectRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.api.GarbageCollectOptions;
+      reader.readMessage(value,proto.api.GarbageCollectOptions.deserializeBinaryFromReader);
+      msg.setOptions(value);
+      break;
+    case 2:
+      var value = new grant_pb.Grant;
+      reader.readMessage(value,grant_pb.Grant.deserializeBinaryFromReader);
+      msg.setGrant(value);
+      break;
+    case 3:
+      var value = new reference_pb.Ref;
+      reader.readMessage(value,reference_pb.Ref.deserializeBinaryFromReader);
+      msg.setReference(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.api.GarbageCollectRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.api.GarbageCollectRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.api.GarbageCollectRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.api.GarbageCollectRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getOptions();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.api.GarbageCollectOptions.serializeBinaryToWriter
+    );
+  }
+  f = message.getGrant();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      grant_pb.Grant.serializeBinaryToWriter
+    );
+  }
+  f = message.getReference();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      reference_pb.Ref.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional GarbageCollectOptions Options = 1;
+ * @return {?proto.api.GarbageCollectOptions}
+ */
+proto.api.GarbageCollectRequest.prototype.getOptions = function() {
+  return /** @type{?proto.api.GarbageCollectOptions} */ (
+    jspb.Message.getWrapperField(this, proto.api.GarbageCollectOptions, 1));
+};
+
+
+/**
+ * @param {?proto.api.GarbageCollectOptions|undefined} value
+ * @return {!proto.api.GarbageCollectRequest} returns this
 */
-proto.api.Plaintext.prototype.setHead = function(value) {
+proto.api.GarbageCollectRequest.prototype.setOptions = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.GarbageCollectRequest} returns this
+ */
+proto.api.GarbageCollectRequest.prototype.clearOptions = function() {
+  return this.setOptions(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.GarbageCollectRequest.prototype.hasOptions = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional grant.Grant Grant = 2;
+ * @return {?proto.grant.Grant}
+ */
+proto.api.GarbageCollectRequest.prototype.getGrant = function() {
+  return /** @type{?proto.grant.Grant} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Grant, 2));
+};
+
+
+/**
+ * @param {?proto.grant.Grant|undefined} value
+ * @return {!proto.api.GarbageCollectRequest} returns this
+*/
+proto.api.GarbageCollectRequest.prototype.setGrant = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.GarbageCollectRequest} returns this
+ */
+proto.api.GarbageCollectRequest.prototype.clearGrant = function() {
+  return this.setGrant(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.api.GarbageCollectRequest.prototype.hasGrant = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional reference.Ref Reference = 3;
+ * @return {?proto.reference.Ref}
+ */
+proto.api.GarbageCollectRequest.prototype.getReference = function() {
+  return /** @type{?proto.reference.Ref} */ (
+    jspb.Message.getWrapperField(this, reference_pb.Ref, 3));
+};
+
+
+/**
+ * @param {?proto.reference.Ref|undefined} value
+ * @return {!proto.api.GarbageCollectRequest} returns this
+*/
+proto.api.GarbageCollectRequest.prototype.setReference = function(value) {
   return jspb.Message.setWrapperField(this, 3, value);
 };
 
 
 /**
  * Clears the message field making it undefined.
- * @return {!proto.api.Plaintext} returns this
+ * @return {!proto.api.GarbageCollectRequest} returns this
  */
-proto.api.Plaintext.prototype.clearHead = function() {
-  return this.setHead(undefined);
+proto.api.GarbageCollectRequest.prototype.clearReference = function() {
+  return this.setReference(undefined);
 };
 
 
@@ -1241,7 +3017,7 @@ proto.api.Plaintext.prototype.clearHead = function() {
  * Returns whether this field is set.
  * @return {boolean}
  */
-proto.api.Plaintext.prototype.hasHead = function() {
+proto.api.GarbageCollectRequest.prototype.hasReference = function() {
   return jspb.Message.getField(this, 3) != null;
 };
 
@@ -1262,8 +3038,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.Ciphertext.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.Ciphertext.toObject(opt_includeInstance, this);
+proto.api.GarbageCollectOptions.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.GarbageCollectOptions.toObject(opt_includeInstance, this);
 };
 
 
@@ -1272,13 +3048,15 @@ proto.api.Ciphertext.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.Ciphertext} msg The msg instance to transform.
+ * @param {!proto.api.GarbageCollectOptions} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Ciphertext.toObject = function(includeInstance, msg) {
+proto.api.GarbageCollectOptions.toObject = function(includeInstance, msg) {
   var f, obj = {
-    encrypteddata: msg.getEncrypteddata_asB64()
+    dryrun: jspb.Message.getBooleanFieldWithDefault(msg, 1, false),
+    store: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    all: jspb.Message.getBooleanFieldWithDefault(msg, 3, false)
   };
 
   if (includeInstance) {
@@ -1292,23 +3070,23 @@ proto.api.Ciphertext.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.Ciphertext}
+ * @return {!proto.api.GarbageCollectOptions}
  */
-proto.api.Ciphertext.deserializeBinary = function(bytes) {
+proto.api.GarbageCollectOptions.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.Ciphertext;
-  return proto.api.Ciphertext.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.GarbageCollectOptions;
+  return proto.api.GarbageCollectOptions.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.Ciphertext} msg The message object to deserialize into.
+ * @param {!proto.api.GarbageCollectOptions} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.Ciphertext}
+ * @return {!proto.api.GarbageCollectOptions}
  */
-proto.api.Ciphertext.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.GarbageCollectOptions.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1316,8 +3094,16 @@ proto.api.Ciphertext.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setEncrypteddata(value);
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setDryrun(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
+      break;
+    case 3:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setAll(value);
       break;
     default:
       reader.skipField();
@@ -1332,9 +3118,9 @@ proto.api.Ciphertext.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.Ciphertext.prototype.serializeBinary = function() {
+proto.api.GarbageCollectOptions.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.Ciphertext.serializeBinaryToWriter(this, writer);
+  proto.api.GarbageCollectOptions.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1342,61 +3128,87 @@ proto.api.Ciphertext.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.Ciphertext} message
+ * @param {!proto.api.GarbageCollectOptions} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Ciphertext.serializeBinaryToWriter = function(message, writer) {
+proto.api.GarbageCollectOptions.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getEncrypteddata_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
+  f = message.getDryrun();
+  if (f) {
+    writer.writeBool(
       1,
       f
     );
   }
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getAll();
+  if (f) {
+    writer.writeBool(
+      3,
+      f
+    );
+  }
 };
 
 
 /**
- * optional bytes EncryptedData = 1;
- * @return {!(string|Uint8Array)}
+ * optional bool DryRun = 1;
+ * @return {boolean}
  */
-proto.api.Ciphertext.prototype.getEncrypteddata = function() {
-  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.api.GarbageCollectOptions.prototype.getDryrun = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 1, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.api.GarbageCollectOptions} returns this
+ */
+proto.api.GarbageCollectOptions.prototype.setDryrun = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+/**
+ * optional string Store = 2;
+ * @return {string}
+ */
+proto.api.GarbageCollectOptions.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /**
- * optional bytes EncryptedData = 1;
- * This is a type-conversion wrapper around `getEncrypteddata()`
- * @return {string}
+ * @param {string} value
+ * @return {!proto.api.GarbageCollectOptions} returns this
  */
-proto.api.Ciphertext.prototype.getEncrypteddata_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getEncrypteddata()));
+proto.api.GarbageCollectOptions.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * optional bytes EncryptedData = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getEncrypteddata()`
- * @return {!Uint8Array}
+ * optional bool All = 3;
+ * @return {boolean}
  */
-proto.api.Ciphertext.prototype.getEncrypteddata_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getEncrypteddata()));
+proto.api.GarbageCollectOptions.prototype.getAll = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 3, false));
 };
 
 
 /**
- * @param {!(string|Uint8Array)} value
- * @return {!proto.api.Ciphertext} returns this
+ * @param {boolean} value
+ * @return {!proto.api.GarbageCollectOptions} returns this
  */
-proto.api.Ciphertext.prototype.setEncrypteddata = function(value) {
-  return jspb.Message.setProto3BytesField(this, 1, value);
+proto.api.GarbageCollectOptions.prototype.setAll = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 3, value);
 };
 
 
@@ -1416,8 +3228,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.ReferenceAndCiphertext.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.ReferenceAndCiphertext.toObject(opt_includeInstance, this);
+proto.api.Address.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.Address.toObject(opt_includeInstance, this);
 };
 
 
@@ -1426,14 +3238,14 @@ proto.api.ReferenceAndCiphertext.prototype.toObject = function(opt_includeInstan
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.ReferenceAndCiphertext} msg The msg instance to transform.
+ * @param {!proto.api.Address} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.ReferenceAndCiphertext.toObject = function(includeInstance, msg) {
+proto.api.Address.toObject = function(includeInstance, msg) {
   var f, obj = {
-    reference: (f = msg.getReference()) && reference_pb.Ref.toObject(includeInstance, f),
-    ciphertext: (f = msg.getCiphertext()) && proto.api.Ciphertext.toObject(includeInstance, f)
+    address: msg.getAddress_asB64(),
+    store: jspb.Message.getFieldWithDefault(msg, 2, "")
   };
 
   if (includeInstance) {
@@ -1447,23 +3259,23 @@ proto.api.ReferenceAndCiphertext.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.ReferenceAndCiphertext}
+ * @return {!proto.api.Address}
  */
-proto.api.ReferenceAndCiphertext.deserializeBinary = function(bytes) {
+proto.api.Address.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.ReferenceAndCiphertext;
-  return proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.Address;
+  return proto.api.Address.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.ReferenceAndCiphertext} msg The message object to deserialize into.
+ * @param {!proto.api.Address} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.ReferenceAndCiphertext}
+ * @return {!proto.api.Address}
  */
-proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.Address.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1471,14 +3283,12 @@ proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader = function(msg, rea
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = new reference_pb.Ref;
-      reader.readMessage(value,reference_pb.Ref.deserializeBinaryFromReader);
-      msg.setReference(value);
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setAddress(value);
       break;
     case 2:
-      var value = new proto.api.Ciphertext;
-      reader.readMessage(value,proto.api.Ciphertext.deserializeBinaryFromReader);
-      msg.setCiphertext(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
       break;
     default:
       reader.skipField();
@@ -1493,9 +3303,9 @@ proto.api.ReferenceAndCiphertext.deserializeBinaryFromReader = function(msg, rea
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.ReferenceAndCiphertext.prototype.serializeBinary = function() {
+proto.api.Address.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.ReferenceAndCiphertext.serializeBinaryToWriter(this, writer);
+  proto.api.Address.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1503,105 +3313,96 @@ proto.api.ReferenceAndCiphertext.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.ReferenceAndCiphertext} message
+ * @param {!proto.api.Address} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.ReferenceAndCiphertext.serializeBinaryToWriter = function(message, writer) {
+proto.api.Address.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getReference();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getAddress_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
       1,
-      f,
-      reference_pb.Ref.serializeBinaryToWriter
+      f
     );
   }
-  f = message.getCiphertext();
-  if (f != null) {
-    writer.writeMessage(
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
       2,
-      f,
-      proto.api.Ciphertext.serializeBinaryToWriter
+      f
     );
   }
 };
 
 
 /**
- * optional reference.Ref Reference = 1;
- * @return {?proto.reference.Ref}
+ * optional bytes Address = 1;
+ * @return {!(string|Uint8Array)}
  */
-proto.api.ReferenceAndCiphertext.prototype.getReference = function() {
-  return /** @type{?proto.reference.Ref} */ (
-    jspb.Message.getWrapperField(this, reference_pb.Ref, 1));
-};
-
-
-/**
- * @param {?proto.reference.Ref|undefined} value
- * @return {!proto.api.ReferenceAndCiphertext} returns this
-*/
-proto.api.ReferenceAndCiphertext.prototype.setReference = function(value) {
-  return jspb.Message.setWrapperField(this, 1, value);
+proto.api.Address.prototype.getAddress = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.api.ReferenceAndCiphertext} returns this
+ * optional bytes Address = 1;
+ * This is a type-conversion wrapper around `getAddress()`
+ * @return {string}
  */
-proto.api.ReferenceAndCiphertext.prototype.clearReference = function() {
-  return this.setReference(undefined);
+proto.api.Address.prototype.getAddress_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getAddress()));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * optional bytes Address = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getAddress()`
+ * @return {!Uint8Array}
  */
-proto.api.ReferenceAndCiphertext.prototype.hasReference = function() {
-  return jspb.Message.getField(this, 1) != null;
+proto.api.Address.prototype.getAddress_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getAddress()));
 };
 
 
 /**
- * optional Ciphertext Ciphertext = 2;
- * @return {?proto.api.Ciphertext}
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.api.Address} returns this
  */
-proto.api.ReferenceAndCiphertext.prototype.getCiphertext = function() {
-  return /** @type{?proto.api.Ciphertext} */ (
-    jspb.Message.getWrapperField(this, proto.api.Ciphertext, 2));
+proto.api.Address.prototype.setAddress = function(value) {
+  return jspb.Message.setProto3BytesField(this, 1, value);
 };
 
 
 /**
- * @param {?proto.api.Ciphertext|undefined} value
- * @return {!proto.api.ReferenceAndCiphertext} returns this
-*/
-proto.api.ReferenceAndCiphertext.prototype.setCiphertext = function(value) {
-  return jspb.Message.setWrapperField(this, 2, value);
+ * optional string Store = 2;
+ * @return {string}
+ */
+proto.api.Address.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.api.ReferenceAndCiphertext} returns this
+ * @param {string} value
+ * @return {!proto.api.Address} returns this
  */
-proto.api.ReferenceAndCiphertext.prototype.clearCiphertext = function() {
-  return this.setCiphertext(undefined);
+proto.api.Address.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
+
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.api.ReferenceAndCiphertext.prototype.hasCiphertext = function() {
-  return jspb.Message.getField(this, 2) != null;
-};
-
-
+proto.api.UnsealDeleteRequest.repeatedFields_ = [2];
 
 
 
@@ -1618,8 +3419,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.api.Address.prototype.toObject = function(opt_includeInstance) {
-  return proto.api.Address.toObject(opt_includeInstance, this);
+proto.api.UnsealDeleteRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.api.UnsealDeleteRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1628,13 +3429,16 @@ proto.api.Address.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Deprecated. Whether to include
  *     the JSPB instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.api.Address} msg The msg instance to transform.
+ * @param {!proto.api.UnsealDeleteRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Address.toObject = function(includeInstance, msg) {
+proto.api.UnsealDeleteRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    address: msg.getAddress_asB64()
+    grant: (f = msg.getGrant()) && grant_pb.Grant.toObject(includeInstance, f),
+    keepList: jspb.Message.toObjectList(msg.getKeepList(),
+    grant_pb.Grant.toObject, includeInstance),
+    force: jspb.Message.getBooleanFieldWithDefault(msg, 3, false)
   };
 
   if (includeInstance) {
@@ -1648,23 +3452,23 @@ proto.api.Address.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.api.Address}
+ * @return {!proto.api.UnsealDeleteRequest}
  */
-proto.api.Address.deserializeBinary = function(bytes) {
+proto.api.UnsealDeleteRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.api.Address;
-  return proto.api.Address.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.api.UnsealDeleteRequest;
+  return proto.api.UnsealDeleteRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.api.Address} msg The message object to deserialize into.
+ * @param {!proto.api.UnsealDeleteRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.api.Address}
+ * @return {!proto.api.UnsealDeleteRequest}
  */
-proto.api.Address.deserializeBinaryFromReader = function(msg, reader) {
+proto.api.UnsealDeleteRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1672,8 +3476,18 @@ proto.api.Address.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setAddress(value);
+      var value = new grant_pb.Grant;
+      reader.readMessage(value,grant_pb.Grant.deserializeBinaryFromReader);
+      msg.setGrant(value);
+      break;
+    case 2:
+      var value = new grant_pb.Grant;
+      reader.readMessage(value,grant_pb.Grant.deserializeBinaryFromReader);
+      msg.addKeep(value);
+      break;
+    case 3:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setForce(value);
       break;
     default:
       reader.skipField();
@@ -1688,9 +3502,9 @@ proto.api.Address.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.api.Address.prototype.serializeBinary = function() {
+proto.api.UnsealDeleteRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.api.Address.serializeBinaryToWriter(this, writer);
+  proto.api.UnsealDeleteRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1698,16 +3512,32 @@ proto.api.Address.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.api.Address} message
+ * @param {!proto.api.UnsealDeleteRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.api.Address.serializeBinaryToWriter = function(message, writer) {
+proto.api.UnsealDeleteRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getAddress_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
+  f = message.getGrant();
+  if (f != null) {
+    writer.writeMessage(
       1,
+      f,
+      grant_pb.Grant.serializeBinaryToWriter
+    );
+  }
+  f = message.getKeepList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      grant_pb.Grant.serializeBinaryToWriter
+    );
+  }
+  f = message.getForce();
+  if (f) {
+    writer.writeBool(
+      3,
       f
     );
   }
@@ -1715,45 +3545,105 @@ proto.api.Address.serializeBinaryToWriter = function(message, writer) {
 
 
 /**
- * optional bytes Address = 1;
- * @return {!(string|Uint8Array)}
+ * optional grant.Grant Grant = 1;
+ * @return {?proto.grant.Grant}
  */
-proto.api.Address.prototype.getAddress = function() {
-  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.api.UnsealDeleteRequest.prototype.getGrant = function() {
+  return /** @type{?proto.grant.Grant} */ (
+    jspb.Message.getWrapperField(this, grant_pb.Grant, 1));
 };
 
 
 /**
- * optional bytes Address = 1;
- * This is a type-conversion wrapper around `getAddress()`
- * @return {string}
+ * @param {?proto.grant.Grant|undefined} value
+ * @return {!proto.api.UnsealDeleteRequest} returns this
+*/
+proto.api.UnsealDeleteRequest.prototype.setGrant = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.api.UnsealDeleteRequest} returns this
  */
-proto.api.Address.prototype.getAddress_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getAddress()));
+proto.api.UnsealDeleteRequest.prototype.clearGrant = function() {
+  return this.setGrant(undefined);
 };
 
 
 /**
- * optional bytes Address = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getAddress()`
- * @return {!Uint8Array}
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.api.Address.prototype.getAddress_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getAddress()));
+proto.api.UnsealDeleteRequest.prototype.hasGrant = function() {
+  return jspb.Message.getField(this, 1) != null;
 };
 
 
 /**
- * @param {!(string|Uint8Array)} value
- * @return {!proto.api.Address} returns this
+ * repeated grant.Grant Keep = 2;
+ * @return {!Array<!proto.grant.Grant>}
  */
-proto.api.Address.prototype.setAddress = function(value) {
-  return jspb.Message.setProto3BytesField(this, 1, value);
+proto.api.UnsealDeleteRequest.prototype.getKeepList = function() {
+  return /** @type{!Array<!proto.grant.Grant>} */ (
+    jspb.Message.getRepeatedWrapperField(this, grant_pb.Grant, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.grant.Grant>} value
+ * @return {!proto.api.UnsealDeleteRequest} returns this
+*/
+proto.api.UnsealDeleteRequest.prototype.setKeepList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.grant.Grant=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.grant.Grant}
+ */
+proto.api.UnsealDeleteRequest.prototype.addKeep = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, grant_pb.Grant, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.api.UnsealDeleteRequest} returns this
+ */
+proto.api.UnsealDeleteRequest.prototype.clearKeepList = function() {
+  return this.setKeepList([]);
+};
+
+
+/**
+ * optional bool Force = 3;
+ * @return {boolean}
+ */
+proto.api.UnsealDeleteRequest.prototype.getForce = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 3, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.api.UnsealDeleteRequest} returns this
+ */
+proto.api.UnsealDeleteRequest.prototype.setForce = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 3, value);
 };
 
 
+/**
+ * @enum {number}
+ */
+proto.api.Chunker = {
+  DEFAULT: 0,
+  FIXED: 1,
+  CONTENT_DEFINED: 2
+};
+
 goog.object.extend(exports, proto.api);
@@ -58,6 +58,18 @@ export class Spec extends jspb.Message {
     getLinknonce_asU8(): Uint8Array;
     getLinknonce_asB64(): string;
     setLinknonce(value: Uint8Array | string): Spec;
+    getStore(): string;
+    setStore(value: string): Spec;
+
+    hasEnvelope(): boolean;
+    clearEnvelope(): void;
+    getEnvelope(): EnvelopeSpec | undefined;
+    setEnvelope(value?: EnvelopeSpec): Spec;
+
+    hasAge(): boolean;
+    clearAge(): void;
+    getAge(): AgeSpec | undefined;
+    setAge(value?: AgeSpec): Spec;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Spec.AsObject;
@@ -75,6 +87,9 @@ export namespace Spec {
         symmetric?: SymmetricSpec.AsObject,
         openpgp?: OpenPGPSpec.AsObject,
         linknonce: Uint8Array | string,
+        store: string,
+        envelope?: EnvelopeSpec.AsObject,
+        age?: AgeSpec.AsObject,
     }
 }
 
@@ -98,6 +113,14 @@ export namespace PlaintextSpec {
 export class SymmetricSpec extends jspb.Message { 
     getPublicid(): string;
     setPublicid(value: string): SymmetricSpec;
+    getSalt(): Uint8Array | string;
+    getSalt_asU8(): Uint8Array;
+    getSalt_asB64(): string;
+    setSalt(value: Uint8Array | string): SymmetricSpec;
+    getWorkfactor(): number;
+    setWorkfactor(value: number): SymmetricSpec;
+    getKeyversion(): number;
+    setKeyversion(value: number): SymmetricSpec;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): SymmetricSpec.AsObject;
@@ -112,6 +135,9 @@ export class SymmetricSpec extends jspb.Message {
 export namespace SymmetricSpec {
     export type AsObject = {
         publicid: string,
+        salt: Uint8Array | string,
+        workfactor: number,
+        keyversion: number,
     }
 }
 
@@ -134,3 +160,82 @@ export namespace OpenPGPSpec {
         publickey: string,
     }
 }
+
+export class EnvelopeSpec extends jspb.Message { 
+    getKeyid(): string;
+    setKeyid(value: string): EnvelopeSpec;
+    getWrappedkey(): Uint8Array | string;
+    getWrappedkey_asU8(): Uint8Array;
+    getWrappedkey_asB64(): string;
+    setWrappedkey(value: Uint8Array | string): EnvelopeSpec;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): EnvelopeSpec.AsObject;
+    static toObject(includeInstance: boolean, msg: EnvelopeSpec): EnvelopeSpec.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: EnvelopeSpec, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): EnvelopeSpec;
+    static deserializeBinaryFromReader(message: EnvelopeSpec, reader: jspb.BinaryReader): EnvelopeSpec;
+}
+
+export namespace EnvelopeSpec {
+    export type AsObject = {
+        keyid: string,
+        wrappedkey: Uint8Array | string,
+    }
+}
+
+export class AgeSpec extends jspb.Message { 
+    clearRecipientsList(): void;
+    getRecipientsList(): Array<string>;
+    setRecipientsList(value: Array<string>): AgeSpec;
+    addRecipients(value: string, index?: number): string;
+    clearStanzasList(): void;
+    getStanzasList(): Array<AgeStanza>;
+    setStanzasList(value: Array<AgeStanza>): AgeSpec;
+    addStanzas(value?: AgeStanza, index?: number): AgeStanza;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): AgeSpec.AsObject;
+    static toObject(includeInstance: boolean, msg: AgeSpec): AgeSpec.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: AgeSpec, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): AgeSpec;
+    static deserializeBinaryFromReader(message: AgeSpec, reader: jspb.BinaryReader): AgeSpec;
+}
+
+export namespace AgeSpec {
+    export type AsObject = {
+        recipientsList: Array<string>,
+        stanzasList: Array<AgeStanza.AsObject>,
+    }
+}
+
+export class AgeStanza extends jspb.Message { 
+    getShare(): Uint8Array | string;
+    getShare_asU8(): Uint8Array;
+    getShare_asB64(): string;
+    setShare(value: Uint8Array | string): AgeStanza;
+    getWrappedkey(): Uint8Array | string;
+    getWrappedkey_asU8(): Uint8Array;
+    getWrappedkey_asB64(): string;
+    setWrappedkey(value: Uint8Array | string): AgeStanza;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): AgeStanza.AsObject;
+    static toObject(includeInstance: boolean, msg: AgeStanza): AgeStanza.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: AgeStanza, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): AgeStanza;
+    static deserializeBinaryFromReader(message: AgeStanza, reader: jspb.BinaryReader): AgeStanza;
+}
+
+export namespace AgeStanza {
+    export type AsObject = {
+        share: Uint8Array | string,
+        wrappedkey: Uint8Array | string,
+    }
+}
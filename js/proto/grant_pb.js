@@ -17,6 +17,9 @@ var global = Function('return this')();
 
 var github_com_gogo_protobuf_gogoproto_gogo_pb = require('./github.com/gogo/protobuf/gogoproto/gogo_pb.js');
 goog.object.extend(proto, github_com_gogo_protobuf_gogoproto_gogo_pb);
+goog.exportSymbol('proto.grant.AgeSpec', null, global);
+goog.exportSymbol('proto.grant.AgeStanza', null, global);
+goog.exportSymbol('proto.grant.EnvelopeSpec', null, global);
 goog.exportSymbol('proto.grant.Grant', null, global);
 goog.exportSymbol('proto.grant.OpenPGPSpec', null, global);
 goog.exportSymbol('proto.grant.PlaintextSpec', null, global);
@@ -127,6 +130,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.grant.OpenPGPSpec.displayName = 'proto.grant.OpenPGPSpec';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.grant.EnvelopeSpec = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.grant.EnvelopeSpec, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.grant.EnvelopeSpec.displayName = 'proto.grant.EnvelopeSpec';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.grant.AgeSpec = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.grant.AgeSpec.repeatedFields_, null);
+};
+goog.inherits(proto.grant.AgeSpec, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.grant.AgeSpec.displayName = 'proto.grant.AgeSpec';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.grant.AgeStanza = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.grant.AgeStanza, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.grant.AgeStanza.displayName = 'proto.grant.AgeStanza';
+}
 
 
 
@@ -397,7 +463,10 @@ proto.grant.Spec.toObject = function(includeInstance, msg) {
     plaintext: (f = msg.getPlaintext()) && proto.grant.PlaintextSpec.toObject(includeInstance, f),
     symmetric: (f = msg.getSymmetric()) && proto.grant.SymmetricSpec.toObject(includeInstance, f),
     openpgp: (f = msg.getOpenpgp()) && proto.grant.OpenPGPSpec.toObject(includeInstance, f),
-    linknonce: msg.getLinknonce_asB64()
+    linknonce: msg.getLinknonce_asB64(),
+    store: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    envelope: (f = msg.getEnvelope()) && proto.grant.EnvelopeSpec.toObject(includeInstance, f),
+    age: (f = msg.getAge()) && proto.grant.AgeSpec.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -453,6 +522,20 @@ proto.grant.Spec.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {!Uint8Array} */ (reader.readBytes());
       msg.setLinknonce(value);
       break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
+      break;
+    case 6:
+      var value = new proto.grant.EnvelopeSpec;
+      reader.readMessage(value,proto.grant.EnvelopeSpec.deserializeBinaryFromReader);
+      msg.setEnvelope(value);
+      break;
+    case 7:
+      var value = new proto.grant.AgeSpec;
+      reader.readMessage(value,proto.grant.AgeSpec.deserializeBinaryFromReader);
+      msg.setAge(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -513,6 +596,29 @@ proto.grant.Spec.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getEnvelope();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      proto.grant.EnvelopeSpec.serializeBinaryToWriter
+    );
+  }
+  f = message.getAge();
+  if (f != null) {
+    writer.writeMessage(
+      7,
+      f,
+      proto.grant.AgeSpec.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -669,6 +775,98 @@ proto.grant.Spec.prototype.setLinknonce = function(value) {
 };
 
 
+/**
+ * optional string Store = 5;
+ * @return {string}
+ */
+proto.grant.Spec.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.grant.Spec} returns this
+ */
+proto.grant.Spec.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * optional EnvelopeSpec Envelope = 6;
+ * @return {?proto.grant.EnvelopeSpec}
+ */
+proto.grant.Spec.prototype.getEnvelope = function() {
+  return /** @type{?proto.grant.EnvelopeSpec} */ (
+    jspb.Message.getWrapperField(this, proto.grant.EnvelopeSpec, 6));
+};
+
+
+/**
+ * @param {?proto.grant.EnvelopeSpec|undefined} value
+ * @return {!proto.grant.Spec} returns this
+*/
+proto.grant.Spec.prototype.setEnvelope = function(value) {
+  return jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.grant.Spec} returns this
+ */
+proto.grant.Spec.prototype.clearEnvelope = function() {
+  return this.setEnvelope(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.grant.Spec.prototype.hasEnvelope = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+/**
+ * optional AgeSpec Age = 7;
+ * @return {?proto.grant.AgeSpec}
+ */
+proto.grant.Spec.prototype.getAge = function() {
+  return /** @type{?proto.grant.AgeSpec} */ (
+    jspb.Message.getWrapperField(this, proto.grant.AgeSpec, 7));
+};
+
+
+/**
+ * @param {?proto.grant.AgeSpec|undefined} value
+ * @return {!proto.grant.Spec} returns this
+*/
+proto.grant.Spec.prototype.setAge = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.grant.Spec} returns this
+ */
+proto.grant.Spec.prototype.clearAge = function() {
+  return this.setAge(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.grant.Spec.prototype.hasAge = function() {
+  return jspb.Message.getField(this, 7) != null;
+};
+
+
 
 
 
@@ -802,7 +1000,10 @@ proto.grant.SymmetricSpec.prototype.toObject = function(opt_includeInstance) {
  */
 proto.grant.SymmetricSpec.toObject = function(includeInstance, msg) {
   var f, obj = {
-    publicid: jspb.Message.getFieldWithDefault(msg, 1, "")
+    publicid: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    salt: msg.getSalt_asB64(),
+    workfactor: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    keyversion: jspb.Message.getFieldWithDefault(msg, 4, 0)
   };
 
   if (includeInstance) {
@@ -843,6 +1044,18 @@ proto.grant.SymmetricSpec.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {string} */ (reader.readString());
       msg.setPublicid(value);
       break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setSalt(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWorkfactor(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setKeyversion(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -879,6 +1092,27 @@ proto.grant.SymmetricSpec.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getSalt_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+  f = message.getWorkfactor();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getKeyversion();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -900,6 +1134,84 @@ proto.grant.SymmetricSpec.prototype.setPublicid = function(value) {
 };
 
 
+/**
+ * optional bytes Salt = 2;
+ * @return {!(string|Uint8Array)}
+ */
+proto.grant.SymmetricSpec.prototype.getSalt = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes Salt = 2;
+ * This is a type-conversion wrapper around `getSalt()`
+ * @return {string}
+ */
+proto.grant.SymmetricSpec.prototype.getSalt_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getSalt()));
+};
+
+
+/**
+ * optional bytes Salt = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getSalt()`
+ * @return {!Uint8Array}
+ */
+proto.grant.SymmetricSpec.prototype.getSalt_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getSalt()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.grant.SymmetricSpec} returns this
+ */
+proto.grant.SymmetricSpec.prototype.setSalt = function(value) {
+  return jspb.Message.setProto3BytesField(this, 2, value);
+};
+
+
+/**
+ * optional int32 WorkFactor = 3;
+ * @return {number}
+ */
+proto.grant.SymmetricSpec.prototype.getWorkfactor = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.grant.SymmetricSpec} returns this
+ */
+proto.grant.SymmetricSpec.prototype.setWorkfactor = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 KeyVersion = 4;
+ * @return {number}
+ */
+proto.grant.SymmetricSpec.prototype.getKeyversion = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.grant.SymmetricSpec} returns this
+ */
+proto.grant.SymmetricSpec.prototype.setKeyversion = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
 
 
 
@@ -1030,4 +1342,605 @@ proto.grant.OpenPGPSpec.prototype.setPublickey = function(value) {
 };
 
 
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.grant.EnvelopeSpec.prototype.toObject = function(opt_includeInstance) {
+  return proto.grant.EnvelopeSpec.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.grant.EnvelopeSpec} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.EnvelopeSpec.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    keyid: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    wrappedkey: msg.getWrappedkey_asB64()
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.grant.EnvelopeSpec}
+ */
+proto.grant.EnvelopeSpec.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.grant.EnvelopeSpec;
+  return proto.grant.EnvelopeSpec.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.grant.EnvelopeSpec} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.grant.EnvelopeSpec}
+ */
+proto.grant.EnvelopeSpec.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setKeyid(value);
+      break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setWrappedkey(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.grant.EnvelopeSpec.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.grant.EnvelopeSpec.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.grant.EnvelopeSpec} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.EnvelopeSpec.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getKeyid();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getWrappedkey_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string KeyID = 1;
+ * @return {string}
+ */
+proto.grant.EnvelopeSpec.prototype.getKeyid = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.grant.EnvelopeSpec} returns this
+ */
+proto.grant.EnvelopeSpec.prototype.setKeyid = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * @return {!(string|Uint8Array)}
+ */
+proto.grant.EnvelopeSpec.prototype.getWrappedkey = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * This is a type-conversion wrapper around `getWrappedkey()`
+ * @return {string}
+ */
+proto.grant.EnvelopeSpec.prototype.getWrappedkey_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getWrappedkey()));
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getWrappedkey()`
+ * @return {!Uint8Array}
+ */
+proto.grant.EnvelopeSpec.prototype.getWrappedkey_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getWrappedkey()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.grant.EnvelopeSpec} returns this
+ */
+proto.grant.EnvelopeSpec.prototype.setWrappedkey = function(value) {
+  return jspb.Message.setProto3BytesField(this, 2, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.grant.AgeSpec.repeatedFields_ = [1,2];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.grant.AgeSpec.prototype.toObject = function(opt_includeInstance) {
+  return proto.grant.AgeSpec.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.grant.AgeSpec} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.AgeSpec.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    recipientsList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    stanzasList: jspb.Message.toObjectList(msg.getStanzasList(),
+    proto.grant.AgeStanza.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.grant.AgeSpec}
+ */
+proto.grant.AgeSpec.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.grant.AgeSpec;
+  return proto.grant.AgeSpec.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.grant.AgeSpec} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.grant.AgeSpec}
+ */
+proto.grant.AgeSpec.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addRecipients(value);
+      break;
+    case 2:
+      var value = new proto.grant.AgeStanza;
+      reader.readMessage(value,proto.grant.AgeStanza.deserializeBinaryFromReader);
+      msg.addStanzas(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.grant.AgeSpec.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.grant.AgeSpec.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.grant.AgeSpec} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.AgeSpec.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getRecipientsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+  f = message.getStanzasList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      proto.grant.AgeStanza.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated string Recipients = 1;
+ * @return {!Array<string>}
+ */
+proto.grant.AgeSpec.prototype.getRecipientsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.grant.AgeSpec} returns this
+ */
+proto.grant.AgeSpec.prototype.setRecipientsList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.grant.AgeSpec} returns this
+ */
+proto.grant.AgeSpec.prototype.addRecipients = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.grant.AgeSpec} returns this
+ */
+proto.grant.AgeSpec.prototype.clearRecipientsList = function() {
+  return this.setRecipientsList([]);
+};
+
+
+/**
+ * repeated AgeStanza Stanzas = 2;
+ * @return {!Array<!proto.grant.AgeStanza>}
+ */
+proto.grant.AgeSpec.prototype.getStanzasList = function() {
+  return /** @type{!Array<!proto.grant.AgeStanza>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.grant.AgeStanza, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.grant.AgeStanza>} value
+ * @return {!proto.grant.AgeSpec} returns this
+*/
+proto.grant.AgeSpec.prototype.setStanzasList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.grant.AgeStanza=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.grant.AgeStanza}
+ */
+proto.grant.AgeSpec.prototype.addStanzas = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.grant.AgeStanza, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.grant.AgeSpec} returns this
+ */
+proto.grant.AgeSpec.prototype.clearStanzasList = function() {
+  return this.setStanzasList([]);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.grant.AgeStanza.prototype.toObject = function(opt_includeInstance) {
+  return proto.grant.AgeStanza.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.grant.AgeStanza} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.AgeStanza.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    share: msg.getShare_asB64(),
+    wrappedkey: msg.getWrappedkey_asB64()
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.grant.AgeStanza}
+ */
+proto.grant.AgeStanza.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.grant.AgeStanza;
+  return proto.grant.AgeStanza.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.grant.AgeStanza} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.grant.AgeStanza}
+ */
+proto.grant.AgeStanza.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setShare(value);
+      break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setWrappedkey(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.grant.AgeStanza.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.grant.AgeStanza.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.grant.AgeStanza} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.grant.AgeStanza.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getShare_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+  f = message.getWrappedkey_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bytes Share = 1;
+ * @return {!(string|Uint8Array)}
+ */
+proto.grant.AgeStanza.prototype.getShare = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes Share = 1;
+ * This is a type-conversion wrapper around `getShare()`
+ * @return {string}
+ */
+proto.grant.AgeStanza.prototype.getShare_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getShare()));
+};
+
+
+/**
+ * optional bytes Share = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getShare()`
+ * @return {!Uint8Array}
+ */
+proto.grant.AgeStanza.prototype.getShare_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getShare()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.grant.AgeStanza} returns this
+ */
+proto.grant.AgeStanza.prototype.setShare = function(value) {
+  return jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * @return {!(string|Uint8Array)}
+ */
+proto.grant.AgeStanza.prototype.getWrappedkey = function() {
+  return /** @type {!(string|Uint8Array)} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * This is a type-conversion wrapper around `getWrappedkey()`
+ * @return {string}
+ */
+proto.grant.AgeStanza.prototype.getWrappedkey_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getWrappedkey()));
+};
+
+
+/**
+ * optional bytes WrappedKey = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getWrappedkey()`
+ * @return {!Uint8Array}
+ */
+proto.grant.AgeStanza.prototype.getWrappedkey_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getWrappedkey()));
+};
+
+
+/**
+ * @param {!(string|Uint8Array)} value
+ * @return {!proto.grant.AgeStanza} returns this
+ */
+proto.grant.AgeStanza.prototype.setWrappedkey = function(value) {
+  return jspb.Message.setProto3BytesField(this, 2, value);
+};
+
+
 goog.object.extend(exports, proto.grant);
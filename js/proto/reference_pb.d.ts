@@ -25,6 +25,8 @@ export class Ref extends jspb.Message {
     setType(value: Ref.RefType): Ref;
     getSize(): number;
     setSize(value: number): Ref;
+    getStore(): string;
+    setStore(value: string): Ref;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Ref.AsObject;
@@ -44,6 +46,7 @@ export namespace Ref {
         version: number,
         type: Ref.RefType,
         size: number,
+        store: string,
     }
 
     export enum RefType {
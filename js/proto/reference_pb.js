@@ -119,7 +119,8 @@ proto.reference.Ref.toObject = function(includeInstance, msg) {
     salt: msg.getSalt_asB64(),
     version: jspb.Message.getFieldWithDefault(msg, 4, 0),
     type: jspb.Message.getFieldWithDefault(msg, 5, 0),
-    size: jspb.Message.getFieldWithDefault(msg, 6, 0)
+    size: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    store: jspb.Message.getFieldWithDefault(msg, 7, "")
   };
 
   if (includeInstance) {
@@ -180,6 +181,10 @@ proto.reference.Ref.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {number} */ (reader.readInt64());
       msg.setSize(value);
       break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStore(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -251,6 +256,13 @@ proto.reference.Ref.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getStore();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
 };
 
 
@@ -443,6 +455,24 @@ proto.reference.Ref.prototype.setSize = function(value) {
 };
 
 
+/**
+ * optional string Store = 7;
+ * @return {string}
+ */
+proto.reference.Ref.prototype.getStore = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.reference.Ref} returns this
+ */
+proto.reference.Ref.prototype.setStore = function(value) {
+  return jspb.Message.setProto3StringField(this, 7, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
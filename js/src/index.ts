@@ -10,6 +10,7 @@ import {
   PlaintextAndGrantSpec,
   ReferenceAndCiphertext,
   ReferenceAndGrantSpec,
+  UnsealDeleteRequest,
 } from '../proto/api_pb';
 import {Grant, OpenPGPSpec, PlaintextSpec, Spec, SymmetricSpec} from '../proto/grant_pb';
 import {Ref} from '../proto/reference_pb';
@@ -200,6 +201,16 @@ export class Client {
   unsealDelete(grt: Grant): Readable<Address> {
     return this.grant.unsealDelete(grt);
   }
+
+  unsealDeleteRecursive(grt: Grant, keep: Grant[] = [], force = false): Readable<Address> {
+    return this.grant.unsealDeleteRecursive(
+      make(UnsealDeleteRequest, (req) => {
+        req.setGrant(grt);
+        req.setKeepList(keep);
+        req.setForce(force);
+      }),
+    );
+  }
 }
 
 function pushPlaintexts(body: BytesLike, chunkSize: number): Readable<Plaintext> {
@@ -0,0 +1,46 @@
+package hoard
+
+import (
+	"github.com/monax/hoard/v8/reference"
+)
+
+// Encrypts (and possibly stores) the chunks pushed to it with up to workers chunks in flight at once, passing their
+// refs to send in the order the chunks were pushed. Push blocks while the workers are busy so that plaintext is only
+// received as fast as it can be encrypted.
+type encryptPipeline struct {
+	encrypt func(data, salt []byte) (*reference.Ref, []byte, error)
+	send    func(ref *reference.Ref, encryptedData []byte) error
+	salt    []byte
+	window  *orderedWindow
+}
+
+func newEncryptPipeline(workers int, salt []byte, encrypt func(data, salt []byte) (*reference.Ref, []byte, error),
+	send func(ref *reference.Ref, encryptedData []byte) error) *encryptPipeline {
+	return &encryptPipeline{
+		encrypt: encrypt,
+		send:    send,
+		salt:    salt,
+		window:  newOrderedWindow(workers),
+	}
+}
+
+// Pushes a chunk to be encrypted, returning an error if encrypting or sending a previous chunk has failed
+func (ep *encryptPipeline) push(chunk []byte) error {
+	// The caller may reuse chunk once we return
+	data := make([]byte, len(chunk))
+	copy(data, chunk)
+	return ep.window.start(func() func() error {
+		ref, encryptedData, err := ep.encrypt(data, ep.salt)
+		return func() error {
+			if err != nil {
+				return err
+			}
+			return ep.send(ref, encryptedData)
+		}
+	})
+}
+
+// Waits for the chunks pushed to be encrypted and sent returning the first error encountered
+func (ep *encryptPipeline) close() error {
+	return ep.window.close()
+}
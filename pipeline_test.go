@@ -0,0 +1,113 @@
+package hoard
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptPipeline(t *testing.T) {
+	const workers = 4
+	var mtx sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	encrypt := func(data, salt []byte) (*reference.Ref, []byte, error) {
+		mtx.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mtx.Unlock()
+		// Complete out of order
+		time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+		mtx.Lock()
+		inFlight--
+		mtx.Unlock()
+		if string(data) == "fail" {
+			return nil, nil, fmt.Errorf("could not encrypt")
+		}
+		return &reference.Ref{Address: data, Salt: salt}, data, nil
+	}
+
+	t.Run("Ordered", func(t *testing.T) {
+		var sent []byte
+		ep := newEncryptPipeline(workers, []byte("salt"), encrypt, func(ref *reference.Ref, encryptedData []byte) error {
+			assert.Equal(t, []byte("salt"), ref.Salt)
+			sent = append(sent, ref.Address...)
+			return nil
+		})
+		chunk := make([]byte, 1)
+		for i := 0; i < 100; i++ {
+			// The chunk is reused as it would be by CopyChunked
+			chunk[0] = byte(i)
+			require.NoError(t, ep.push(chunk))
+		}
+		require.NoError(t, ep.close())
+		require.Len(t, sent, 100)
+		for i, b := range sent {
+			assert.Equal(t, byte(i), b)
+		}
+		assert.LessOrEqual(t, maxInFlight, workers)
+	})
+
+	t.Run("Backpressure", func(t *testing.T) {
+		release := make(chan struct{})
+		ep := newEncryptPipeline(workers, nil, func(data, salt []byte) (*reference.Ref, []byte, error) {
+			<-release
+			return &reference.Ref{Address: data}, data, nil
+		}, func(ref *reference.Ref, encryptedData []byte) error { return nil })
+		for i := 0; i < workers; i++ {
+			require.NoError(t, ep.push([]byte{byte(i)}))
+		}
+		pushed := make(chan error)
+		go func() {
+			pushed <- ep.push([]byte("blocked"))
+		}()
+		select {
+		case <-pushed:
+			t.Fatal("push should block while every worker is busy")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		require.NoError(t, <-pushed)
+		require.NoError(t, ep.close())
+	})
+
+	t.Run("EncryptError", func(t *testing.T) {
+		ep := newEncryptPipeline(workers, nil, encrypt, func(ref *reference.Ref, encryptedData []byte) error {
+			return nil
+		})
+		var err error
+		for _, chunk := range []string{"a", "fail", "b", "c", "d", "e", "f", "g", "h"} {
+			err = ep.push([]byte(chunk))
+			if err != nil {
+				break
+			}
+		}
+		closeErr := ep.close()
+		assert.EqualError(t, closeErr, "could not encrypt")
+		if err != nil {
+			assert.Equal(t, closeErr, err)
+		}
+	})
+
+	t.Run("SendError", func(t *testing.T) {
+		sends := 0
+		ep := newEncryptPipeline(workers, nil, encrypt, func(ref *reference.Ref, encryptedData []byte) error {
+			sends++
+			return fmt.Errorf("could not send")
+		})
+		for i := 0; i < 10; i++ {
+			if ep.push([]byte{byte(i)}) != nil {
+				break
+			}
+		}
+		assert.EqualError(t, ep.close(), "could not send")
+		assert.Equal(t, 1, sends)
+	})
+}
@@ -0,0 +1,48 @@
+package hoard
+
+import (
+	"github.com/monax/hoard/v8/reference"
+)
+
+// Fetches the data behind each of refs and passes it to receive in the order of refs, stopping at the first error
+type fetchFunc func(refs []*reference.Ref, receive func(ref *reference.Ref, data []byte) error) error
+
+// Returns a fetchFunc that calls get for up to window refs concurrently (ahead of the ref being received) while
+// preserving their order. A window of one or less fetches each ref only once the previous one has been received.
+func prefetcher(window int, get func(*reference.Ref) ([]byte, error)) fetchFunc {
+	if window <= 1 {
+		return func(refs []*reference.Ref, receive func(ref *reference.Ref, data []byte) error) error {
+			for _, ref := range refs {
+				data, err := get(ref)
+				if err != nil {
+					return err
+				}
+				err = receive(ref, data)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return func(refs []*reference.Ref, receive func(ref *reference.Ref, data []byte) error) error {
+		// Data is fetched ahead of the ref being received by the window's tasks and received as it is delivered
+		window := newOrderedWindow(window)
+		for _, ref := range refs {
+			ref := ref
+			err := window.start(func() func() error {
+				data, err := get(ref)
+				return func() error {
+					if err != nil {
+						return err
+					}
+					return receive(ref, data)
+				}
+			})
+			if err != nil {
+				break
+			}
+		}
+		return window.close()
+	}
+}
@@ -0,0 +1,93 @@
+package hoard
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefetcher(t *testing.T) {
+	refs := make([]*reference.Ref, 50)
+	for i := range refs {
+		refs[i] = &reference.Ref{Address: []byte{byte(i)}}
+	}
+
+	for _, window := range []int{0, 1, 4, 100} {
+		t.Run(fmt.Sprintf("Window%d", window), func(t *testing.T) {
+			var mtx sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			get := func(ref *reference.Ref) ([]byte, error) {
+				mtx.Lock()
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				mtx.Unlock()
+				// Complete out of order
+				time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+				mtx.Lock()
+				inFlight--
+				mtx.Unlock()
+				return ref.Address, nil
+			}
+
+			var received []byte
+			err := prefetcher(window, get)(refs, func(ref *reference.Ref, data []byte) error {
+				assert.Equal(t, ref.Address, data)
+				received = append(received, data...)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, received, len(refs))
+			for i, b := range received {
+				assert.Equal(t, byte(i), b)
+			}
+
+			expectedMax := window
+			if window <= 1 {
+				expectedMax = 1
+			}
+			if expectedMax > len(refs) {
+				expectedMax = len(refs)
+			}
+			assert.LessOrEqual(t, maxInFlight, expectedMax)
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		var mtx sync.Mutex
+		fetches := 0
+		get := func(ref *reference.Ref) ([]byte, error) {
+			mtx.Lock()
+			fetches++
+			mtx.Unlock()
+			if ref.Address[0] == 10 {
+				return nil, fmt.Errorf("could not get chunk")
+			}
+			return ref.Address, nil
+		}
+		received := 0
+		err := prefetcher(4, get)(refs, func(ref *reference.Ref, data []byte) error {
+			received++
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 10, received)
+
+		// Fetching stops once the receiver has returned
+		mtx.Lock()
+		assert.Less(t, fetches, len(refs))
+		mtx.Unlock()
+
+		err = prefetcher(4, get)(refs, func(ref *reference.Ref, data []byte) error {
+			return fmt.Errorf("could not send chunk")
+		})
+		assert.EqualError(t, err, "could not send chunk")
+	})
+}
@@ -64,7 +64,9 @@ type Ref struct {
 	// Type indicates whether to undergo further decoding
 	Type Ref_RefType `protobuf:"varint,5,opt,name=Type,proto3,enum=reference.Ref_RefType" json:"Type,omitempty"`
 	// The size in bytes of the plaintext data
-	Size_                int64    `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`
+	Size_ int64 `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`
+	// The name of the configured store the data was placed in, empty for the default store
+	Store                string   `protobuf:"bytes,7,opt,name=Store,proto3" json:"Store,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -136,6 +138,13 @@ func (m *Ref) GetSize_() int64 {
 	return 0
 }
 
+func (m *Ref) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
 // Note the Salt here is different to the salt that may have been used to encrypt
 // the data pointed to by the reference.
 type RefsWithNonce struct {
@@ -248,26 +257,26 @@ func init() {
 func init() { proto.RegisterFile("reference.proto", fileDescriptor_6b165e33ad62994c) }
 
 var fileDescriptor_6b165e33ad62994c = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4f, 0x4f, 0xf2, 0x40,
-	0x10, 0xc6, 0xdf, 0xa5, 0xa5, 0xbc, 0x8c, 0x8a, 0x64, 0x62, 0xcc, 0x1e, 0x3c, 0x34, 0x35, 0x9a,
-	0xea, 0x81, 0x26, 0x78, 0xf1, 0x0a, 0x81, 0x04, 0x02, 0xc1, 0x64, 0x21, 0x1a, 0xbd, 0x95, 0x76,
-	0x2a, 0x8d, 0xd0, 0x25, 0xdb, 0x6a, 0xc4, 0xf8, 0x39, 0xfd, 0x3c, 0x66, 0x57, 0xfe, 0xc4, 0xe0,
-	0xa1, 0xc9, 0xcc, 0xf3, 0x7b, 0x3a, 0xb3, 0xfb, 0x2c, 0x1c, 0x2b, 0x4a, 0x48, 0x51, 0x16, 0x51,
-	0x63, 0xa9, 0x64, 0x21, 0xb1, 0xba, 0x15, 0xbc, 0x2f, 0x06, 0x96, 0xa0, 0x04, 0x39, 0x54, 0x5a,
-	0x71, 0xac, 0x28, 0xcf, 0x39, 0x73, 0x99, 0x7f, 0x28, 0x36, 0x2d, 0x9e, 0x41, 0x75, 0x4c, 0x91,
-	0xa2, 0x62, 0x40, 0x2b, 0x5e, 0x32, 0x6c, 0x27, 0x20, 0x82, 0x3d, 0x0e, 0xe7, 0x05, 0xb7, 0x0c,
-	0x30, 0xb5, 0x9e, 0x75, 0x4f, 0x2a, 0x4f, 0x65, 0xc6, 0x6d, 0x97, 0xf9, 0x65, 0xb1, 0x69, 0xf1,
-	0x1a, 0xec, 0xc9, 0x6a, 0x49, 0xbc, 0xec, 0x32, 0xbf, 0xd6, 0x3c, 0x6d, 0xec, 0x0e, 0x26, 0x28,
-	0xd1, 0x9f, 0xa6, 0xc2, 0x78, 0xcc, 0xe4, 0xf4, 0x83, 0xb8, 0xe3, 0x32, 0xdf, 0x12, 0xa6, 0xf6,
-	0xae, 0xa0, 0xb2, 0x36, 0xe1, 0x7f, 0xb0, 0xdb, 0x77, 0x9d, 0xc7, 0xfa, 0x3f, 0x04, 0x70, 0x7a,
-	0xdd, 0x56, 0xa7, 0x2b, 0xea, 0x4c, 0xab, 0xc3, 0xfe, 0x68, 0x50, 0x2f, 0x79, 0x7d, 0x38, 0x12,
-	0x94, 0xe4, 0x0f, 0x69, 0x31, 0x1b, 0xc9, 0x2c, 0x22, 0xf4, 0xc0, 0xd6, 0x02, 0x67, 0xae, 0xe5,
-	0x1f, 0x34, 0x6b, 0xbf, 0x77, 0x0b, 0xc3, 0xf0, 0x04, 0xca, 0x99, 0x36, 0xaf, 0xef, 0xf9, 0xd3,
-	0x78, 0x9f, 0x60, 0x0f, 0xd3, 0xec, 0x05, 0x2f, 0xc1, 0xe9, 0x51, 0x18, 0x93, 0x32, 0x11, 0xed,
-	0xcf, 0x58, 0x53, 0xbd, 0xa9, 0x2d, 0x63, 0x1d, 0xd6, 0x9f, 0x9b, 0x34, 0x43, 0x1f, 0x2a, 0x13,
-	0x15, 0xa6, 0x73, 0x52, 0x26, 0xba, 0x7d, 0xdb, 0x06, 0xb7, 0x2f, 0x9e, 0xce, 0x9f, 0xd3, 0x62,
-	0xf6, 0x3a, 0x6d, 0x44, 0x72, 0x11, 0x2c, 0x64, 0x16, 0xbe, 0x07, 0x33, 0x19, 0xaa, 0x38, 0x78,
-	0xbb, 0x0d, 0xb6, 0xff, 0x4c, 0x1d, 0xf3, 0xb4, 0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x63,
-	0xeb, 0x33, 0x5f, 0xed, 0x01, 0x00, 0x00,
+	// 333 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4d, 0x4b, 0xc3, 0x40,
+	0x10, 0x86, 0xdd, 0x26, 0x4d, 0xec, 0xa8, 0xb5, 0x2c, 0x22, 0x7b, 0xf0, 0x10, 0x22, 0xca, 0xea,
+	0xa1, 0x81, 0x7a, 0xf1, 0xda, 0xd2, 0x42, 0x4b, 0x4b, 0x85, 0x6d, 0x51, 0xf4, 0x96, 0x26, 0x13,
+	0x1b, 0x6c, 0xb3, 0x65, 0x13, 0xc5, 0x8a, 0x3f, 0xd9, 0x1f, 0x21, 0xbb, 0xfd, 0x42, 0xea, 0x21,
+	0x30, 0xef, 0xfb, 0xcc, 0x57, 0x66, 0xe1, 0x54, 0x61, 0x82, 0x0a, 0xb3, 0x08, 0xeb, 0x0b, 0x25,
+	0x0b, 0x49, 0x2b, 0x5b, 0xc3, 0xff, 0x21, 0x60, 0x09, 0x4c, 0x28, 0x03, 0xb7, 0x19, 0xc7, 0x0a,
+	0xf3, 0x9c, 0x11, 0x8f, 0xf0, 0x63, 0xb1, 0x91, 0xf4, 0x02, 0x2a, 0x23, 0x8c, 0x14, 0x16, 0x7d,
+	0x5c, 0xb2, 0x92, 0x61, 0x3b, 0x83, 0x52, 0xb0, 0x47, 0xe1, 0xac, 0x60, 0x96, 0x01, 0x26, 0xd6,
+	0xbd, 0x1e, 0x51, 0xe5, 0xa9, 0xcc, 0x98, 0xed, 0x11, 0x5e, 0x16, 0x1b, 0x49, 0x6f, 0xc1, 0x1e,
+	0x2f, 0x17, 0xc8, 0xca, 0x1e, 0xe1, 0xd5, 0xc6, 0x79, 0x7d, 0xb7, 0x98, 0xc0, 0x44, 0x7f, 0x9a,
+	0x0a, 0x93, 0x63, 0x3a, 0xa7, 0x5f, 0xc8, 0x1c, 0x8f, 0x70, 0x4b, 0x98, 0x98, 0x9e, 0x41, 0x79,
+	0x54, 0x48, 0x85, 0xcc, 0xf5, 0x08, 0xaf, 0x88, 0x95, 0xf0, 0x6f, 0xc0, 0x5d, 0x97, 0xd2, 0x43,
+	0xb0, 0x5b, 0x0f, 0xed, 0xe7, 0xda, 0x01, 0x05, 0x70, 0xba, 0x9d, 0x66, 0xbb, 0x23, 0x6a, 0x44,
+	0xbb, 0x83, 0xde, 0xb0, 0x5f, 0x2b, 0xf9, 0x3d, 0x38, 0x11, 0x98, 0xe4, 0x4f, 0x69, 0x31, 0x1d,
+	0xca, 0x2c, 0x42, 0xea, 0x83, 0xad, 0x0d, 0x46, 0x3c, 0x8b, 0x1f, 0x35, 0xaa, 0x7f, 0x37, 0x12,
+	0x86, 0xe9, 0xa9, 0x99, 0x4e, 0x5e, 0xff, 0xfd, 0x4a, 0xf8, 0xdf, 0x60, 0x0f, 0xd2, 0xec, 0x8d,
+	0x5e, 0x83, 0xd3, 0xc5, 0x30, 0x46, 0x65, 0x0e, 0xb7, 0xdf, 0x63, 0x4d, 0xf5, 0xa4, 0x96, 0x8c,
+	0xf5, 0x09, 0xff, 0x9d, 0xa4, 0x19, 0xe5, 0xe0, 0x8e, 0x55, 0x98, 0xce, 0x50, 0x99, 0x83, 0xee,
+	0xa7, 0x6d, 0x70, 0xeb, 0xea, 0xe5, 0xf2, 0x35, 0x2d, 0xa6, 0xef, 0x93, 0x7a, 0x24, 0xe7, 0xc1,
+	0x5c, 0x66, 0xe1, 0x67, 0x30, 0x95, 0xa1, 0x8a, 0x83, 0x8f, 0xfb, 0x60, 0x5b, 0x33, 0x71, 0xcc,
+	0x83, 0xdf, 0xfd, 0x0e, 0x00, 0xef, 0xa1, 0x5e, 0xb4, 0x03, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,41 @@
+package hoard
+
+import (
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/monax/hoard/v8/reference"
+	"github.com/monax/hoard/v8/versions"
+)
+
+// CiphertextSizes returns the size of the ciphertext that each ref reachable from roots (following LINK refs) implies
+// is stored in the store named storeName, keyed by address, for use with stores.Scrub. A LINK ref that cannot be read
+// is not followed, it will be reported by the scrub, so that the sizes of everything else can still be found.
+func CiphertextSizes(grantService GrantService, storeName string, roots []*reference.Ref) map[string]int64 {
+	sizes := make(map[string]int64)
+	followed := make(map[refKey]struct{})
+	var visit func(refs []*reference.Ref)
+	visit = func(refs []*reference.Ref) {
+		for _, ref := range refs {
+			if ref.Store == storeName {
+				sizes[string(ref.Address)] = encryption.ConvergentCiphertextSize(ref.Size_, ref.Salt)
+			}
+			if ref.Type != reference.Ref_LINK {
+				continue
+			}
+			if _, ok := followed[keyOf(ref)]; ok {
+				continue
+			}
+			followed[keyOf(ref)] = struct{}{}
+			data, err := grantService.Get(ref)
+			if err != nil {
+				continue
+			}
+			linked, err := reference.RefsFromPlaintext(data, versions.LatestGrantVersion)
+			if err != nil {
+				continue
+			}
+			visit(linked)
+		}
+	}
+	visit(roots)
+	return sizes
+}
@@ -0,0 +1,44 @@
+package hoard
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCiphertextSizes(t *testing.T) {
+	store := stores.NewMemoryStore()
+	hrd := NewHoard(store, config.NoopSecretManager, log.NewNopLogger())
+	service := NewStreamingService(hrd, 64)
+
+	var grt *grant.Grant
+	err := service.PutSeal(func(g *grant.Grant) error {
+		grt = g
+		return nil
+	}, sendOnce(&api.PlaintextAndGrantSpec{
+		Plaintext: &api.Plaintext{Head: &api.Header{Data: []byte("meta")}, Body: []byte(helpers.LongText)},
+		GrantSpec: &grant.Spec{Plaintext: &grant.PlaintextSpec{}},
+	}))
+	require.NoError(t, err)
+	refs, err := hrd.Unseal(grt)
+	require.NoError(t, err)
+
+	// Every blob written, including the LINK and the chunks it links to, should have the size its ref implies
+	sizes := CiphertextSizes(hrd, "", refs)
+	count := 0
+	require.NoError(t, stores.ListAll(store, nil, nil, func(address []byte) error {
+		count++
+		statInfo, err := store.Stat(address)
+		require.NoError(t, err)
+		assert.Equal(t, int64(statInfo.Size_), sizes[string(address)])
+		return nil
+	}))
+	assert.Equal(t, count, len(sizes))
+}
@@ -0,0 +1,78 @@
+package keywrap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+)
+
+var _ config.KeyWrapper = (*FileWrapper)(nil)
+
+// FileWrapper wraps keys with AES-GCM using keys read from files in a local directory, which could be a mounted
+// secret volume
+type FileWrapper struct {
+	directory string
+}
+
+func NewFileWrapper(directory string) (*FileWrapper, error) {
+	if directory == "" {
+		return nil, fmt.Errorf("a directory of keys is required to wrap keys from files")
+	}
+	return &FileWrapper{directory: directory}, nil
+}
+
+func (fw *FileWrapper) Wrap(keyID string, key []byte) ([]byte, error) {
+	kek, err := fw.readKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := encryption.NewNonce(encryption.NonceSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate nonce to wrap key: %v", err)
+	}
+	blob, err := encryption.Encrypt(key, nonce, kek)
+	if err != nil {
+		return nil, fmt.Errorf("could not wrap key with '%s': %v", keyID, err)
+	}
+	return encryption.Salinate(blob.EncryptedData, nonce), nil
+}
+
+func (fw *FileWrapper) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	kek, err := fw.readKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < encryption.NonceSize {
+		return nil, fmt.Errorf("wrapped key is too short")
+	}
+	encryptedKey, nonce := encryption.Desalinate(wrapped, encryption.NonceSize)
+	key, err := encryption.Decrypt(encryptedKey, nonce, kek)
+	if err != nil {
+		return nil, fmt.Errorf("could not unwrap key with '%s': %v", keyID, err)
+	}
+	return key, nil
+}
+
+// Keys are read on each use so they can be replaced without a restart
+func (fw *FileWrapper) readKey(keyID string) ([]byte, error) {
+	if keyID == "" || keyID != filepath.Base(keyID) || strings.HasPrefix(keyID, ".") {
+		return nil, fmt.Errorf("invalid key ID '%s'", keyID)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(fw.directory, keyID))
+	if err != nil {
+		return nil, fmt.Errorf("could not read key '%s': %v", keyID, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("key '%s' is not base64-encoded: %v", keyID, err)
+	}
+	if len(key) != encryption.KeySize {
+		return nil, fmt.Errorf("key '%s' must be %d bytes but is %d", keyID, encryption.KeySize, len(key))
+	}
+	return key, nil
+}
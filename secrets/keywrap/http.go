@@ -0,0 +1,106 @@
+package keywrap
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/monax/hoard/v8/config"
+)
+
+var _ config.KeyWrapper = (*HTTPWrapper)(nil)
+
+const requestTimeout = 10 * time.Second
+
+// HTTPWrapper wraps keys with a remote key-management service which receives a JSON object on POST requests to /wrap
+// and /unwrap such as {"keyid": "name", "key": "<base64>"} and {"keyid": "name", "wrappedkey": "<base64>"} and replies
+// to each with the same object having the other key field set
+type HTTPWrapper struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// The body of each request and response, []byte fields are base64-encoded
+type wrapMessage struct {
+	KeyID      string `json:"keyid"`
+	Key        []byte `json:"key,omitempty"`
+	WrappedKey []byte `json:"wrappedkey,omitempty"`
+}
+
+func NewHTTPWrapper(url, token, caFile string) (*HTTPWrapper, error) {
+	if url == "" {
+		return nil, fmt.Errorf("a URL is required to wrap keys over HTTP")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile != "" {
+		pool, err := config.LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &HTTPWrapper{
+		url:    strings.TrimRight(url, "/"),
+		token:  token,
+		client: &http.Client{Transport: transport, Timeout: requestTimeout},
+	}, nil
+}
+
+func (hw *HTTPWrapper) Wrap(keyID string, key []byte) ([]byte, error) {
+	result, err := hw.post("wrap", &wrapMessage{KeyID: keyID, Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.WrappedKey) == 0 {
+		return nil, fmt.Errorf("key wrapping service returned no wrapped key")
+	}
+	return result.WrappedKey, nil
+}
+
+func (hw *HTTPWrapper) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	result, err := hw.post("unwrap", &wrapMessage{KeyID: keyID, WrappedKey: wrapped})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Key) == 0 {
+		return nil, fmt.Errorf("key wrapping service returned no key")
+	}
+	return result.Key, nil
+}
+
+func (hw *HTTPWrapper) post(operation string, msg *wrapMessage) (*wrapMessage, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, hw.url+"/"+operation, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hw.token != "" {
+		req.Header.Set("Authorization", "Bearer "+hw.token)
+	}
+	resp, err := hw.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach key wrapping service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		reason, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("key wrapping service could not %s key '%s', status %d: %s", operation, msg.KeyID,
+			resp.StatusCode, strings.TrimSpace(string(reason)))
+	}
+	result := new(wrapMessage)
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode key wrapping service response: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package keywrap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWrapper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keywrap_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	kek := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "team"), []byte(kek+"\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "short"), []byte("c2hvcnQ="), 0600))
+
+	wrapper, err := NewFileWrapper(dir)
+	require.NoError(t, err)
+	testWrapper(t, wrapper)
+
+	_, err = wrapper.Wrap("short", []byte("data key"))
+	assert.Error(t, err)
+	_, err = wrapper.Wrap("../team", []byte("data key"))
+	assert.Error(t, err)
+	_, err = wrapper.Unwrap("team", []byte("short"))
+	assert.Error(t, err)
+}
+
+func TestHTTPWrapper(t *testing.T) {
+	// A stub service that wraps by reversing the key, which is enough to check the protocol
+	reverse := func(bs []byte) []byte {
+		out := make([]byte, len(bs))
+		for i, b := range bs {
+			out[len(bs)-1-i] = b
+		}
+		return out
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "bad token", http.StatusUnauthorized)
+			return
+		}
+		msg := new(wrapMessage)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(msg))
+		if msg.KeyID != "team" {
+			http.Error(w, "no such key", http.StatusNotFound)
+			return
+		}
+		switch r.URL.Path {
+		case "/kms/wrap":
+			msg.WrappedKey, msg.Key = reverse(msg.Key), nil
+		case "/kms/unwrap":
+			msg.Key, msg.WrappedKey = reverse(msg.WrappedKey), nil
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(msg))
+	}))
+	defer server.Close()
+
+	wrapper, err := NewHTTPWrapper(server.URL+"/kms/", "token", "")
+	require.NoError(t, err)
+	testWrapper(t, wrapper)
+
+	wrapper, err = NewHTTPWrapper(server.URL+"/kms", "guess", "")
+	require.NoError(t, err)
+	_, err = wrapper.Wrap("team", []byte("data key"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad token")
+}
+
+func testWrapper(t *testing.T, wrapper config.KeyWrapper) {
+	wrapped, err := wrapper.Wrap("team", []byte("data key"))
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte("data key"), wrapped)
+	key, err := wrapper.Unwrap("team", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data key"), key)
+
+	_, err = wrapper.Wrap("missing", []byte("data key"))
+	assert.Error(t, err)
+}
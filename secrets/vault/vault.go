@@ -0,0 +1,182 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monax/hoard/v8/config"
+)
+
+var _ config.SymmetricBackend = (*Client)(nil)
+var _ config.KeyWrapper = (*Client)(nil)
+
+const requestTimeout = 10 * time.Second
+
+// Client reads symmetric secrets from (and wraps keys with) the transit secrets engine of a HashiCorp Vault server
+// over its HTTP API, see https://www.vaultproject.io/api-docs/secret/transit
+type Client struct {
+	address string
+	token   string
+	ttl     time.Duration
+	client  *http.Client
+
+	mtx   sync.Mutex
+	cache map[string]cached
+}
+
+type cached struct {
+	secrets []config.SymmetricSecret
+	expires time.Time
+}
+
+// The envelope of every Vault response
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+func NewClient(conf *config.Vault) (*Client, error) {
+	if conf.Address == "" {
+		return nil, fmt.Errorf("vault config must provide an Address")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf.CAFile != "" {
+		pool, err := config.LoadCertPool(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &Client{
+		address: fmt.Sprintf("%s/v1/%s", strings.TrimRight(conf.Address, "/"), strings.Trim(conf.MountPath(), "/")),
+		token:   conf.VaultToken(),
+		ttl:     conf.CacheTTL(),
+		client:  &http.Client{Transport: transport, Timeout: requestTimeout},
+		cache:   make(map[string]cached),
+	}, nil
+}
+
+// SymmetricVersions exports every version of the transit key named by secretID, newest first
+func (vc *Client) SymmetricVersions(secretID string) ([]config.SymmetricSecret, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("empty secret ID passed to vault")
+	}
+	vc.mtx.Lock()
+	entry, ok := vc.cache[secretID]
+	vc.mtx.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.secrets, nil
+	}
+
+	exported := new(struct {
+		Keys map[string]string `json:"keys"`
+	})
+	err := vc.do(http.MethodGet, "export/encryption-key/"+url.PathEscape(secretID), nil, exported)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]config.SymmetricSecret, 0, len(exported.Keys))
+	for version, key := range exported.Keys {
+		keyVersion, err := strconv.Atoi(version)
+		if err != nil {
+			return nil, fmt.Errorf("vault returned key for '%s' with invalid version '%s'", secretID, version)
+		}
+		secretKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("vault returned key for '%s' that is not base64: %v", secretID, err)
+		}
+		secrets = append(secrets, config.SymmetricSecret{
+			PublicID:   secretID,
+			SecretKey:  secretKey,
+			KeyVersion: keyVersion,
+		})
+	}
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("vault returned no keys for '%s'", secretID)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].KeyVersion > secrets[j].KeyVersion
+	})
+
+	vc.mtx.Lock()
+	vc.cache[secretID] = cached{secrets: secrets, expires: time.Now().Add(vc.ttl)}
+	vc.mtx.Unlock()
+	return secrets, nil
+}
+
+// Wrap encrypts key with the newest version of the transit key named by secretID without the transit key leaving
+// Vault, the result includes the version used so it can be unwrapped after the transit key is rotated
+func (vc *Client) Wrap(secretID string, key []byte) ([]byte, error) {
+	result := new(struct {
+		Ciphertext string `json:"ciphertext"`
+	})
+	err := vc.do(http.MethodPost, "encrypt/"+url.PathEscape(secretID), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(result.Ciphertext), nil
+}
+
+// Unwrap decrypts a key wrapped by Wrap
+func (vc *Client) Unwrap(secretID string, wrapped []byte) ([]byte, error) {
+	result := new(struct {
+		Plaintext string `json:"plaintext"`
+	})
+	err := vc.do(http.MethodPost, "decrypt/"+url.PathEscape(secretID), map[string]string{
+		"ciphertext": string(wrapped),
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.Plaintext)
+}
+
+// Makes a request to the transit engine decoding the data of the response into out
+func (vc *Client) do(method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		err := json.NewEncoder(&body).Encode(in)
+		if err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, vc.address+"/"+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", vc.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := vc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result := new(response)
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if resp.StatusCode != http.StatusOK {
+		if err == nil && len(result.Errors) > 0 {
+			return fmt.Errorf("vault %s %s failed with status %d: %s", method, path, resp.StatusCode,
+				strings.Join(result.Errors, "; "))
+		}
+		return fmt.Errorf("vault %s %s failed with status %d", method, path, resp.StatusCode)
+	}
+	if err != nil {
+		return fmt.Errorf("could not decode vault response: %w", err)
+	}
+	return json.Unmarshal(result.Data, out)
+}
@@ -0,0 +1,152 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const token = "s.test"
+
+// A fake transit secrets engine mounted at /v1/transit holding the key versions of each named key
+func fakeVault(t *testing.T, keys map[string][][]byte, requests *int64) *httptest.Server {
+	reply := func(w http.ResponseWriter, status int, data interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string][]string{"errors": {data.(string)}}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": data}))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		if r.Header.Get("X-Vault-Token") != token {
+			reply(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/transit/"), "/")
+		versions, ok := keys[parts[len(parts)-1]]
+		if !ok {
+			reply(w, http.StatusNotFound, "encryption key not found")
+			return
+		}
+		body := make(map[string]string)
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+		switch parts[0] {
+		case "export":
+			exported := make(map[string]string)
+			for i, key := range versions {
+				exported[strconv.Itoa(i+1)] = base64.StdEncoding.EncodeToString(key)
+			}
+			reply(w, http.StatusOK, map[string]interface{}{"keys": exported})
+		case "encrypt":
+			// Not encryption but enough to check the round trip
+			reply(w, http.StatusOK, map[string]string{
+				"ciphertext": "vault:v" + strconv.Itoa(len(versions)) + ":" + body["plaintext"]})
+		case "decrypt":
+			fields := strings.SplitN(body["ciphertext"], ":", 3)
+			if len(fields) != 3 {
+				reply(w, http.StatusBadRequest, "invalid ciphertext")
+				return
+			}
+			reply(w, http.StatusOK, map[string]string{"plaintext": fields[2]})
+		default:
+			reply(w, http.StatusNotFound, "unsupported path")
+		}
+	}))
+}
+
+func TestClient(t *testing.T) {
+	keys := map[string][][]byte{
+		"team": {[]byte("0123456789abcdef0123456789abcdef"), []byte("fedcba9876543210fedcba9876543210")},
+	}
+	var requests int64
+	server := fakeVault(t, keys, &requests)
+	defer server.Close()
+
+	client, err := NewClient(&config.Vault{Address: server.URL + "/", Token: token})
+	require.NoError(t, err)
+
+	t.Run("SymmetricVersions", func(t *testing.T) {
+		secrets, err := client.SymmetricVersions("team")
+		require.NoError(t, err)
+		require.Len(t, secrets, 2)
+		assert.Equal(t, 2, secrets[0].KeyVersion)
+		assert.Equal(t, keys["team"][1], []byte(secrets[0].SecretKey))
+		assert.Equal(t, 1, secrets[1].KeyVersion)
+		assert.Equal(t, keys["team"][0], []byte(secrets[1].SecretKey))
+
+		// Served from the cache
+		before := atomic.LoadInt64(&requests)
+		_, err = client.SymmetricVersions("team")
+		require.NoError(t, err)
+		assert.Equal(t, before, atomic.LoadInt64(&requests))
+
+		_, err = client.SymmetricVersions("missing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "encryption key not found")
+	})
+
+	t.Run("WrapUnwrap", func(t *testing.T) {
+		wrapped, err := client.Wrap("team", []byte("data key"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(wrapped), "vault:v2:"))
+		key, err := client.Unwrap("team", wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data key"), key)
+
+		_, err = client.Unwrap("team", []byte("garbage"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		client, err := NewClient(&config.Vault{Address: server.URL, Token: "wrong"})
+		require.NoError(t, err)
+		_, err = client.SymmetricVersions("team")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+func TestVaultGrants(t *testing.T) {
+	keys := map[string][][]byte{"team": {[]byte("0123456789abcdef0123456789abcdef")}}
+	var requests int64
+	server := fakeVault(t, keys, &requests)
+	defer server.Close()
+
+	client, err := NewClient(&config.Vault{Address: server.URL, Token: token})
+	require.NoError(t, err)
+	secrets := config.NewSecretsManager(client, nil)
+
+	refs := []*reference.Ref{reference.New([]byte("address"), []byte("secret key"), nil, 1024)}
+	grt, err := grant.Seal(secrets, refs, &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: "team"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), grt.Spec.Symmetric.KeyVersion)
+
+	// Rotate the key in vault and drop it from the cache
+	keys["team"] = append(keys["team"], []byte("fedcba9876543210fedcba9876543210"))
+	client.cache = make(map[string]cached)
+
+	refsOut, err := grant.Unseal(secrets, grt)
+	require.NoError(t, err)
+	assert.Equal(t, refs[0].Address, refsOut[0].Address)
+
+	grt, rotated, err := grant.Rotate(secrets, grt)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, int32(2), grt.Spec.Symmetric.KeyVersion)
+}
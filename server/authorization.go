@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+	// Health checks are always allowed so that probes (which typically cannot authenticate) work
+	healthMethodPrefix = "/grpc.health.v1.Health/"
+)
+
+// WithAuthorization only allows clients to call the methods, and use the symmetric secrets, granted to them by the
+// policies in conf. The recipients of the server's age identities are authorized as secrets likewise. The health
+// service can be called by any client.
+func WithAuthorization(conf *config.Authorization, logger log.Logger) Option {
+	az := newAuthorizer(conf, logger)
+	return func(serv *Server) {
+		serv.authorizer = az
+		serv.serverOptions = append(serv.serverOptions,
+			grpc.ChainUnaryInterceptor(az.unaryInterceptor),
+			grpc.ChainStreamInterceptor(az.streamInterceptor))
+	}
+}
+
+type authorizer struct {
+	policies []*config.Policy
+	// The recipients of the identities with which the server unseals age grants
+	ageRecipients map[string]struct{}
+	logger        log.Logger
+}
+
+// The permissions of an identified (or anonymous) client
+type permissions struct {
+	identified bool
+	policies   []string
+	methods    map[string]struct{}
+	secretIDs  map[string]struct{}
+	// Shared with the authorizer, see authorizer.ageRecipients
+	ageRecipients map[string]struct{}
+}
+
+func newAuthorizer(conf *config.Authorization, logger log.Logger) *authorizer {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return &authorizer{
+		policies:      conf.Policies,
+		ageRecipients: make(map[string]struct{}),
+		logger:        logger,
+	}
+}
+
+// Records the recipients of the server's age identities so that they are authorized as secret IDs
+func (az *authorizer) addAgeIdentities(identities []*encryption.X25519Identity) {
+	for _, id := range identities {
+		az.ageRecipients[id.Recipient().String()] = struct{}{}
+	}
+}
+
+func (az *authorizer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	perms, err := az.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	err = perms.checkSecrets(info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (az *authorizer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	perms, err := az.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{
+		ServerStream: ss,
+		fullMethod:   info.FullMethod,
+		perms:        perms,
+	})
+}
+
+// Authorizes requests to the HTTP gateway by presenting their identity as it would be presented over gRPC
+func (az *authorizer) authorizeHTTP(r *http.Request, method string) (func(msg interface{}) error, error) {
+	ctx := r.Context()
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+	}
+	if values := r.Header[http.CanonicalHeaderKey(authorizationHeader)]; len(values) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.MD{authorizationHeader: values})
+	}
+	fullMethod := apiMethodPrefix + method
+	perms, err := az.authorize(ctx, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return func(msg interface{}) error {
+		return perms.checkSecrets(fullMethod, msg)
+	}, nil
+}
+
+// Checks that the client calling fullMethod is allowed to do so and returns its permissions
+func (az *authorizer) authorize(ctx context.Context, fullMethod string) (*permissions, error) {
+	if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return new(permissions), nil
+	}
+	perms, err := az.permissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !perms.allowsMethod(fullMethod) {
+		logging.InfoMsg(az.logger, "Refused unauthorized call",
+			"method", fullMethod,
+			"policies", strings.Join(perms.policies, ","))
+		if !perms.identified {
+			return nil, status.Errorf(codes.Unauthenticated, "%s requires an authenticated client", fullMethod)
+		}
+		return nil, status.Errorf(codes.PermissionDenied, "client is not authorized to call %s", fullMethod)
+	}
+	return perms, nil
+}
+
+// Collects the permissions of every policy applying to the client
+func (az *authorizer) permissions(ctx context.Context) (*permissions, error) {
+	subject := clientSubject(ctx)
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	perms := &permissions{
+		identified:    subject != "",
+		methods:       make(map[string]struct{}),
+		secretIDs:     make(map[string]struct{}),
+		ageRecipients: az.ageRecipients,
+	}
+	tokenMatched := false
+	for _, policy := range az.policies {
+		applies := policy.Anonymous || (subject != "" && contains(policy.Subjects, subject))
+		if token != "" && containsToken(policy.Tokens, token) {
+			applies = true
+			tokenMatched = true
+		}
+		if !applies {
+			continue
+		}
+		perms.policies = append(perms.policies, policy.Name)
+		for _, method := range policy.Methods {
+			perms.methods[method] = struct{}{}
+		}
+		for _, secretID := range policy.SecretIDs {
+			perms.secretIDs[secretID] = struct{}{}
+		}
+	}
+	if token != "" {
+		if !tokenMatched {
+			return nil, status.Errorf(codes.Unauthenticated, "bearer token is not recognised")
+		}
+		perms.identified = true
+	}
+	return perms, nil
+}
+
+func (perms *permissions) allowsMethod(fullMethod string) bool {
+	if _, ok := perms.methods[config.AuthorizeAll]; ok {
+		return true
+	}
+	method := methodName(fullMethod)
+	if _, ok := perms.methods[method]; ok {
+		return true
+	}
+	service := strings.Split(method, "/")[0]
+	_, ok := perms.methods[service]
+	return ok
+}
+
+func (perms *permissions) allowsSecret(publicID string) bool {
+	if _, ok := perms.secretIDs[config.AuthorizeAll]; ok {
+		return true
+	}
+	_, ok := perms.secretIDs[publicID]
+	return ok
+}
+
+// Checks any grants or grant specs carried by msg only use symmetric secrets, envelope keys, or age identities the client
+// is allowed to use
+func (perms *permissions) checkSecrets(fullMethod string, msg interface{}) error {
+	var specs []*grant.Spec
+	if m, ok := msg.(interface{ GetSpec() *grant.Spec }); ok {
+		specs = append(specs, m.GetSpec())
+	}
+	if m, ok := msg.(interface{ GetGrantSpec() *grant.Spec }); ok {
+		specs = append(specs, m.GetGrantSpec())
+	}
+	if m, ok := msg.(interface{ GetGrant() *grant.Grant }); ok {
+		specs = append(specs, m.GetGrant().GetSpec())
+	}
+	if m, ok := msg.(interface{ GetKeep() []*grant.Grant }); ok {
+		for _, grt := range m.GetKeep() {
+			specs = append(specs, grt.GetSpec())
+		}
+	}
+	for _, spec := range specs {
+		for _, secretID := range perms.secretIDsOf(spec) {
+			if !perms.allowsSecret(secretID) {
+				return status.Errorf(codes.PermissionDenied, "client is not authorized to use secret '%s' with %s",
+					secretID, fullMethod)
+			}
+		}
+	}
+	return nil
+}
+
+// The IDs of the secrets held by the server that sealing or unsealing with spec would use. An age grant uses the
+// server's identities for those of its recipients that are the server's own, or for all of them if it names none.
+func (perms *permissions) secretIDsOf(spec *grant.Spec) []string {
+	if symmetric := spec.GetSymmetric(); symmetric != nil {
+		return []string{symmetric.GetPublicID()}
+	}
+	if envelope := spec.GetEnvelope(); envelope != nil {
+		return []string{envelope.GetKeyID()}
+	}
+	age := spec.GetAge()
+	if age == nil {
+		return nil
+	}
+	var secretIDs []string
+	if len(age.GetRecipients()) == 0 {
+		for recipient := range perms.ageRecipients {
+			secretIDs = append(secretIDs, recipient)
+		}
+		sort.Strings(secretIDs)
+		return secretIDs
+	}
+	for _, encoded := range age.GetRecipients() {
+		rcp, err := encryption.ParseX25519Recipient(encoded)
+		if err != nil {
+			// Sealing will fail and the recipient cannot be one of ours when unsealing
+			continue
+		}
+		if _, ok := perms.ageRecipients[rcp.String()]; ok {
+			secretIDs = append(secretIDs, rcp.String())
+		}
+	}
+	return secretIDs
+}
+
+// Checks every message received from the client against its permissions
+type authorizedStream struct {
+	grpc.ServerStream
+	fullMethod string
+	perms      *permissions
+}
+
+func (as *authorizedStream) RecvMsg(m interface{}) error {
+	err := as.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	return as.perms.checkSecrets(as.fullMethod, m)
+}
+
+// The subject common name of a verified TLS client certificate or empty if there is none
+func clientSubject(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	if len(values) > 1 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return "", status.Errorf(codes.Unauthenticated, "expected a single 'authorization: Bearer <token>' header")
+	}
+	return strings.TrimPrefix(values[0], bearerPrefix), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
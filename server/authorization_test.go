@@ -0,0 +1,175 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/client"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/encryption"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorization(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "authorization_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	certs, err := helpers.WriteTestCertificates(tempDir, "admin")
+	require.NoError(t, err)
+
+	ageID, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+	clientID, err := encryption.GenerateX25519Identity()
+	require.NoError(t, err)
+	secretManager := config.SecretsManager{
+		Provider: func(id string) (config.SymmetricSecret, error) {
+			return config.SymmetricSecret{PublicID: id, SecretKey: bytes.Repeat([]byte(id[:1]), 32)}, nil
+		},
+		Age: []*encryption.X25519Identity{ageID},
+	}
+	authConf := config.NewAuthorization(
+		&config.Policy{
+			Name:      "public",
+			Anonymous: true,
+			Methods:   []string{"Cleartext", "Grant"},
+			SecretIDs: []string{"public"},
+		},
+		&config.Policy{
+			Name:      "admin",
+			Subjects:  []string{"admin"},
+			Methods:   []string{config.AuthorizeAll},
+			SecretIDs: []string{config.AuthorizeAll},
+		},
+		&config.Policy{
+			Name:    "monitor",
+			Tokens:  []string{"monitor-token"},
+			Methods: []string{"Storage/Stat"},
+		})
+	require.NoError(t, authConf.Validate())
+
+	tlsConf := config.NewTLS(certs.ServerCertFile, certs.ServerKeyFile, certs.CAFile)
+	tlsConf.ClientCertOptional = true
+	dialURL := serveTest(t, secretManager, tlsConf, WithAuthorization(authConf, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	anonymous := dialTest(t, dialURL, certs.CAFile, "", "")
+	defer anonymous.Close()
+	admin := dialTest(t, dialURL, certs.CAFile, certs.ClientCertFile, certs.ClientKeyFile)
+	defer admin.Close()
+	monitor := dialTest(t, dialURL, certs.CAFile, "", "",
+		grpc.WithPerRPCCredentials(client.BearerToken("monitor-token")))
+	defer monitor.Close()
+	impostor := dialTest(t, dialURL, certs.CAFile, "", "",
+		grpc.WithPerRPCCredentials(client.BearerToken("guess")))
+	defer impostor.Close()
+
+	stat := func(conn *grpc.ClientConn) error {
+		_, err := api.NewStorageClient(conn).Stat(ctx, &api.Address{Address: []byte("address")})
+		return err
+	}
+	putSeal := func(conn *grpc.ClientConn, secretID string) error {
+		spec := &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: secretID}}
+		_, err := client.New(conn).PutSeal(ctx, spec, nil, bytes.NewBufferString("secret data"))
+		return err
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		assertCode(t, codes.Unauthenticated, stat(anonymous))
+		assert.NoError(t, putSeal(anonymous, "public"))
+		assertCode(t, codes.PermissionDenied, putSeal(anonymous, "private"))
+		// Envelope keys are authorized like symmetric secrets
+		_, err := client.New(anonymous).PutSeal(ctx, &grant.Spec{Envelope: &grant.EnvelopeSpec{KeyID: "private"}}, nil,
+			bytes.NewBufferString("secret data"))
+		assertCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("Subject", func(t *testing.T) {
+		assert.NoError(t, stat(admin))
+		assert.NoError(t, putSeal(admin, "private"))
+		_, err := api.NewStorageClient(admin).Delete(ctx, &api.Address{Address: []byte("address")})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		assert.NoError(t, stat(monitor))
+		_, err := api.NewStorageClient(monitor).Delete(ctx, &api.Address{Address: []byte("address")})
+		assertCode(t, codes.PermissionDenied, err)
+		// Anonymous policies apply to identified clients too
+		assert.NoError(t, putSeal(monitor, "public"))
+	})
+
+	t.Run("KeepGrants", func(t *testing.T) {
+		private, err := client.New(admin).PutSeal(ctx, &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: "private"}},
+			nil, bytes.NewBufferString("secret data"))
+		require.NoError(t, err)
+		public, err := client.New(anonymous).PutSeal(ctx, &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: "public"}},
+			nil, bytes.NewBufferString("secret data"))
+		require.NoError(t, err)
+		// Grants to keep are unsealed so must only use secrets the client is allowed to use
+		unsealDelete, err := api.NewGrantClient(anonymous).UnsealDeleteRecursive(ctx,
+			&api.UnsealDeleteRequest{Grant: public, Keep: []*grant.Grant{private}})
+		require.NoError(t, err)
+		_, err = unsealDelete.Recv()
+		assertCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("Age", func(t *testing.T) {
+		sealAge := func(conn *grpc.ClientConn, recipients ...string) (*grant.Grant, error) {
+			return client.New(conn).PutSeal(ctx, &grant.Spec{Age: &grant.AgeSpec{Recipients: recipients}}, nil,
+				bytes.NewBufferString("secret data"))
+		}
+		unsealAge := func(conn *grpc.ClientConn, grt *grant.Grant) error {
+			_, err := client.New(conn).UnsealGet(ctx, grt)
+			return err
+		}
+		// The server's identities are authorized by their recipients whether named or sealed to by default
+		_, err := sealAge(anonymous)
+		assertCode(t, codes.PermissionDenied, err)
+		_, err = sealAge(anonymous, ageID.Recipient().String())
+		assertCode(t, codes.PermissionDenied, err)
+		// Sealing only to the client's own recipient uses none of the server's secrets
+		_, err = sealAge(anonymous, clientID.Recipient().String())
+		assert.NoError(t, err)
+
+		grt, err := sealAge(admin)
+		require.NoError(t, err)
+		assert.NoError(t, unsealAge(admin, grt))
+		assertCode(t, codes.PermissionDenied, unsealAge(anonymous, grt))
+		// Claiming another recipient does not let the server's identity unwrap the key
+		forged := *grt
+		forgedSpec := *grt.Spec
+		forgedSpec.Age = &grant.AgeSpec{Recipients: []string{clientID.Recipient().String()},
+			Stanzas: grt.Spec.Age.Stanzas}
+		forged.Spec = &forgedSpec
+		assert.Error(t, unsealAge(anonymous, &forged))
+	})
+
+	t.Run("UnknownToken", func(t *testing.T) {
+		assertCode(t, codes.Unauthenticated, putSeal(impostor, "public"))
+	})
+}
+
+// Checks the gRPC status code of err, which may have been wrapped by the client
+func assertCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+	for wrapped := err; wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		if _, ok := status.FromError(wrapped); ok {
+			assert.Equal(t, code, status.Code(wrapped), err.Error())
+			return
+		}
+	}
+	t.Errorf("expected error with code %v but got: %v", code, err)
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPGateway(t *testing.T) {
+	authConf := config.NewAuthorization(
+		&config.Policy{
+			Name:      "public",
+			Anonymous: true,
+			Methods:   []string{"Cleartext"},
+		},
+		&config.Policy{
+			Name:    "monitor",
+			Tokens:  []string{"monitor-token"},
+			Methods: []string{"Storage/Stat"},
+		})
+	serv, _ := serveTestStore(t, stores.NewMemoryStore(), config.NoopSecretManager,
+		WithHTTPGateway("tcp://127.0.0.1:0"), WithAuthorization(authConf, nil))
+	gatewayURL := "http://" + serv.GatewayAddress().String()
+
+	resp, err := http.Post(gatewayURL+"/v1/put", "application/octet-stream", strings.NewReader("data"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(gatewayURL + "/v1/blobs/YWRkcmVzcw")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, gatewayURL+"/v1/blobs/YWRkcmVzcw", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer monitor-token")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
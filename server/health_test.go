@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealth(t *testing.T) {
+	store := &flakyStore{NamedStore: stores.NewMemoryStore()}
+	store.broken.Store(true)
+	_, dialURL := serveTestStore(t, store, config.NoopSecretManager, WithHealthCheckInterval(10*time.Millisecond))
+	conn := dialTest(t, dialURL, "", "", "")
+	defer conn.Close()
+	client := healthgrpc.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	assertStatus := func(service string, expected healthgrpc.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		assert.Eventually(t, func() bool {
+			resp, err := client.Check(ctx, &healthgrpc.HealthCheckRequest{Service: service})
+			return err == nil && resp.Status == expected
+		}, 5*time.Second, 10*time.Millisecond, "expected %s to be %v", service, expected)
+	}
+
+	assertStatus("", healthgrpc.HealthCheckResponse_NOT_SERVING)
+	store.broken.Store(false)
+	assertStatus("", healthgrpc.HealthCheckResponse_SERVING)
+	assertStatus("api.Grant", healthgrpc.HealthCheckResponse_SERVING)
+	store.broken.Store(true)
+	assertStatus("api.Storage", healthgrpc.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestGracefulStop(t *testing.T) {
+	serv, dialURL := serveTestStore(t, stores.NewMemoryStore(), config.NoopSecretManager)
+	conn := dialTest(t, dialURL, "", "", "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Hold a stream open so that a request is in-flight
+	push, err := api.NewStorageClient(conn).Push(ctx)
+	require.NoError(t, err)
+	require.NoError(t, push.Send(&api.Ciphertext{EncryptedData: []byte("data")}))
+	_, err = push.Recv()
+	require.NoError(t, err)
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- serv.GracefulStop(5 * time.Second)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("GracefulStop should wait for in-flight requests")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, push.CloseSend())
+	_, err = push.Recv()
+	require.Error(t, err)
+	assert.NoError(t, <-stopped)
+
+	// A request that does not finish in time is cancelled
+	serv, dialURL = serveTestStore(t, stores.NewMemoryStore(), config.NoopSecretManager)
+	conn = dialTest(t, dialURL, "", "", "")
+	defer conn.Close()
+	push, err = api.NewStorageClient(conn).Push(ctx)
+	require.NoError(t, err)
+	require.NoError(t, push.Send(&api.Ciphertext{EncryptedData: []byte("data")}))
+	_, err = push.Recv()
+	require.NoError(t, err)
+	assert.Error(t, serv.GracefulStop(100*time.Millisecond))
+}
+
+// A store whose Stat fails while it is broken
+type flakyStore struct {
+	stores.NamedStore
+	broken atomic.Value
+}
+
+func (fs *flakyStore) Stat(address []byte) (*stores.StatInfo, error) {
+	if fs.broken.Load().(bool) {
+		return nil, errors.New("store is unreachable")
+	}
+	return fs.NamedStore.Stat(address)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics are the Prometheus metrics recorded for each RPC by a Server using WithMetrics, register them to expose them
+type Metrics struct {
+	requests         *prometheus.CounterVec
+	duration         *prometheus.HistogramVec
+	messagesReceived *prometheus.CounterVec
+	messagesSent     *prometheus.CounterVec
+	bytesReceived    *prometheus.CounterVec
+	bytesSent        *prometheus.CounterVec
+}
+
+var _ prometheus.Collector = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hoard",
+			Subsystem: "grpc",
+			Name:      name,
+			Help:      help,
+		}, labels)
+	}
+	return &Metrics{
+		requests: counter("requests_total", "Number of completed RPCs by method and status code",
+			"method", "code"),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "hoard",
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of RPCs by method",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"method"}),
+		messagesReceived: counter("messages_received_total", "Number of messages (e.g. chunks) received by method",
+			"method"),
+		messagesSent: counter("messages_sent_total", "Number of messages (e.g. chunks) sent by method",
+			"method"),
+		bytesReceived: counter("bytes_received_total", "Size of the messages received by method", "method"),
+		bytesSent:     counter("bytes_sent_total", "Size of the messages sent by method", "method"),
+	}
+}
+
+// WithMetrics records the number, duration, status, and size of every RPC in metrics
+func WithMetrics(metrics *Metrics) Option {
+	return func(serv *Server) {
+		serv.serverOptions = append(serv.serverOptions,
+			grpc.ChainUnaryInterceptor(metrics.unaryInterceptor),
+			grpc.ChainStreamInterceptor(metrics.streamInterceptor))
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range m.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range m.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.duration, m.messagesReceived, m.messagesSent, m.bytesReceived,
+		m.bytesSent}
+}
+
+func (m *Metrics) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	method := methodName(info.FullMethod)
+	start := time.Now()
+	m.received(method, req)
+	resp, err := handler(ctx, req)
+	if err == nil {
+		m.sent(method, resp)
+	}
+	m.completed(method, start, err)
+	return resp, err
+}
+
+func (m *Metrics) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	method := methodName(info.FullMethod)
+	start := time.Now()
+	err := handler(srv, &meteredStream{
+		ServerStream: ss,
+		method:       method,
+		metrics:      m,
+	})
+	m.completed(method, start, err)
+	return err
+}
+
+func (m *Metrics) received(method string, msg interface{}) {
+	m.messagesReceived.WithLabelValues(method).Inc()
+	m.bytesReceived.WithLabelValues(method).Add(float64(messageSize(msg)))
+}
+
+func (m *Metrics) sent(method string, msg interface{}) {
+	m.messagesSent.WithLabelValues(method).Inc()
+	m.bytesSent.WithLabelValues(method).Add(float64(messageSize(msg)))
+}
+
+func (m *Metrics) completed(method string, start time.Time, err error) {
+	m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// Counts the messages passing through a stream
+type meteredStream struct {
+	grpc.ServerStream
+	method  string
+	metrics *Metrics
+}
+
+func (ms *meteredStream) RecvMsg(msg interface{}) error {
+	err := ms.ServerStream.RecvMsg(msg)
+	if err == nil {
+		ms.metrics.received(ms.method, msg)
+	}
+	return err
+}
+
+func (ms *meteredStream) SendMsg(msg interface{}) error {
+	err := ms.ServerStream.SendMsg(msg)
+	if err == nil {
+		ms.metrics.sent(ms.method, msg)
+	}
+	return err
+}
+
+func messageSize(msg interface{}) int {
+	if pm, ok := msg.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/client"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/grant"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	dialURL := serveTest(t, config.NoopSecretManager, nil, WithMetrics(metrics))
+	conn := dialTest(t, dialURL, "", "", "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := api.NewStorageClient(conn).Stat(ctx, &api.Address{Address: []byte("address")})
+	require.NoError(t, err)
+	// No secrets are configured so this must fail
+	unseal, err := api.NewGrantClient(conn).Unseal(ctx, &grant.Grant{
+		Spec: &grant.Spec{Symmetric: &grant.SymmetricSpec{PublicID: "missing"}},
+	})
+	require.NoError(t, err)
+	_, unsealErr := unseal.Recv()
+	require.Error(t, unsealErr)
+
+	// Four chunks of plaintext after the message carrying the spec
+	data := bytes.Repeat([]byte{1}, 4*64*1024)
+	_, err = client.New(conn).PutSeal(ctx, &grant.Spec{Plaintext: &grant.PlaintextSpec{}}, nil,
+		bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("Storage/Stat", codes.OK.String())))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.messagesSent.WithLabelValues("Storage/Stat")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("Grant/PutSeal", codes.OK.String())))
+	unsealCode := status.Code(unsealErr).String()
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("Grant/Unseal", unsealCode)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.messagesSent.WithLabelValues("Grant/Unseal")))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(metrics.messagesReceived.WithLabelValues("Grant/PutSeal")), 5.0)
+	assert.Greater(t, testutil.ToFloat64(metrics.bytesReceived.WithLabelValues("Grant/PutSeal")), float64(len(data)))
+}
@@ -2,9 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/monax/hoard/v8"
@@ -14,50 +19,135 @@ import (
 	"github.com/monax/hoard/v8/logging/loggers"
 	"github.com/monax/hoard/v8/stores"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// Prefix of the full names of the Hoard gRPC methods, which are omitted from the method names used in configuration
+// and metrics
+const apiMethodPrefix = "/api."
+
+// The address probed with Stat to check that a store is reachable
+var healthProbeAddress = make([]byte, sha256.Size)
+
 type Server struct {
-	listenURL  string
-	listener   net.Listener
-	hoard      *hoard.Hoard
-	chunk      int64
-	grpcServer *grpc.Server
-	ready      chan struct{}
-	logger     log.Logger
+	listenURL      string
+	listener       net.Listener
+	hoard          *hoard.Hoard
+	chunk          int64
+	prefetch       int
+	encryptWorkers int
+	chunker        api.Chunker
+	uploadStats    bool
+	serverOptions  []grpc.ServerOption
+	grpcServer     *grpc.Server
+	tlsConfig      *tls.Config
+	authorizer     *authorizer
+	gatewayURL     string
+	gatewayServer  *http.Server
+	gatewayAddress net.Addr
+	health         *health.Server
+	healthInterval time.Duration
+	ready          chan struct{}
+	stop           chan struct{}
+	stopOnce       sync.Once
+	logger         log.Logger
 }
 
-func New(listenURL string, store stores.NamedStore, secretManager config.SecretsManager, chunkSize int64, logger log.Logger) *Server {
-	return &Server{
-		listenURL: listenURL,
-		hoard:     hoard.NewHoard(store, secretManager, logger),
-		chunk:     chunkSize,
-		ready:     make(chan struct{}),
-		logger:    logger,
+// Option configures optional behaviour of a Server
+type Option func(serv *Server)
+
+// WithTLS makes the server only accept TLS connections, which must present a client certificate if required by
+// tlsConfig
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(serv *Server) {
+		serv.serverOptions = append(serv.serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		serv.tlsConfig = tlsConfig
 	}
 }
 
-func (serv *Server) Serve() error {
-	netProtocol, localAddress, err := SplitListenURL(serv.listenURL)
-	if err != nil {
-		return fmt.Errorf("failed to split listen URL '%s': %v", serv.listenURL, err)
+// WithHTTPGateway serves the REST endpoints of hoard.HTTPGateway on gatewayURL ('<net>://<laddr>') in addition to
+// the gRPC API. The gateway uses the same TLS configuration and authorization policies as the gRPC API.
+func WithHTTPGateway(gatewayURL string) Option {
+	return func(serv *Server) {
+		serv.gatewayURL = gatewayURL
 	}
-	serv.listener, err = net.Listen(netProtocol, localAddress)
-	if err != nil {
-		return fmt.Errorf("failed to create listener: %v", err)
+}
+
+// WithHealthCheckInterval sets how often the stores are probed to determine the health reported by the server
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(serv *Server) {
+		serv.healthInterval = interval
+	}
+}
+
+// WithPrefetch sets the number of chunks that are fetched and decrypted concurrently when streaming plaintext
+func WithPrefetch(window int) Option {
+	return func(serv *Server) {
+		serv.prefetch = window
+	}
+}
+
+// WithEncryptWorkers sets the number of chunks that are encrypted and stored concurrently when receiving plaintext
+func WithEncryptWorkers(workers int) Option {
+	return func(serv *Server) {
+		serv.encryptWorkers = workers
+	}
+}
+
+// WithChunker sets how plaintext is split into chunks when its header does not select a chunker
+func WithChunker(chunker api.Chunker) Option {
+	return func(serv *Server) {
+		serv.chunker = chunker
+	}
+}
+
+// WithUploadStats logs how many of the chunks stored by each upload were already stored, which requires checking for
+// each chunk before it is stored
+func WithUploadStats() Option {
+	return func(serv *Server) {
+		serv.uploadStats = true
+	}
+}
+
+// New creates a Server storing data in store by default, or in one of the named routes when selected by a grant spec
+// or header
+func New(listenURL string, store stores.NamedStore, routes map[string]stores.NamedStore,
+	secretManager config.SecretsManager, chunkSize int64, logger log.Logger, options ...Option) *Server {
+	serv := &Server{
+		listenURL:      listenURL,
+		hoard:          hoard.NewRoutingHoard(store, routes, secretManager, logger),
+		chunk:          chunkSize,
+		prefetch:       config.DefaultPrefetchChunks,
+		encryptWorkers: config.DefaultEncryptWorkers,
+		healthInterval: config.DefaultHealthCheckInterval,
+		health:         health.NewServer(),
+		ready:          make(chan struct{}),
+		stop:           make(chan struct{}),
+		logger:         logger,
+	}
+	for _, option := range options {
+		option(serv)
+	}
+	if serv.authorizer != nil {
+		serv.authorizer.addAgeIdentities(secretManager.Age)
 	}
-	serv.grpcServer = grpc.NewServer()
 	if serv.logger == nil {
 		serv.logger = log.NewNopLogger()
 	} else {
 		serv.logger = loggers.Compose(logging.WithMetadata, loggers.NonBlockingLogger,
 			loggers.VectorValuedLogger)(serv.logger)
 	}
-
-	logging.InfoMsg(serv.logger, "Initialising Hoard server",
-		"store_name", serv.hoard.Name())
-
-	hoardService := hoard.NewService(serv.hoard, serv.chunk)
+	// The gRPC server and gateway are created here rather than in Serve since they may be stopped concurrently by Stop
+	// before we start serving
+	serv.grpcServer = grpc.NewServer(serv.serverOptions...)
+	hoardService := hoard.NewService(serv.hoard, serv.chunk).
+		WithPrefetch(serv.prefetch).
+		WithEncryptWorkers(serv.encryptWorkers).
+		WithChunker(serv.chunker).
+		WithUploadStats(serv.uploadReporter())
 	api.RegisterCleartextServer(serv.grpcServer, hoardService)
 	api.RegisterEncryptionServer(serv.grpcServer, hoardService)
 	api.RegisterStorageServer(serv.grpcServer, hoardService)
@@ -65,10 +155,53 @@ func (serv *Server) Serve() error {
 
 	// Register reflection service on gRPC server.
 	reflection.Register(serv.grpcServer)
+	// Report that we are not serving until the stores have been found to be reachable
+	serv.setServingStatus(healthgrpc.HealthCheckResponse_NOT_SERVING)
+	healthgrpc.RegisterHealthServer(serv.grpcServer, serv.health)
+	if serv.gatewayURL != "" {
+		var authorize hoard.GatewayAuthorizer
+		if serv.authorizer != nil {
+			authorize = serv.authorizer.authorizeHTTP
+		}
+		serv.gatewayServer = &http.Server{
+			Handler: hoard.NewHTTPGateway(serv.hoard, serv.chunk, authorize).
+				WithPrefetch(serv.prefetch).
+				WithEncryptWorkers(serv.encryptWorkers).
+				WithChunker(serv.chunker).
+				WithUploadStats(serv.uploadReporter()),
+		}
+	}
+	return serv
+}
+
+// Serve listens on the listen URL (and gateway URL if any) and serves requests until the server is stopped, returning
+// nil if the server was stopped before or while serving
+func (serv *Server) Serve() error {
+	netProtocol, localAddress, err := SplitListenURL(serv.listenURL)
+	if err != nil {
+		return fmt.Errorf("failed to split listen URL '%s': %v", serv.listenURL, err)
+	}
+	serv.listener, err = net.Listen(netProtocol, localAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create listener: %v", err)
+	}
+
+	logging.InfoMsg(serv.logger, "Initialising Hoard server",
+		"store_name", serv.hoard.Name(),
+		"store_routes", strings.Join(serv.hoard.StoreNames(), ","))
+
+	if serv.gatewayServer != nil {
+		err = serv.listenGateway()
+		if err != nil {
+			serv.listener.Close()
+			return err
+		}
+	}
+	go serv.checkHealth()
 	// Announce ready
 	close(serv.ready)
 	err = serv.grpcServer.Serve(serv.listener)
-	if err != nil {
+	if err != nil && err != grpc.ErrServerStopped {
 		return fmt.Errorf("failed to start GRPC Server: %v", err)
 	}
 	return nil
@@ -78,6 +211,34 @@ func (serv *Server) ListenAddress() net.Addr {
 	return serv.listener.Addr()
 }
 
+// GatewayAddress is the address of the HTTP gateway listener, if there is one
+func (serv *Server) GatewayAddress() net.Addr {
+	return serv.gatewayAddress
+}
+
+func (serv *Server) listenGateway() error {
+	netProtocol, localAddress, err := SplitListenURL(serv.gatewayURL)
+	if err != nil {
+		return fmt.Errorf("failed to split gateway URL '%s': %v", serv.gatewayURL, err)
+	}
+	listener, err := net.Listen(netProtocol, localAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create gateway listener: %v", err)
+	}
+	serv.gatewayAddress = listener.Addr()
+	if serv.tlsConfig != nil {
+		listener = tls.NewListener(listener, serv.tlsConfig)
+	}
+	logging.InfoMsg(serv.logger, "Serving HTTP gateway", "address", serv.gatewayAddress.String())
+	go func() {
+		err := serv.gatewayServer.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			logging.InfoMsg(serv.logger, "HTTP gateway failed", "error", err)
+		}
+	}()
+	return nil
+}
+
 // Wait until server is listening or context is done
 func (serv *Server) Wait(ctx context.Context) error {
 	select {
@@ -88,25 +249,125 @@ func (serv *Server) Wait(ctx context.Context) error {
 	}
 }
 
+// Stop the server immediately, cancelling any in-flight requests
 func (serv *Server) Stop() {
+	serv.stopHealthChecks()
+	if serv.gatewayServer != nil {
+		serv.gatewayServer.Close()
+	}
 	serv.grpcServer.Stop()
 }
 
+// GracefulStop stops the server accepting new requests and waits for in-flight requests to finish. If they have not
+// finished after timeout they are cancelled and an error is returned.
+func (serv *Server) GracefulStop(timeout time.Duration) error {
+	serv.stopHealthChecks()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		if serv.gatewayServer != nil {
+			serv.gatewayServer.Shutdown(ctx)
+		}
+		serv.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		if serv.gatewayServer != nil {
+			serv.gatewayServer.Close()
+		}
+		serv.grpcServer.Stop()
+		return fmt.Errorf("in-flight requests were cancelled after waiting %v for them to finish", timeout)
+	}
+}
+
+// Report NOT_SERVING from now on so clients stop sending us requests
+func (serv *Server) stopHealthChecks() {
+	serv.stopOnce.Do(func() {
+		close(serv.stop)
+		serv.health.Shutdown()
+	})
+}
+
+// Periodically probes the stores and reports whether we are serving through the health service
+func (serv *Server) checkHealth() {
+	ticker := time.NewTicker(serv.healthInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for first := true; ; first = false {
+		err := serv.probeStores()
+		if err != nil {
+			serv.setServingStatus(healthgrpc.HealthCheckResponse_NOT_SERVING)
+			if first || lastErr == nil {
+				logging.InfoMsg(serv.logger, "Store health check failed, not serving", "error", err)
+			}
+		} else {
+			serv.setServingStatus(healthgrpc.HealthCheckResponse_SERVING)
+			if first || lastErr != nil {
+				logging.InfoMsg(serv.logger, "Store health check passed, serving")
+			}
+		}
+		lastErr = err
+		select {
+		case <-ticker.C:
+		case <-serv.stop:
+			return
+		}
+	}
+}
+
+// Checks the default and every named store answer a Stat
+func (serv *Server) probeStores() error {
+	_, err := serv.hoard.Store().Stat(healthProbeAddress)
+	if err != nil {
+		return fmt.Errorf("default store is unreachable: %w", err)
+	}
+	for _, name := range serv.hoard.StoreNames() {
+		store, err := serv.hoard.Route(name)
+		if err != nil {
+			return err
+		}
+		_, err = store.Stat(healthProbeAddress)
+		if err != nil {
+			return fmt.Errorf("store '%s' is unreachable: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Sets the status of the server as a whole and of each of its services
+func (serv *Server) setServingStatus(servingStatus healthgrpc.HealthCheckResponse_ServingStatus) {
+	serv.health.SetServingStatus("", servingStatus)
+	for service := range serv.grpcServer.GetServiceInfo() {
+		serv.health.SetServingStatus(service, servingStatus)
+	}
+}
+
 func SplitListenURL(listenOn string) (string, string, error) {
-	// net.Listen does not want a parsed url.URL so it seems to make more sense
-	// just to do a dumb split here to support the various networks
-	listenParts := strings.Split(listenOn, "://")
-	if len(listenParts) != 2 {
-		return "", "", fmt.Errorf("expected a Go net.Listen URL of the form "+
-			"'<net>://<laddr>', but got: '%s'", listenOn)
-	}
-	if listenParts[0] == "" {
-		return "", "", fmt.Errorf("expected the URL scheme to be present, "+
-			"but got '%s'", listenOn)
-	}
-	if listenParts[1] == "" {
-		return "", "", fmt.Errorf("expected the URL host to be present, "+
-			"but got '%s'", listenOn)
-	}
-	return listenParts[0], listenParts[1], nil
+	return config.SplitListenURL(listenOn)
+}
+
+// The name of a method as used in configuration and metrics, e.g. 'Grant/PutSeal'
+func methodName(fullMethod string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(fullMethod, apiMethodPrefix), "/")
+}
+
+// Returns the function reporting upload stats, or nil if they are not enabled
+func (serv *Server) uploadReporter() func(stats *hoard.UploadStats) {
+	if !serv.uploadStats {
+		return nil
+	}
+	return serv.logUpload
+}
+
+// Logs how much of an upload was deduplicated against chunks already stored
+func (serv *Server) logUpload(stats *hoard.UploadStats) {
+	logging.InfoMsg(serv.logger, "Stored plaintext",
+		"chunks", stats.Chunks,
+		"bytes", stats.Bytes,
+		"duplicate_chunks", stats.DuplicateChunks,
+		"duplicate_bytes", stats.DuplicateBytes)
 }
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/monax/hoard/v8/api"
+	"github.com/monax/hoard/v8/client"
+	"github.com/monax/hoard/v8/config"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/monax/hoard/v8/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestServeTLS(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "server_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	certs, err := helpers.WriteTestCertificates(tempDir, "client")
+	require.NoError(t, err)
+
+	dialURL := serveTest(t, config.NoopSecretManager, config.NewTLS(certs.ServerCertFile, certs.ServerKeyFile,
+		certs.CAFile))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// An empty caFile dials without TLS
+	stat := func(caFile, certFile, keyFile string) error {
+		conn := dialTest(t, dialURL, caFile, certFile, keyFile)
+		defer conn.Close()
+		_, err = api.NewStorageClient(conn).Stat(ctx, &api.Address{Address: []byte("address")})
+		return err
+	}
+
+	t.Run("MutualTLS", func(t *testing.T) {
+		assert.NoError(t, stat(certs.CAFile, certs.ClientCertFile, certs.ClientKeyFile))
+	})
+
+	t.Run("NoClientCertificate", func(t *testing.T) {
+		assert.Error(t, stat(certs.CAFile, "", ""))
+	})
+
+	t.Run("Insecure", func(t *testing.T) {
+		assert.Error(t, stat("", "", ""))
+	})
+
+	t.Run("UntrustedServer", func(t *testing.T) {
+		otherDir, err := ioutil.TempDir(tempDir, "other")
+		require.NoError(t, err)
+		otherCerts, err := helpers.WriteTestCertificates(otherDir, "client")
+		require.NoError(t, err)
+		assert.Error(t, stat(otherCerts.CAFile, certs.ClientCertFile, certs.ClientKeyFile))
+	})
+}
+
+func TestStopBeforeServe(t *testing.T) {
+	// As happens when a signal is caught while the daemon is starting
+	t.Run("GracefulStop", func(t *testing.T) {
+		serv := New("tcp://127.0.0.1:0", stores.NewMemoryStore(), nil, config.NoopSecretManager,
+			config.DefaultChunkSize, nil, WithHTTPGateway("tcp://127.0.0.1:0"))
+		require.NoError(t, serv.GracefulStop(time.Second))
+		assert.NoError(t, serv.Serve())
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		serv := New("tcp://127.0.0.1:0", stores.NewMemoryStore(), nil, config.NoopSecretManager,
+			config.DefaultChunkSize, nil)
+		serv.Stop()
+		assert.NoError(t, serv.Serve())
+	})
+}
+
+// Serves from a memory store on a local port until the test completes, returning the URL to dial. TLS is only used if
+// tlsConf is non-nil.
+func serveTest(t *testing.T, secretManager config.SecretsManager, tlsConf *config.TLS, options ...Option) string {
+	if tlsConf != nil {
+		tlsConfig, err := tlsConf.ServerConfig()
+		require.NoError(t, err)
+		options = append(options, WithTLS(tlsConfig))
+	}
+	_, dialURL := serveTestStore(t, stores.NewMemoryStore(), secretManager, options...)
+	return dialURL
+}
+
+// Serves from store on a local port until the test completes
+func serveTestStore(t *testing.T, store stores.NamedStore, secretManager config.SecretsManager,
+	options ...Option) (*Server, string) {
+	serv := New("tcp://127.0.0.1:0", store, nil, secretManager, config.DefaultChunkSize, nil, options...)
+	go serv.Serve()
+	t.Cleanup(serv.Stop)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, serv.Wait(ctx))
+	return serv, "tcp://" + serv.ListenAddress().String()
+}
+
+// Dials a test server using TLS unless caFile is empty
+func dialTest(t *testing.T, dialURL, caFile, certFile, keyFile string, opts ...grpc.DialOption) *grpc.ClientConn {
+	var clientTLS *tls.Config
+	if caFile != "" {
+		var err error
+		clientTLS, err = client.TLSConfig(caFile, certFile, keyFile, "localhost")
+		require.NoError(t, err)
+	}
+	conn, err := client.Dial(dialURL, clientTLS, opts...)
+	require.NoError(t, err)
+	return conn
+}
@@ -38,6 +38,30 @@ func NewService(grantService GrantService, chunkSize int64) *Service {
 	}
 }
 
+// WithPrefetch sets the number of chunks fetched and decrypted concurrently, see StreamingService.WithPrefetch
+func (service *Service) WithPrefetch(window int) *Service {
+	service.streaming.WithPrefetch(window)
+	return service
+}
+
+// WithEncryptWorkers sets the number of chunks encrypted concurrently, see StreamingService.WithEncryptWorkers
+func (service *Service) WithEncryptWorkers(workers int) *Service {
+	service.streaming.WithEncryptWorkers(workers)
+	return service
+}
+
+// WithChunker sets how plaintext is split into chunks by default, see StreamingService.WithChunker
+func (service *Service) WithChunker(chunker api.Chunker) *Service {
+	service.streaming.WithChunker(chunker)
+	return service
+}
+
+// WithUploadStats reports the chunks stored by each upload, see StreamingService.WithUploadStats
+func (service *Service) WithUploadStats(report func(stats *UploadStats)) *Service {
+	service.streaming.WithUploadStats(report)
+	return service
+}
+
 // PutSeal encrypts and seals plaintext
 func (service *Service) PutSeal(srv api.Grant_PutSealServer) error {
 	return service.streaming.PutSeal(srv.SendAndClose, srv.Recv)
@@ -47,6 +71,10 @@ func (service *Service) UnsealGet(grt *grant.Grant, srv api.Grant_UnsealGetServe
 	return service.streaming.UnsealGet(grt, srv.Send)
 }
 
+func (service *Service) UnsealGetRange(req *api.UnsealGetRangeRequest, srv api.Grant_UnsealGetRangeServer) error {
+	return service.streaming.UnsealGetRange(req, srv.Send)
+}
+
 func (service *Service) Seal(srv api.Grant_SealServer) error {
 	return service.streaming.Seal(srv.SendAndClose, srv.Recv)
 }
@@ -59,10 +87,25 @@ func (service *Service) Reseal(ctx context.Context, grts *api.GrantAndGrantSpec)
 	return service.streaming.Reseal(grts)
 }
 
+// Annotate replaces the header metadata of the plaintext behind a grant returning a new grant
+func (service *Service) Annotate(ctx context.Context, req *api.AnnotateRequest) (*grant.Grant, error) {
+	return service.streaming.Annotate(req)
+}
+
+// StatGrant describes the plaintext behind a grant without fetching its body
+func (service *Service) StatGrant(ctx context.Context, grt *grant.Grant) (*api.GrantStat, error) {
+	return service.streaming.StatGrant(grt)
+}
+
 func (service *Service) UnsealDelete(grt *grant.Grant, srv api.Grant_UnsealDeleteServer) error {
 	return service.streaming.UnsealDelete(grt, srv.Send)
 }
 
+func (service *Service) UnsealDeleteRecursive(req *api.UnsealDeleteRequest,
+	srv api.Grant_UnsealDeleteRecursiveServer) error {
+	return service.streaming.UnsealDeleteRecursive(req, srv.Send)
+}
+
 func (service *Service) Put(srv api.Cleartext_PutServer) error {
 	return service.streaming.Put(srv.Send, srv.Recv)
 }
@@ -87,9 +130,19 @@ func (service *Service) Pull(srv api.Storage_PullServer) error {
 	return service.streaming.Pull(srv.Send, srv.Recv)
 }
 
+// List the addresses held by a store
+func (service *Service) List(req *api.ListRequest, srv api.Storage_ListServer) error {
+	return service.streaming.List(req, srv.Send)
+}
+
+// GarbageCollect removes data that is not reachable from the grants and references provided
+func (service *Service) GarbageCollect(srv api.Storage_GarbageCollectServer) error {
+	return service.streaming.GarbageCollect(srv.Send, srv.Recv)
+}
+
 // Delete removes the data located at the address
 func (service *Service) Delete(ctx context.Context, address *api.Address) (*api.Address, error) {
-	return address, service.streaming.Delete(address.Address)
+	return address, service.streaming.Delete(address)
 }
 
 // Stat checks the data stored at the given address
@@ -190,6 +190,38 @@ func TestService(t *testing.T) {
 				require.Equal(t, data, bs)
 			})
 
+			t.Run("StreamingRange", func(t *testing.T) {
+				gs := &grant.Spec{
+					Symmetric: &grant.SymmetricSpec{
+						PublicID: publicID,
+					},
+				}
+				head := &api.Header{Salt: salt, Data: []byte("meta")}
+
+				grt, err := cli.PutSeal(ctx, gs, head, bytes.NewBuffer(data))
+				require.NoError(t, err)
+
+				stream, err := cli.UnsealGetRange(ctx, grt, 10, 20)
+				require.NoError(t, err)
+				assert.Equal(t, head.Data, stream.GetHead().GetData())
+
+				bs, err := stream.Bytes()
+				require.NoError(t, err)
+				require.Equal(t, data[10:30], bs)
+
+				stream, err = cli.UnsealGetRange(ctx, grt, int64(len(data))+1, 0)
+				require.NoError(t, err)
+				bs, err = stream.Bytes()
+				require.NoError(t, err)
+				require.Empty(t, bs)
+
+				stream, err = cli.UnsealGetRange(ctx, grt, -1, 0)
+				if err == nil {
+					_, err = stream.Bytes()
+				}
+				require.Error(t, err)
+			})
+
 			t.Run("Streaming_EmptyGrant", func(t *testing.T) {
 				_, err := cli.UnsealGet(ctx, &grant.Grant{})
 				require.Error(t, err)
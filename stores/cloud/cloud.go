@@ -7,15 +7,18 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"gocloud.dev/gcerrors"
 
 	"github.com/monax/hoard/v8/stores"
 
+	"cloud.google.com/go/storage"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/go-kit/kit/log"
 	"github.com/monax/hoard/v8/logging"
 	"github.com/monax/hoard/v8/logging/structure"
@@ -37,7 +40,8 @@ const (
 
 const GcloudServiceKeyEnvVar = "GCLOUD_SERVICE_KEY"
 
-var _ stores.Store = (*cloudStore)(nil)
+var _ stores.StreamingStore = (*cloudStore)(nil)
+var _ stores.Lister = (*cloudStore)(nil)
 
 type cloudStore struct {
 	back     context.Context
@@ -46,6 +50,9 @@ type cloudStore struct {
 	prefix   string
 	encoding stores.AddressEncoding
 	logger   log.Logger
+
+	mtx     sync.Mutex
+	listing *listing
 }
 
 func NewStore(cloud Type, bucket, prefix, region string, addrenc stores.AddressEncoding, logger log.Logger) (*cloudStore, error) {
@@ -117,7 +124,7 @@ func NewStore(cloud Type, bucket, prefix, region string, addrenc stores.AddressE
 }
 
 func (inv *cloudStore) Put(address, data []byte) ([]byte, error) {
-	writer, err := inv.blob.NewWriter(inv.back, fmt.Sprintf("%s/%s", inv.prefix, inv.encode(address)), nil)
+	writer, err := inv.blob.NewWriter(inv.back, inv.key(address), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +147,7 @@ func (inv *cloudStore) Put(address, data []byte) ([]byte, error) {
 }
 
 func (inv *cloudStore) Delete(address []byte) error {
-	err := inv.blob.Delete(inv.back, fmt.Sprintf("%s/%s", inv.prefix, inv.encode(address)))
+	err := inv.blob.Delete(inv.back, inv.key(address))
 	if err != nil {
 		return err
 	}
@@ -153,13 +160,19 @@ func (inv *cloudStore) Delete(address []byte) error {
 }
 
 func (inv *cloudStore) Get(address []byte) ([]byte, error) {
-	reader, err := inv.blob.NewReader(inv.back, fmt.Sprintf("%s/%s", inv.prefix, inv.encode(address)), nil)
+	reader, err := inv.blob.NewReader(inv.back, inv.key(address), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var buf bytes.Buffer
-	io.Copy(&buf, reader)
+	defer reader.Close()
+
+	// Size the buffer up front so we do not reallocate as we read
+	buf := bytes.NewBuffer(make([]byte, 0, reader.Size()+bytes.MinRead))
+	_, err = buf.ReadFrom(reader)
+	if err != nil {
+		return nil, err
+	}
 
 	inv.logger.Log("method", "Get",
 		"encoded_address", inv.encode(address),
@@ -172,8 +185,34 @@ func (inv *cloudStore) Get(address []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (inv *cloudStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	reader, err := inv.blob.NewRangeReader(inv.back, inv.key(address), offset, length, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.logger.Log("method", "GetReader",
+		"encoded_address", inv.encode(address),
+		"offset", offset,
+		"length", length)
+
+	return reader, nil
+}
+
+func (inv *cloudStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	writer, err := inv.blob.NewWriter(inv.back, inv.key(address), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.logger.Log("method", "PutWriter",
+		"encoded_address", inv.encode(address))
+
+	return writer, nil
+}
+
 func (inv *cloudStore) Stat(address []byte) (*stores.StatInfo, error) {
-	reader, err := inv.blob.NewReader(inv.back, fmt.Sprintf("%s/%s", inv.prefix, inv.encode(address)), nil)
+	reader, err := inv.blob.NewReader(inv.back, inv.key(address), nil)
 	if err != nil {
 		if gcerrors.Code(err) == gcerrors.NotFound {
 			return &stores.StatInfo{
@@ -197,6 +236,103 @@ func (inv *cloudStore) Stat(address []byte) (*stores.StatInfo, error) {
 	}, nil
 }
 
+// List the addresses in the bucket under our prefix in lexical order of their keys. Only keys that may encode an
+// address beginning with prefix are listed. A page that continues the last page returned resumes its listing, otherwise
+// the services that can (S3 and GCS) are asked to start listing after the key of after and others are listed from the
+// beginning of the prefix.
+func (inv *cloudStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	namePrefix := inv.key(nil)
+	keyPrefix := namePrefix + stores.EncodedPrefix(inv.encoding, prefix)
+	var afterKey string
+	if after != nil {
+		afterKey = inv.key(after)
+	}
+	iter := inv.resumeListing(keyPrefix, afterKey)
+	if iter == nil {
+		opts := &blob.ListOptions{Prefix: keyPrefix}
+		if after != nil {
+			opts.BeforeList = startAfter(afterKey)
+		}
+		iter = inv.blob.List(opts)
+	}
+	addresses, err := stores.ListEncoded(inv.encoding, func() (string, bool, error) {
+		for {
+			obj, err := iter.Next(inv.back)
+			if err == io.EOF {
+				return "", false, nil
+			}
+			if err != nil {
+				return "", false, err
+			}
+			if !obj.IsDir {
+				return strings.TrimPrefix(obj.Key, namePrefix), true, nil
+			}
+		}
+	}, prefix, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	// A full page stops reading the listing just after its last address so the next page can carry on from there
+	if limit > 0 && len(addresses) == limit {
+		inv.saveListing(keyPrefix, inv.key(addresses[len(addresses)-1]), iter)
+	}
+
+	inv.logger.Log("method", "List",
+		"prefix", inv.encode(prefix),
+		"listed", len(addresses))
+
+	return addresses, nil
+}
+
+// A listing that stopped after the key last
+type listing struct {
+	keyPrefix string
+	last      string
+	iter      *blob.ListIterator
+}
+
+// Takes the saved listing if it lists keyPrefix and stopped at after
+func (inv *cloudStore) resumeListing(keyPrefix, after string) *blob.ListIterator {
+	inv.mtx.Lock()
+	defer inv.mtx.Unlock()
+	saved := inv.listing
+	if saved == nil || after == "" || saved.keyPrefix != keyPrefix || saved.last != after {
+		return nil
+	}
+	inv.listing = nil
+	return saved.iter
+}
+
+func (inv *cloudStore) saveListing(keyPrefix, last string, iter *blob.ListIterator) {
+	inv.mtx.Lock()
+	defer inv.mtx.Unlock()
+	inv.listing = &listing{keyPrefix: keyPrefix, last: last, iter: iter}
+}
+
+// Asks S3 and GCS to begin listing after key rather than from the start of the prefix (keys up to and including key
+// are skipped by name regardless)
+func startAfter(key string) func(asFunc func(interface{}) bool) error {
+	return func(asFunc func(interface{}) bool) error {
+		var listV2 *s3.ListObjectsV2Input
+		var listV1 *s3.ListObjectsInput
+		var query *storage.Query
+		switch {
+		case asFunc(&listV2):
+			// Only the first request, later pages follow the continuation token
+			if listV2.ContinuationToken == nil {
+				listV2.StartAfter = aws.String(key)
+			}
+		case asFunc(&listV1):
+			if listV1.Marker == nil {
+				listV1.Marker = aws.String(key)
+			}
+		case asFunc(&query):
+			query.StartOffset = key
+		}
+		return nil
+	}
+}
+
 func (inv *cloudStore) Location(address []byte) string {
 	return fmt.Sprintf("gs://%s/%s", inv.bucket,
 		inv.encode(address))
@@ -206,6 +342,10 @@ func (inv *cloudStore) Name() string {
 	return fmt.Sprintf("gcpStore[bucket=%s]", inv.bucket)
 }
 
+func (inv *cloudStore) key(address []byte) string {
+	return fmt.Sprintf("%s/%s", inv.prefix, inv.encode(address))
+}
+
 func (inv *cloudStore) encode(address []byte) string {
 	return inv.encoding.EncodeToString(address)
 }
@@ -0,0 +1,89 @@
+package cloud
+
+import (
+	"context"
+	"encoding/base32"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/stores"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+)
+
+func TestStoreMemBlob(t *testing.T) {
+	store := &cloudStore{
+		back:     context.Background(),
+		blob:     memblob.OpenBucket(nil),
+		bucket:   "memblob",
+		prefix:   "test-store",
+		encoding: base32.StdEncoding,
+		logger:   log.NewNopLogger(),
+	}
+	stores.RunTests(t, store)
+}
+
+func TestListResumes(t *testing.T) {
+	store := &cloudStore{
+		back:     context.Background(),
+		blob:     memblob.OpenBucket(nil),
+		bucket:   "memblob",
+		prefix:   "test-store",
+		encoding: base32.StdEncoding,
+		logger:   log.NewNopLogger(),
+	}
+	for _, address := range []string{"a-1", "a-2", "a-3", "b-1"} {
+		_, err := store.Put([]byte(address), []byte("data"))
+		require.NoError(t, err)
+	}
+
+	page, err := store.List([]byte("a-"), nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.NotNil(t, store.listing)
+	iter := store.listing.iter
+	assert.Equal(t, "test-store/"+stores.EncodedPrefix(store.encoding, []byte("a-")), store.listing.keyPrefix)
+
+	// Continuing from the last address takes up the saved listing
+	rest, err := store.List([]byte("a-"), page[1], 2)
+	require.NoError(t, err)
+	assert.Nil(t, store.listing)
+	assert.ElementsMatch(t, [][]byte{[]byte("a-1"), []byte("a-2"), []byte("a-3")}, append(page, rest...))
+
+	// Any other after starts a new listing
+	saved := &listing{keyPrefix: store.key(nil), last: store.key([]byte("a-1")), iter: iter}
+	store.listing = saved
+	rest, err = store.List(nil, []byte("a-2"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, saved, store.listing)
+	assert.ElementsMatch(t, [][]byte{[]byte("a-3"), []byte("b-1")}, rest)
+}
+
+func TestStartAfter(t *testing.T) {
+	listV2 := &s3.ListObjectsV2Input{}
+	require.NoError(t, startAfter("prefix/key")(func(i interface{}) bool {
+		p, ok := i.(**s3.ListObjectsV2Input)
+		if ok {
+			*p = listV2
+		}
+		return ok
+	}))
+	assert.Equal(t, "prefix/key", aws.StringValue(listV2.StartAfter))
+
+	query := &storage.Query{}
+	require.NoError(t, startAfter("prefix/key")(func(i interface{}) bool {
+		p, ok := i.(**storage.Query)
+		if ok {
+			*p = query
+		}
+		return ok
+	}))
+	assert.Equal(t, "prefix/key", query.StartOffset)
+
+	// Drivers that cannot start after a key are listed from the beginning
+	assert.NoError(t, startAfter("prefix/key")(func(i interface{}) bool { return false }))
+}
@@ -2,13 +2,16 @@ package stores
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
 	"path"
+	"strings"
 )
 
-var _ Store = (*fileSystemStore)(nil)
+var _ StreamingStore = (*fileSystemStore)(nil)
+var _ Lister = (*fileSystemStore)(nil)
 
 type fileSystemStore struct {
 	rootDirectory string
@@ -49,10 +52,73 @@ func (inv *fileSystemStore) Put(address []byte, data []byte) ([]byte, error) {
 	return address, ioutil.WriteFile(inv.Path(address), data, 0644)
 }
 
+func (inv *fileSystemStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(inv.Path(address))
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	length, err = rangeLength(fileInfo.Size(), offset, length)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &sectionReadCloser{
+		Reader: io.NewSectionReader(file, offset, length),
+		Closer: file,
+	}, nil
+}
+
+// Writes to a temporary file that is renamed into place on Close so that partial writes are never visible
+func (inv *fileSystemStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	file, err := ioutil.TempFile(inv.rootDirectory, ".put-")
+	if err != nil {
+		return nil, err
+	}
+	return &writeCloseHook{
+		WriteCloser: file,
+		onClose: func(err error) error {
+			if err == nil {
+				err = os.Chmod(file.Name(), 0644)
+			}
+			if err == nil {
+				err = os.Rename(file.Name(), inv.Path(address))
+			}
+			if err != nil {
+				os.Remove(file.Name())
+			}
+			return err
+		},
+	}, nil
+}
+
 func (inv *fileSystemStore) Delete(address []byte) error {
 	return os.Remove(inv.Path(address))
 }
 
+// List decodes the name of each file in the root directory as an address in lexical order of file name, skipping the
+// hidden temporary files created by PutWriter
+func (inv *fileSystemStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	fileInfos, err := ioutil.ReadDir(inv.rootDirectory)
+	if err != nil {
+		return nil, err
+	}
+	return ListEncoded(inv.encoding, func() (string, bool, error) {
+		for len(fileInfos) > 0 {
+			fileInfo := fileInfos[0]
+			fileInfos = fileInfos[1:]
+			if !fileInfo.IsDir() && !strings.HasPrefix(fileInfo.Name(), ".") {
+				return fileInfo.Name(), true, nil
+			}
+		}
+		return "", false, nil
+	}, prefix, after, limit)
+}
+
 func (inv *fileSystemStore) Location(address []byte) string {
 	filePath := inv.Path(address)
 	uri, err := url.Parse(filePath)
@@ -70,3 +136,8 @@ func (inv *fileSystemStore) Path(address []byte) string {
 func (inv *fileSystemStore) Name() string {
 	return fmt.Sprintf("fileSystemStore[root=%s]", inv.rootDirectory)
 }
+
+type sectionReadCloser struct {
+	io.Reader
+	io.Closer
+}
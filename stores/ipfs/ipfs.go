@@ -13,10 +13,12 @@ import (
 )
 
 var _ stores.Store = (*ipfsStore)(nil)
+var _ stores.Lister = (*ipfsStore)(nil)
 
 type ipfsStore struct {
 	host     string
 	encoding stores.AddressEncoding
+	listing  stores.SortedListing
 }
 
 func NewStore(host string, encoding stores.AddressEncoding) (*ipfsStore, error) {
@@ -107,6 +109,37 @@ func (inv *ipfsStore) Stat(address []byte) (*stores.StatInfo, error) {
 	}, nil
 }
 
+// List the addresses of the blobs pinned by the IPFS node (blobs are pinned recursively when they are added). IPFS has
+// no way to tell which pins were made by Hoard so every recursive pin is listed, and the node must be dedicated to
+// Hoard if its addresses are to be garbage collected, scrubbed, or migrated.
+func (inv *ipfsStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return inv.listing.List(prefix, after, limit, func() ([][]byte, error) {
+		url := fmt.Sprintf("%s/pin/ls?type=recursive", inv.host)
+		resp, err := http.Get(url)
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("could not list IPFS pins: %s", resp.Status)
+		}
+		pins := new(struct {
+			Keys map[string]interface{}
+		})
+		err = json.NewDecoder(resp.Body).Decode(pins)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses := make([][]byte, 0, len(pins.Keys))
+		for key := range pins.Keys {
+			addresses = append(addresses, []byte(key))
+		}
+		return addresses, nil
+	})
+}
+
 func (inv *ipfsStore) Location(address []byte) string {
 	return string(address)
 }
@@ -0,0 +1,183 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// The number of addresses fetched per page by ListAll
+const DefaultListLimit = 1000
+
+// Lister is optionally implemented by stores that can enumerate the addresses they hold
+type Lister interface {
+	// List returns up to limit addresses beginning with prefix in an order fixed by the store. Listing starts after
+	// the address after, so passing the last address of the previous page continues the listing, and a nil after
+	// starts from the beginning. Fewer than limit addresses are returned only once the listing is complete. A limit
+	// of 0 or less returns all remaining addresses.
+	List(prefix, after []byte, limit int) ([][]byte, error)
+}
+
+func ErrorListingNotSupported(store interface{}) error {
+	return fmt.Errorf("store %v does not support listing addresses", storeName(store))
+}
+
+// List a page of addresses in store if it implements Lister, otherwise returns an error
+func List(store interface{}, prefix, after []byte, limit int) ([][]byte, error) {
+	lister, ok := store.(Lister)
+	if !ok {
+		return nil, ErrorListingNotSupported(store)
+	}
+	return lister.List(prefix, after, limit)
+}
+
+// ListAll calls fn with each address beginning with prefix in store listed after the address after, fetching
+// DefaultListLimit addresses at a time
+func ListAll(store interface{}, prefix, after []byte, fn func(address []byte) error) error {
+	for {
+		addresses, err := List(store, prefix, after, DefaultListLimit)
+		if err != nil {
+			return err
+		}
+		for _, address := range addresses {
+			err = fn(address)
+			if err != nil {
+				return err
+			}
+		}
+		if len(addresses) < DefaultListLimit {
+			return nil
+		}
+		after = addresses[len(addresses)-1]
+	}
+}
+
+// ListSorted pages through addresses after sorting them bytewise, for stores that can cheaply hold all their
+// addresses in memory
+func ListSorted(addresses [][]byte, prefix, after []byte, limit int) [][]byte {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i], addresses[j]) < 0
+	})
+	start := 0
+	if after != nil {
+		start = sort.Search(len(addresses), func(i int) bool {
+			return bytes.Compare(addresses[i], after) > 0
+		})
+	}
+	var page [][]byte
+	for _, address := range addresses[start:] {
+		if limit > 0 && len(page) >= limit {
+			break
+		}
+		if bytes.HasPrefix(address, prefix) {
+			page = append(page, address)
+		}
+	}
+	return page
+}
+
+// SortedListing pages through addresses sorted bytewise for stores that can only list all of their addresses at once,
+// holding the rest of the listing between pages so that a page continuing from the last page returned does not list
+// the store again. The zero value is ready to use.
+type SortedListing struct {
+	mtx   sync.Mutex
+	saved *sortedListing
+}
+
+// The sorted addresses of a listing that remain after the address last
+type sortedListing struct {
+	prefix    []byte
+	last      []byte
+	remaining [][]byte
+}
+
+// List a page of the addresses returned by listAll, which is only called if the page does not continue the last one
+func (sl *SortedListing) List(prefix, after []byte, limit int, listAll func() ([][]byte, error)) ([][]byte, error) {
+	remaining, ok := sl.resume(prefix, after)
+	if !ok {
+		addresses, err := listAll()
+		if err != nil {
+			return nil, err
+		}
+		remaining = ListSorted(addresses, prefix, after, 0)
+	}
+	if limit <= 0 || len(remaining) < limit {
+		return remaining, nil
+	}
+	page := remaining[:limit]
+	sl.save(prefix, page[len(page)-1], remaining[limit:])
+	return page, nil
+}
+
+// Takes the saved listing if it lists prefix and stopped at after
+func (sl *SortedListing) resume(prefix, after []byte) ([][]byte, bool) {
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+	saved := sl.saved
+	if saved == nil || after == nil || !bytes.Equal(saved.prefix, prefix) || !bytes.Equal(saved.last, after) {
+		return nil, false
+	}
+	sl.saved = nil
+	return saved.remaining, true
+}
+
+func (sl *SortedListing) save(prefix, last []byte, remaining [][]byte) {
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+	sl.saved = &sortedListing{prefix: prefix, last: last, remaining: remaining}
+}
+
+// ListEncoded pages through the addresses encoded in a sequence of names provided in lexical order by next (which
+// should return false once exhausted), for stores that name their data by encoded address
+func ListEncoded(encoding AddressEncoding, next func() (string, bool, error), prefix, after []byte,
+	limit int) ([][]byte, error) {
+	var afterName string
+	if after != nil {
+		afterName = encoding.EncodeToString(after)
+	}
+	var page [][]byte
+	for limit <= 0 || len(page) < limit {
+		name, ok, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		if after != nil && name <= afterName {
+			continue
+		}
+		address, err := encoding.DecodeString(name)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode name '%s' as an address: %w", name, err)
+		}
+		if bytes.HasPrefix(address, prefix) {
+			page = append(page, address)
+		}
+	}
+	return page, nil
+}
+
+// EncodedPrefix returns the longest string that begins the encoding of every address beginning with prefix, so stores
+// naming their data by encoded address can list only the names that may match
+func EncodedPrefix(encoding AddressEncoding, prefix []byte) string {
+	if len(prefix) == 0 {
+		return ""
+	}
+	// Trailing characters of the encoded prefix also depend on the bytes that follow it
+	low := encoding.EncodeToString(append(append([]byte{}, prefix...), bytes.Repeat([]byte{0x00}, 8)...))
+	high := encoding.EncodeToString(append(append([]byte{}, prefix...), bytes.Repeat([]byte{0xff}, 8)...))
+	i := 0
+	for i < len(low) && i < len(high) && low[i] == high[i] {
+		i++
+	}
+	return low[:i]
+}
+
+func storeName(store interface{}) string {
+	if ns, ok := store.(NamedStore); ok {
+		return ns.Name()
+	}
+	return fmt.Sprintf("%T", store)
+}
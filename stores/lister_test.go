@@ -0,0 +1,32 @@
+package stores
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodedPrefix(t *testing.T) {
+	encodings := []AddressEncoding{
+		base64.URLEncoding,
+		base32.StdEncoding,
+		NewAddressEncoding(hex.EncodeToString, hex.DecodeString),
+	}
+	prefix := []byte("pre")
+	addresses := [][]byte{[]byte("pre"), []byte("pre\x00"), []byte("prefix"), []byte("pre\xff\xff\xff\xff\xff")}
+	for _, encoding := range encodings {
+		encodedPrefix := EncodedPrefix(encoding, prefix)
+		assert.NotEmpty(t, encodedPrefix)
+		for _, address := range addresses {
+			assert.True(t, strings.HasPrefix(encoding.EncodeToString(address), encodedPrefix),
+				"%T encoding of %q should begin with %q", encoding, address, encodedPrefix)
+		}
+		assert.Empty(t, EncodedPrefix(encoding, nil))
+	}
+	assert.Equal(t, hex.EncodeToString(prefix), EncodedPrefix(NewAddressEncoding(hex.EncodeToString, hex.DecodeString),
+		prefix))
+}
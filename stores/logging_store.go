@@ -3,6 +3,7 @@ package stores
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 
 	"github.com/go-kit/kit/log"
 	"github.com/monax/hoard/v8/logging"
@@ -24,6 +25,7 @@ func NewLoggingStore(store NamedStore, logger log.Logger) *loggingStore {
 }
 
 var _ NamedStore = (*loggingStore)(nil)
+var _ StreamingStore = (*loggingStore)(nil)
 
 func (inv *loggingStore) Put(address []byte, data []byte) ([]byte, error) {
 	address, err := inv.store.Put(address, data)
@@ -49,6 +51,33 @@ func (inv *loggingStore) Stat(address []byte) (*StatInfo, error) {
 		formatAddress(address)), err)
 }
 
+func (inv *loggingStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	reader, err := GetReader(inv.store, address, offset, length)
+	return reader, logErrorOrSuccess(log.With(inv.logger, "method", "GetReader", "address",
+		formatAddress(address), "offset", offset, "length", length), err)
+}
+
+func (inv *loggingStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	writer, err := putWriter(inv.store, address)
+	if err != nil {
+		return nil, logErrorOrSuccess(log.With(inv.logger, "method", "PutWriter", "address",
+			formatAddress(address)), err)
+	}
+	return &writeCloseHook{
+		WriteCloser: writer,
+		onClose: func(err error) error {
+			return logErrorOrSuccess(log.With(inv.logger, "method", "PutWriter", "address",
+				formatAddress(address)), err)
+		},
+	}, nil
+}
+
+func (inv *loggingStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	addresses, err := List(inv.store, prefix, after, limit)
+	return addresses, logErrorOrSuccess(log.With(inv.logger, "method", "List", "prefix", formatAddress(prefix),
+		"after", formatAddress(after), "limit", limit), err)
+}
+
 func (inv *loggingStore) Location(address []byte) string {
 	inv.logger.Log("method", "Location", "address", formatAddress(address))
 	return inv.store.Location(address)
@@ -1,11 +1,15 @@
 package stores
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"sync"
 )
 
-var _ Store = (*memoryStore)(nil)
+var _ StreamingStore = (*memoryStore)(nil)
+var _ Lister = (*memoryStore)(nil)
 
 type memoryStore struct {
 	memory map[string][]byte
@@ -49,6 +53,37 @@ func (inv *memoryStore) Stat(address []byte) (*StatInfo, error) {
 	}, nil
 }
 
+func (inv *memoryStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	data, err := inv.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	data, err = sliceRange(data, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (inv *memoryStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	return &bufferWriter{
+		onClose: func(data []byte) error {
+			_, err := inv.Put(address, data)
+			return err
+		},
+	}, nil
+}
+
+func (inv *memoryStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	inv.mtx.RLock()
+	addresses := make([][]byte, 0, len(inv.memory))
+	for address := range inv.memory {
+		addresses = append(addresses, []byte(address))
+	}
+	inv.mtx.RUnlock()
+	return ListSorted(addresses, prefix, after, limit), nil
+}
+
 func (inv *memoryStore) Location(address []byte) string {
 	return fmt.Sprintf("memfs://%x", address)
 }
@@ -0,0 +1,203 @@
+package stores
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// StoreMetrics are the Prometheus metrics recorded by stores decorated with NewMetricsStore, register them to expose
+// them
+type StoreMetrics struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	bytes      *prometheus.CounterVec
+}
+
+var _ prometheus.Collector = (*StoreMetrics)(nil)
+
+func NewStoreMetrics() *StoreMetrics {
+	return &StoreMetrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hoard",
+			Subsystem: "store",
+			Name:      "operations_total",
+			Help:      "Number of store operations by store, operation, and result",
+		}, []string{"store", "operation", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "hoard",
+			Subsystem: "store",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of store operations by store and operation",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 10),
+		}, []string{"store", "operation"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "hoard",
+			Subsystem: "store",
+			Name:      "bytes_total",
+			Help:      "Number of encrypted bytes read from or written to each store",
+		}, []string{"store", "direction"}),
+	}
+}
+
+func (sm *StoreMetrics) Describe(ch chan<- *prometheus.Desc) {
+	sm.operations.Describe(ch)
+	sm.duration.Describe(ch)
+	sm.bytes.Describe(ch)
+}
+
+func (sm *StoreMetrics) Collect(ch chan<- prometheus.Metric) {
+	sm.operations.Collect(ch)
+	sm.duration.Collect(ch)
+	sm.bytes.Collect(ch)
+}
+
+func (sm *StoreMetrics) observe(store, operation string, start time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	sm.operations.WithLabelValues(store, operation, result).Inc()
+	sm.duration.WithLabelValues(store, operation).Observe(time.Since(start).Seconds())
+}
+
+type metricsStore struct {
+	store   NamedStore
+	label   string
+	metrics *StoreMetrics
+}
+
+// Decorates a Store so that its operations are recorded in metrics under the label given for the store
+func NewMetricsStore(store NamedStore, label string, metrics *StoreMetrics) *metricsStore {
+	return &metricsStore{
+		store:   store,
+		label:   label,
+		metrics: metrics,
+	}
+}
+
+var _ NamedStore = (*metricsStore)(nil)
+var _ StreamingStore = (*metricsStore)(nil)
+var _ Lister = (*metricsStore)(nil)
+
+func (inv *metricsStore) Put(address []byte, data []byte) ([]byte, error) {
+	start := time.Now()
+	address, err := inv.store.Put(address, data)
+	inv.metrics.observe(inv.label, "Put", start, err)
+	if err == nil {
+		inv.metrics.bytes.WithLabelValues(inv.label, "written").Add(float64(len(data)))
+	}
+	return address, err
+}
+
+func (inv *metricsStore) Delete(address []byte) error {
+	start := time.Now()
+	err := inv.store.Delete(address)
+	inv.metrics.observe(inv.label, "Delete", start, err)
+	return err
+}
+
+func (inv *metricsStore) Get(address []byte) ([]byte, error) {
+	start := time.Now()
+	data, err := inv.store.Get(address)
+	inv.metrics.observe(inv.label, "Get", start, err)
+	if err == nil {
+		inv.metrics.bytes.WithLabelValues(inv.label, "read").Add(float64(len(data)))
+	}
+	return data, err
+}
+
+func (inv *metricsStore) Stat(address []byte) (*StatInfo, error) {
+	start := time.Now()
+	statInfo, err := inv.store.Stat(address)
+	inv.metrics.observe(inv.label, "Stat", start, err)
+	return statInfo, err
+}
+
+// The duration of streaming operations is measured until the reader or writer is closed
+func (inv *metricsStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	start := time.Now()
+	reader, err := GetReader(inv.store, address, offset, length)
+	if err != nil {
+		inv.metrics.observe(inv.label, "GetReader", start, err)
+		return nil, err
+	}
+	cr := &countingReader{Reader: reader}
+	return &closeHook{
+		ReadCloser: struct {
+			io.Reader
+			io.Closer
+		}{cr, reader},
+		onClose: func() {
+			inv.metrics.observe(inv.label, "GetReader", start, nil)
+			inv.metrics.bytes.WithLabelValues(inv.label, "read").Add(float64(cr.count))
+		},
+	}, nil
+}
+
+func (inv *metricsStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	start := time.Now()
+	writer, err := putWriter(inv.store, address)
+	if err != nil {
+		inv.metrics.observe(inv.label, "PutWriter", start, err)
+		return nil, err
+	}
+	cw := &countingWriter{Writer: writer}
+	return &writeCloseHook{
+		WriteCloser: struct {
+			io.Writer
+			io.Closer
+		}{cw, writer},
+		onClose: func(err error) error {
+			inv.metrics.observe(inv.label, "PutWriter", start, err)
+			if err == nil {
+				inv.metrics.bytes.WithLabelValues(inv.label, "written").Add(float64(cw.count))
+			}
+			return err
+		},
+	}, nil
+}
+
+func (inv *metricsStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	start := time.Now()
+	addresses, err := List(inv.store, prefix, after, limit)
+	inv.metrics.observe(inv.label, "List", start, err)
+	return addresses, err
+}
+
+func (inv *metricsStore) Location(address []byte) string {
+	return inv.store.Location(address)
+}
+
+func (inv *metricsStore) Name() string {
+	return fmt.Sprintf("metricsStore(%s)", inv.store.Name())
+}
+
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.Reader.Read(p)
+	cr.count += int64(n)
+	return n, err
+}
+
+type countingWriter struct {
+	io.Writer
+	count int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.Writer.Write(p)
+	cw.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,45 @@
+package stores
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsStore(t *testing.T) {
+	RunTests(t, NewMetricsStore(NewMemoryStore(), "memory", NewStoreMetrics()))
+
+	metrics := NewStoreMetrics()
+	store := NewMetricsStore(NewMemoryStore(), "memory", metrics)
+	_, err := store.Put(bs("address"), bs("data"))
+	require.NoError(t, err)
+	_, err = store.Get(bs("address"))
+	require.NoError(t, err)
+	_, err = store.Get(bs("missing"))
+	require.Error(t, err)
+
+	reader, err := store.GetReader(bs("address"), 1, -1)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	writer, err := store.PutWriter(bs("streamed"))
+	require.NoError(t, err)
+	_, err = writer.Write(bs("streamed data"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.operations.WithLabelValues("memory", "Put", resultSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.operations.WithLabelValues("memory", "Get", resultSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.operations.WithLabelValues("memory", "Get", resultError)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.operations.WithLabelValues("memory", "GetReader", resultSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.operations.WithLabelValues("memory", "PutWriter", resultSuccess)))
+	assert.Equal(t, 7.0, testutil.ToFloat64(metrics.bytes.WithLabelValues("memory", "read")))
+	assert.Equal(t, 17.0, testutil.ToFloat64(metrics.bytes.WithLabelValues("memory", "written")))
+	// Put, Get, GetReader, and PutWriter
+	assert.Equal(t, 4, testutil.CollectAndCount(metrics.duration))
+}
@@ -0,0 +1,126 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The number of failures described by the error returned from Migrate
+const maxReportedFailures = 10
+
+// Counts of the blobs processed so far by a migration
+type MigrationProgress struct {
+	// Blobs copied to the destination store
+	Copied int
+	// Blobs already present (with the same size) in the destination store
+	Skipped int
+	// Blobs that could not be copied
+	Failed int
+}
+
+func (mp MigrationProgress) Processed() int {
+	return mp.Copied + mp.Skipped + mp.Failed
+}
+
+func (mp MigrationProgress) String() string {
+	return fmt.Sprintf("copied %d, skipped %d, failed %d", mp.Copied, mp.Skipped, mp.Failed)
+}
+
+// Migrate copies every blob held by from (which must support listing) to to using at most concurrency concurrent
+// copies. Each blob is checked against its address using addresser before it is copied. Blobs already present in to
+// with the same size as in from are skipped so an interrupted migration can be resumed by running it again. If
+// progress is non-nil it is called (never concurrently) after each blob is processed. A failure to copy a blob does
+// not stop the migration but an error describing the failures is returned at the end.
+func Migrate(from, to Store, addresser func(data []byte) []byte, concurrency int,
+	progress func(MigrationProgress)) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if progress == nil {
+		progress = func(MigrationProgress) {}
+	}
+
+	var mtx sync.Mutex
+	var counts MigrationProgress
+	var failures []string
+	record := func(address []byte, copied bool, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		switch {
+		case err != nil:
+			counts.Failed++
+			if len(failures) < maxReportedFailures {
+				failures = append(failures, fmt.Sprintf("%s: %v", formatAddress(address), err))
+			}
+		case copied:
+			counts.Copied++
+		default:
+			counts.Skipped++
+		}
+		progress(counts)
+	}
+
+	addresses := make(chan []byte)
+	wg := new(sync.WaitGroup)
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for address := range addresses {
+				copied, err := migrateBlob(from, to, addresser, address)
+				record(address, copied, err)
+			}
+		}()
+	}
+
+	err := ListAll(from, nil, nil, func(address []byte) error {
+		addresses <- address
+		return nil
+	})
+	close(addresses)
+	wg.Wait()
+	if err != nil {
+		return fmt.Errorf("could not list blobs to migrate: %w", err)
+	}
+	if counts.Failed > 0 {
+		return fmt.Errorf("could not migrate %d of %d blobs: %s", counts.Failed, counts.Processed(),
+			strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Copy a single blob returning whether it needed to be copied. A blob already in the destination whose size differs
+// from the source (such as one truncated by an interrupted write) is copied again.
+func migrateBlob(from, to Store, addresser func(data []byte) []byte, address []byte) (bool, error) {
+	statInfo, err := to.Stat(address)
+	if err != nil {
+		return false, err
+	}
+	if statInfo.Exists {
+		fromInfo, err := from.Stat(address)
+		if err != nil {
+			return false, err
+		}
+		if fromInfo.Size_ == statInfo.Size_ {
+			return false, nil
+		}
+	}
+	data, err := from.Get(address)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(addresser(data), address) {
+		return false, fmt.Errorf("data does not match its address")
+	}
+	// Stores that can write atomically do so through PutFrom so an interrupted copy does not leave a partial blob
+	stored, err := PutFrom(to, address, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(stored, address) {
+		return false, fmt.Errorf("destination store put data at a different address %s", formatAddress(stored))
+	}
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package stores
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "migrate_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	addresser := MakeAddresser(sha256.New)
+	from := NewMemoryStore()
+	to, err := NewFileSystemStore(tempDir, base32.StdEncoding)
+	require.NoError(t, err)
+
+	cas := NewContentAddressedStore(addresser, from)
+	var addresses [][]byte
+	for i := 0; i < 50; i++ {
+		address, err := cas.Put(bs("data", strconv.Itoa(i)))
+		require.NoError(t, err)
+		addresses = append(addresses, address)
+	}
+	// Simulate an interrupted migration
+	for _, address := range addresses[:10] {
+		data, err := from.Get(address)
+		require.NoError(t, err)
+		_, err = to.Put(address, data)
+		require.NoError(t, err)
+	}
+	// A blob truncated by the interruption should be copied again
+	data, err := from.Get(addresses[9])
+	require.NoError(t, err)
+	_, err = to.Put(addresses[9], data[:len(data)/2])
+	require.NoError(t, err)
+	// Data that does not match its address should not be copied
+	_, err = from.Put(bs("corrupt"), bs("data"))
+	require.NoError(t, err)
+
+	var last MigrationProgress
+	err = Migrate(from, to, addresser, 4, func(progress MigrationProgress) {
+		assert.Equal(t, last.Processed()+1, progress.Processed())
+		last = progress
+	})
+	assert.Error(t, err)
+	assert.Equal(t, MigrationProgress{Copied: 41, Skipped: 9, Failed: 1}, last)
+
+	for i, address := range addresses {
+		data, err := to.Get(address)
+		require.NoError(t, err)
+		assert.Equal(t, bs("data", strconv.Itoa(i)), data)
+	}
+	statInfo, err := to.Stat(bs("corrupt"))
+	require.NoError(t, err)
+	assert.False(t, statInfo.Exists)
+
+	require.NoError(t, from.Delete(bs("corrupt")))
+	last = MigrationProgress{}
+	err = Migrate(from, to, addresser, 4, func(progress MigrationProgress) {
+		last = progress
+	})
+	require.NoError(t, err)
+	assert.Equal(t, MigrationProgress{Skipped: 50}, last)
+}
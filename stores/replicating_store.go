@@ -0,0 +1,200 @@
+package stores
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/monax/hoard/v8/logging"
+)
+
+type replicatingStore struct {
+	replicas    []NamedStore
+	writeQuorum int
+	logger      log.Logger
+	listing     SortedListing
+	// Tracks read-repairs running in the background
+	repairs sync.WaitGroup
+}
+
+// Decorates a number of replica stores so that writes are fanned out to all replicas and succeed if at least
+// writeQuorum replicas succeed (a writeQuorum of 0 requires every replica to succeed). Reads are served from the
+// first replica (in order) that answers, with any other replicas missing the data being repaired from it in the
+// background.
+func NewReplicatingStore(writeQuorum int, logger log.Logger, replicas ...NamedStore) (*replicatingStore, error) {
+	if len(replicas) == 0 {
+		return nil, fmt.Errorf("replicatingStore needs at least one replica")
+	}
+	if writeQuorum == 0 {
+		writeQuorum = len(replicas)
+	}
+	if writeQuorum < 0 || writeQuorum > len(replicas) {
+		return nil, fmt.Errorf("replicatingStore write quorum must be between 1 and the number of replicas (%d) "+
+			"but was %d", len(replicas), writeQuorum)
+	}
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	inv := &replicatingStore{
+		replicas:    replicas,
+		writeQuorum: writeQuorum,
+	}
+	inv.logger = log.With(logging.InfoLogger(log.With(logger, "module", "storage")), "store", inv.Name())
+	return inv, nil
+}
+
+var _ NamedStore = (*replicatingStore)(nil)
+var _ Lister = (*replicatingStore)(nil)
+
+func (inv *replicatingStore) Put(address []byte, data []byte) ([]byte, error) {
+	addresses := make([][]byte, len(inv.replicas))
+	err := inv.fanOut("Put", func(i int, replica NamedStore) (err error) {
+		addresses[i], err = replica.Put(address, data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addresses {
+		if addr != nil {
+			return addr, nil
+		}
+	}
+	return address, nil
+}
+
+func (inv *replicatingStore) Delete(address []byte) error {
+	return inv.fanOut("Delete", func(i int, replica NamedStore) error {
+		return replica.Delete(address)
+	})
+}
+
+func (inv *replicatingStore) Get(address []byte) ([]byte, error) {
+	var errs []string
+	for i, replica := range inv.replicas {
+		data, err := replica.Get(address)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", replica.Name(), err))
+			continue
+		}
+		others := make([]NamedStore, 0, len(inv.replicas)-1)
+		others = append(others, inv.replicas[:i]...)
+		others = append(others, inv.replicas[i+1:]...)
+		inv.repairs.Add(1)
+		go func() {
+			defer inv.repairs.Done()
+			inv.repair(address, data, others)
+		}()
+		return data, nil
+	}
+	return nil, fmt.Errorf("replicatingStore could not get address %s from any replica: %s",
+		formatAddress(address), strings.Join(errs, "; "))
+}
+
+func (inv *replicatingStore) Stat(address []byte) (*StatInfo, error) {
+	var missing *StatInfo
+	var errs []string
+	for _, replica := range inv.replicas {
+		statInfo, err := replica.Stat(address)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", replica.Name(), err))
+			continue
+		}
+		if statInfo.Exists {
+			return statInfo, nil
+		}
+		if missing == nil {
+			missing = statInfo
+		}
+	}
+	if missing != nil {
+		return missing, nil
+	}
+	return nil, fmt.Errorf("replicatingStore could not stat address %s on any replica: %s",
+		formatAddress(address), strings.Join(errs, "; "))
+}
+
+// List the union of the addresses held by each replica. Since the replicas may order their addresses differently the
+// union is collected and sorted in full once per listing.
+func (inv *replicatingStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return inv.listing.List(prefix, after, limit, func() ([][]byte, error) {
+		seen := make(map[string]struct{})
+		var addresses [][]byte
+		for _, replica := range inv.replicas {
+			err := ListAll(replica, prefix, nil, func(address []byte) error {
+				if _, ok := seen[string(address)]; !ok {
+					seen[string(address)] = struct{}{}
+					addresses = append(addresses, address)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return addresses, nil
+	})
+}
+
+func (inv *replicatingStore) Location(address []byte) string {
+	return inv.replicas[0].Location(address)
+}
+
+func (inv *replicatingStore) Name() string {
+	names := make([]string, len(inv.replicas))
+	for i, replica := range inv.replicas {
+		names[i] = replica.Name()
+	}
+	return fmt.Sprintf("replicatingStore[quorum=%d](%s)", inv.writeQuorum, strings.Join(names, ", "))
+}
+
+// Run op against every replica concurrently returning an error if fewer than writeQuorum succeed
+func (inv *replicatingStore) fanOut(method string, op func(i int, replica NamedStore) error) error {
+	errs := make([]error, len(inv.replicas))
+	wg := new(sync.WaitGroup)
+	wg.Add(len(inv.replicas))
+	for i, replica := range inv.replicas {
+		go func(i int, replica NamedStore) {
+			errs[i] = op(i, replica)
+			wg.Done()
+		}(i, replica)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			logging.Err(log.With(inv.logger, "method", method, "replica", inv.replicas[i].Name()), err)
+			failures = append(failures, fmt.Sprintf("%s: %v", inv.replicas[i].Name(), err))
+			continue
+		}
+		succeeded++
+	}
+	if succeeded < inv.writeQuorum {
+		return fmt.Errorf("replicatingStore %s succeeded on %d of %d replicas but write quorum is %d: %s",
+			method, succeeded, len(inv.replicas), inv.writeQuorum, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Best-effort write of data to those replicas that report not having it
+func (inv *replicatingStore) repair(address, data []byte, replicas []NamedStore) {
+	for _, replica := range replicas {
+		logger := log.With(inv.logger, "method", "repair", "replica", replica.Name(),
+			"address", formatAddress(address))
+		statInfo, err := replica.Stat(address)
+		if err != nil {
+			logging.Err(logger, err)
+			continue
+		}
+		if statInfo.Exists {
+			continue
+		}
+		_, err = replica.Put(address, data)
+		if logging.Err(logger, err) == nil {
+			logging.Msg(logger, "Repaired missing replica")
+		}
+	}
+}
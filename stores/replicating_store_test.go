@@ -0,0 +1,117 @@
+package stores
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicatingStore(t *testing.T) {
+	rs, err := NewReplicatingStore(0, nil, NewMemoryStore(), NewMemoryStore(), NewMemoryStore())
+	require.NoError(t, err)
+	RunTests(t, rs)
+}
+
+func TestReplicatingStoreQuorum(t *testing.T) {
+	healthy := NewMemoryStore()
+	rs, err := NewReplicatingStore(2, nil, healthy, NewMemoryStore(), brokenStore{})
+	require.NoError(t, err)
+
+	address, err := rs.Put(bs("address"), bs("data"))
+	require.NoError(t, err)
+	data, err := healthy.Get(address)
+	require.NoError(t, err)
+	assert.Equal(t, bs("data"), data)
+
+	rs, err = NewReplicatingStore(2, nil, healthy, brokenStore{}, brokenStore{})
+	require.NoError(t, err)
+	_, err = rs.Put(bs("address"), bs("data"))
+	assert.Error(t, err)
+
+	_, err = NewReplicatingStore(4, nil, healthy, brokenStore{}, brokenStore{})
+	assert.Error(t, err)
+}
+
+func TestReplicatingStoreReadRepair(t *testing.T) {
+	first := NewMemoryStore()
+	second := NewMemoryStore()
+	third := NewMemoryStore()
+	rs, err := NewReplicatingStore(1, nil, brokenStore{}, first, second, third)
+	require.NoError(t, err)
+
+	_, err = second.Put(bs("address"), bs("data"))
+	require.NoError(t, err)
+
+	statInfo, err := rs.Stat(bs("address"))
+	require.NoError(t, err)
+	assert.True(t, statInfo.Exists)
+
+	data, err := rs.Get(bs("address"))
+	require.NoError(t, err)
+	assert.Equal(t, bs("data"), data)
+
+	// The missing copies before and after the second replica should have been repaired from it
+	rs.repairs.Wait()
+	for _, replica := range []NamedStore{first, third} {
+		data, err = replica.Get(bs("address"))
+		require.NoError(t, err)
+		assert.Equal(t, bs("data"), data)
+	}
+}
+
+type brokenStore struct{}
+
+func (brokenStore) Get(address []byte) ([]byte, error)       { return nil, fmt.Errorf("broken") }
+func (brokenStore) Stat(address []byte) (*StatInfo, error)   { return nil, fmt.Errorf("broken") }
+func (brokenStore) Put(address, data []byte) ([]byte, error) { return nil, fmt.Errorf("broken") }
+func (brokenStore) Delete(address []byte) error              { return fmt.Errorf("broken") }
+func (brokenStore) Location(address []byte) string           { return "broken://" }
+func (brokenStore) Name() string                             { return "brokenStore" }
+
+// Counts the pages listed from a memory store
+type listCountingStore struct {
+	*memoryStore
+	lists int
+}
+
+func (ls *listCountingStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	ls.lists++
+	return ls.memoryStore.List(prefix, after, limit)
+}
+
+func TestReplicatingStoreList(t *testing.T) {
+	first := &listCountingStore{memoryStore: NewMemoryStore()}
+	second := &listCountingStore{memoryStore: NewMemoryStore()}
+	rs, err := NewReplicatingStore(1, nil, first, second)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = first.Put(bs(fmt.Sprintf("address-%d", i)), bs("data"))
+		require.NoError(t, err)
+		_, err = second.Put(bs(fmt.Sprintf("address-%d", i+5)), bs("data"))
+		require.NoError(t, err)
+	}
+
+	var listed [][]byte
+	var after []byte
+	for {
+		page, err := rs.List(nil, after, 3)
+		require.NoError(t, err)
+		listed = append(listed, page...)
+		if len(page) < 3 {
+			break
+		}
+		after = page[len(page)-1]
+	}
+	assert.Len(t, listed, 15)
+	// Each replica is only listed for the first page
+	assert.Equal(t, 1, first.lists)
+	assert.Equal(t, 1, second.lists)
+
+	// A listing from anywhere else lists the replicas again
+	page, err := rs.List(nil, bs("address-5"), 3)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{bs("address-6"), bs("address-7"), bs("address-8")}, page)
+	assert.Equal(t, 2, first.lists)
+}
@@ -0,0 +1,58 @@
+package stores
+
+import (
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ErrorStoreNotFound(name string) error {
+	return status.Errorf(codes.NotFound, "No store configured with name '%s'", name)
+}
+
+// RoutingStore selects between a default store and a number of named stores. Used directly as a
+// ContentAddressedStore it acts as the default store.
+type RoutingStore struct {
+	ContentAddressedStore
+	routes map[string]ContentAddressedStore
+}
+
+var _ ContentAddressedStore = (*RoutingStore)(nil)
+
+func NewRoutingStore(defaultStore ContentAddressedStore, routes map[string]ContentAddressedStore) *RoutingStore {
+	if routes == nil {
+		routes = make(map[string]ContentAddressedStore)
+	}
+	return &RoutingStore{
+		ContentAddressedStore: defaultStore,
+		routes:                routes,
+	}
+}
+
+// Route returns the store registered under name, an empty name selects the default store
+func (rs *RoutingStore) Route(name string) (ContentAddressedStore, error) {
+	if name == "" {
+		return rs.ContentAddressedStore, nil
+	}
+	store, ok := rs.routes[name]
+	if !ok {
+		return nil, ErrorStoreNotFound(name)
+	}
+	return store, nil
+}
+
+// Names of the routable stores (excluding the default store) in lexical order
+func (rs *RoutingStore) Names() []string {
+	names := make([]string, 0, len(rs.routes))
+	for name := range rs.routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List the addresses in the default store
+func (rs *RoutingStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return List(rs.ContentAddressedStore, prefix, after, limit)
+}
@@ -0,0 +1,68 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/monax/hoard/v8/encryption"
+	"google.golang.org/grpc/status"
+)
+
+// A problem found with a blob by Scrub
+type ScrubReport struct {
+	Address  []byte
+	Location string
+	// One of: unreadable, truncated, or corrupted
+	Problem string
+	Error   string
+	// The number of bytes read from the store
+	Size int64 `json:",omitempty"`
+	// The number of bytes the refs to the blob imply it should have, if known
+	ExpectedSize int64 `json:",omitempty"`
+}
+
+// Scrub reads every blob in store (which must support listing) and checks it against its address using addresser,
+// calling report for each blob that cannot be read or does not match its address. A blob that does not match is
+// reported as truncated if it is shorter than its size in expectedSizes (which may be nil) or shorter than any
+// ciphertext can be, and as corrupted otherwise. It returns the number of blobs checked.
+func Scrub(store Store, addresser func(data []byte) []byte, expectedSizes map[string]int64,
+	report func(*ScrubReport) error) (int, error) {
+	checked := 0
+	err := ListAll(store, nil, nil, func(address []byte) error {
+		checked++
+		problem := scrubBlob(store, addresser, expectedSizes, address)
+		if problem == nil {
+			return nil
+		}
+		problem.Address = address
+		problem.Location = store.Location(address)
+		return report(problem)
+	})
+	return checked, err
+}
+
+func scrubBlob(store Store, addresser func(data []byte) []byte, expectedSizes map[string]int64,
+	address []byte) *ScrubReport {
+	data, err := store.Get(address)
+	if err != nil {
+		if IsIntegrityError(err) {
+			return &ScrubReport{Problem: "corrupted", Error: status.Convert(err).Message()}
+		}
+		return &ScrubReport{Problem: "unreadable", Error: err.Error()}
+	}
+	if bytes.Equal(addresser(data), address) {
+		return nil
+	}
+	size := int64(len(data))
+	expected, ok := expectedSizes[string(address)]
+	switch {
+	case ok && size < expected:
+		return &ScrubReport{Problem: "truncated", Size: size, ExpectedSize: expected,
+			Error: fmt.Sprintf("read %d bytes but its refs imply %d", size, expected)}
+	case size < encryption.TagSize:
+		return &ScrubReport{Problem: "truncated", Size: size, ExpectedSize: expected,
+			Error: fmt.Sprintf("read %d bytes which is shorter than any ciphertext", size)}
+	}
+	return &ScrubReport{Problem: "corrupted", Size: size, ExpectedSize: expected,
+		Error: "data does not match its address"}
+}
@@ -0,0 +1,52 @@
+package stores
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrub(t *testing.T) {
+	addresser := MakeAddresser(sha256.New)
+	store := NewMemoryStore()
+	cas := NewContentAddressedStore(addresser, store)
+
+	blob := func(name string) []byte {
+		return bs(name, " blob long enough to be ciphertext")
+	}
+	put := func(name string, stored []byte) []byte {
+		address, err := cas.Put(blob(name))
+		require.NoError(t, err)
+		if stored != nil {
+			_, err = store.Put(address, stored)
+			require.NoError(t, err)
+		}
+		return address
+	}
+
+	put("good", nil)
+	corrupted := put("corrupted", blob("c0rrupted"))
+	truncated := put("truncated", blob("truncated")[:20])
+	// Without an expected size a blob that is not too short to be ciphertext is just corrupted
+	unknown := put("unknown", blob("unknown")[:20])
+	empty := put("empty", []byte{})
+
+	problems := make(map[string]*ScrubReport)
+	expectedSizes := map[string]int64{string(truncated): int64(len(blob("truncated")))}
+	checked, err := Scrub(store, addresser, expectedSizes, func(report *ScrubReport) error {
+		assert.Equal(t, store.Location(report.Address), report.Location)
+		problems[string(report.Address)] = report
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, checked)
+	require.Len(t, problems, 4)
+	assert.Equal(t, "corrupted", problems[string(corrupted)].Problem)
+	assert.Equal(t, "truncated", problems[string(truncated)].Problem)
+	assert.Equal(t, int64(20), problems[string(truncated)].Size)
+	assert.Equal(t, int64(len(blob("truncated"))), problems[string(truncated)].ExpectedSize)
+	assert.Equal(t, "corrupted", problems[string(unknown)].Problem)
+	assert.Equal(t, "truncated", problems[string(empty)].Problem)
+}
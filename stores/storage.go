@@ -3,7 +3,9 @@
 package stores
 
 import (
+	"bytes"
 	"encoding/base64"
+	"io"
 
 	"hash"
 
@@ -58,6 +60,8 @@ type ContentAddressedStore interface {
 	Address(data []byte) (address []byte)
 }
 
+var _ StreamingReadStore = (*contentAddressedStore)(nil)
+
 type contentAddressedStore struct {
 	// The addresser that derives an address from some data deterministically.
 	// Generally we would expect addresser to be a good (enough) hash function for
@@ -80,6 +84,8 @@ func (cas *contentAddressedStore) Address(data []byte) []byte {
 	return cas.addresser(data)
 }
 
+// Put writes data through the underlying store's StreamingWriteStore implementation if it has one, so stores that can
+// write atomically (such as the filesystem store) never expose a partially written blob
 func (cas *contentAddressedStore) Put(data []byte) ([]byte, error) {
 	address := cas.addresser(data)
 	info, err := cas.Stat(address)
@@ -88,7 +94,7 @@ func (cas *contentAddressedStore) Put(data []byte) ([]byte, error) {
 	} else if info.Exists {
 		return address, nil
 	}
-	return cas.store.Put(address, data)
+	return PutFrom(cas.store, address, bytes.NewReader(data))
 }
 
 func (cas *contentAddressedStore) Delete(address []byte) error {
@@ -99,6 +105,14 @@ func (cas *contentAddressedStore) Get(address []byte) ([]byte, error) {
 	return cas.store.Get(address)
 }
 
+func (cas *contentAddressedStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	return GetReader(cas.store, address, offset, length)
+}
+
+func (cas *contentAddressedStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return List(cas.store, prefix, after, limit)
+}
+
 func (cas *contentAddressedStore) Stat(address []byte) (*StatInfo, error) {
 	return cas.store.Stat(address)
 }
@@ -0,0 +1,38 @@
+package stores
+
+import (
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Counts the writers opened on a memory store
+type countingStore struct {
+	*memoryStore
+	writers int
+}
+
+func (cs *countingStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	cs.writers++
+	return cs.memoryStore.PutWriter(address)
+}
+
+func TestContentAddressedStorePutStreams(t *testing.T) {
+	store := &countingStore{memoryStore: NewMemoryStore()}
+	cas := NewContentAddressedStore(MakeAddresser(sha256.New), store)
+
+	address, err := cas.Put([]byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.writers)
+	data, err := cas.Get(address)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	// Data already stored is not written again
+	_, err = cas.Put([]byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.writers)
+}
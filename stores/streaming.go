@@ -0,0 +1,132 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// StreamingReadStore is optionally implemented by stores that can read data without buffering it all in memory
+type StreamingReadStore interface {
+	// Open a reader over length bytes of the data stored at address starting from offset. A negative length reads
+	// to the end of the data.
+	GetReader(address []byte, offset, length int64) (io.ReadCloser, error)
+}
+
+// StreamingWriteStore is optionally implemented by stores that can write data without buffering it all in memory
+type StreamingWriteStore interface {
+	// Open a writer that stores data at address. The data is only guaranteed to be stored once the writer has been
+	// closed without error.
+	PutWriter(address []byte) (io.WriteCloser, error)
+}
+
+type StreamingStore interface {
+	Store
+	StreamingReadStore
+	StreamingWriteStore
+}
+
+// GetReader reads from store using its StreamingReadStore implementation if it has one, otherwise by slicing the
+// result of Get
+func GetReader(store ReadStore, address []byte, offset, length int64) (io.ReadCloser, error) {
+	if srs, ok := store.(StreamingReadStore); ok {
+		return srs.GetReader(address, offset, length)
+	}
+	data, err := store.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	data, err = sliceRange(data, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// PutFrom writes the contents of reader to store using its StreamingWriteStore implementation if it has one,
+// otherwise by reading it all and calling Put
+func PutFrom(store WriteStore, address []byte, reader io.Reader) ([]byte, error) {
+	if sws, ok := store.(StreamingWriteStore); ok {
+		writer, err := sws.PutWriter(address)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(writer, reader)
+		if err != nil {
+			writer.Close()
+			return nil, err
+		}
+		return address, writer.Close()
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return store.Put(address, data)
+}
+
+// Opens a writer on store using its StreamingWriteStore implementation if it has one, otherwise buffering writes
+// until the writer is closed
+func putWriter(store WriteStore, address []byte) (io.WriteCloser, error) {
+	if sws, ok := store.(StreamingWriteStore); ok {
+		return sws.PutWriter(address)
+	}
+	return &bufferWriter{
+		onClose: func(data []byte) error {
+			_, err := store.Put(address, data)
+			return err
+		},
+	}, nil
+}
+
+// Validates offset and length against the size of some data, returning the length to read (which may be shorter
+// than requested if the data ends first)
+func rangeLength(size, offset, length int64) (int64, error) {
+	if offset < 0 || offset > size {
+		return 0, fmt.Errorf("offset %d is out of range for data of size %d", offset, size)
+	}
+	if length < 0 || offset+length > size {
+		return size - offset, nil
+	}
+	return length, nil
+}
+
+func sliceRange(data []byte, offset, length int64) ([]byte, error) {
+	length, err := rangeLength(int64(len(data)), offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return data[offset : offset+length], nil
+}
+
+// Calls onClose with the accumulated bytes once closed
+type bufferWriter struct {
+	bytes.Buffer
+	onClose func(data []byte) error
+}
+
+func (bw *bufferWriter) Close() error {
+	return bw.onClose(bw.Bytes())
+}
+
+// Calls onClose after the underlying ReadCloser is closed
+type closeHook struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (ch *closeHook) Close() error {
+	defer ch.onClose()
+	return ch.ReadCloser.Close()
+}
+
+// Calls onClose after the underlying WriteCloser is closed
+type writeCloseHook struct {
+	io.WriteCloser
+	onClose func(err error) error
+}
+
+func (wch *writeCloseHook) Close() error {
+	return wch.onClose(wch.WriteCloser.Close())
+}
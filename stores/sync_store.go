@@ -2,6 +2,7 @@ package stores
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/monax/hoard/v8/sync"
 )
@@ -30,7 +31,7 @@ func NewSyncStore(store NamedStore) *syncStore {
 	}
 }
 
-var _ Store = (*syncStore)(nil)
+var _ StreamingStore = (*syncStore)(nil)
 
 func (inv *syncStore) Put(address []byte, data []byte) ([]byte, error) {
 	inv.mtx.Lock(address)
@@ -57,6 +58,41 @@ func (inv *syncStore) Stat(address []byte) (*StatInfo, error) {
 
 }
 
+// The read lock on address is held until the reader is closed
+func (inv *syncStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	inv.mtx.RLock(address)
+	reader, err := GetReader(inv.store, address, offset, length)
+	if err != nil {
+		inv.mtx.RUnlock(address)
+		return nil, err
+	}
+	return &closeHook{
+		ReadCloser: reader,
+		onClose:    func() { inv.mtx.RUnlock(address) },
+	}, nil
+}
+
+// The write lock on address is held until the writer is closed
+func (inv *syncStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	inv.mtx.Lock(address)
+	writer, err := putWriter(inv.store, address)
+	if err != nil {
+		inv.mtx.Unlock(address)
+		return nil, err
+	}
+	return &writeCloseHook{
+		WriteCloser: writer,
+		onClose: func(err error) error {
+			inv.mtx.Unlock(address)
+			return err
+		},
+	}, nil
+}
+
+func (inv *syncStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return List(inv.store, prefix, after, limit)
+}
+
 func (inv *syncStore) Location(address []byte) string {
 	return inv.store.Location(address)
 }
@@ -0,0 +1,11 @@
+package stores
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestSyncStore(t *testing.T) {
+	RunTests(t, NewSyncStore(NewLoggingStore(NewMemoryStore(), log.NewNopLogger())))
+}
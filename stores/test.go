@@ -1,6 +1,8 @@
 package stores
 
 import (
+	"bytes"
+	"io/ioutil"
 	"testing"
 
 	"crypto/sha256"
@@ -37,9 +39,80 @@ func RunTests(t *testing.T, store Store) {
 	// Has a '/' under standard encoding
 	getPutGet(t, store, []byte{0, 0, 63, 0, 0}, bs("bar-data"))
 
+	testStreaming(t, store)
+
+	testList(t, store)
+
 	testConcurrentContentAddressedStore(t, store)
 }
 
+func testStreaming(t *testing.T, store Store) {
+	address := bs("streaming-address")
+	data := bs("streaming-data")
+
+	_, err := PutFrom(store, address, bytes.NewReader(data))
+	assert.NoError(t, err, "Should be able to stream data to address")
+
+	retrieved, err := store.Get(address)
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+
+	assertRange := func(offset, length int64, expected []byte) {
+		reader, err := GetReader(store, address, offset, length)
+		if assert.NoError(t, err, "Should be able to read range [%d, %d)", offset, offset+length) {
+			retrieved, err := ioutil.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.NoError(t, reader.Close())
+			assert.Equal(t, expected, retrieved)
+		}
+	}
+	assertRange(0, -1, data)
+	assertRange(0, 9, bs("streaming"))
+	assertRange(10, -1, bs("data"))
+	assertRange(10, 100, bs("data"))
+
+	_, err = GetReader(store, bs("streaming-missing"), 0, -1)
+	assert.Error(t, err)
+}
+
+func testList(t *testing.T, store Store) {
+	if _, ok := store.(Lister); !ok {
+		return
+	}
+	listed := make(map[string]bool)
+	err := ListAll(store, nil, nil, func(address []byte) error {
+		listed[string(address)] = true
+		return nil
+	})
+	assert.NoError(t, err, "Should be able to list addresses")
+	assert.True(t, listed["address"], "Should list address")
+	assert.True(t, listed[string([]byte{0, 0, 63, 0, 0})], "Should list address with '/' under standard encoding")
+	assert.True(t, listed["streaming-address"], "Should list streamed address")
+	assert.False(t, listed["bar"], "Should not list address with no data")
+
+	// Page through addresses with a common prefix one at a time
+	for _, suffix := range []string{"c", "a", "b"} {
+		_, err = store.Put(bs("list-", suffix), bs("data"))
+		assert.NoError(t, err)
+	}
+	var paged []string
+	var after []byte
+	for len(paged) <= 3 {
+		addresses, err := List(store, bs("list-"), after, 1)
+		if !assert.NoError(t, err) || len(addresses) == 0 {
+			break
+		}
+		assert.Len(t, addresses, 1)
+		paged = append(paged, string(addresses[0]))
+		after = addresses[0]
+	}
+	assert.ElementsMatch(t, []string{"list-a", "list-b", "list-c"}, paged)
+
+	addresses, err := List(store, bs("list-"), nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 3, "A limit of 0 should list all addresses")
+}
+
 func testConcurrentContentAddressedStore(t *testing.T, store Store) {
 	cas := NewContentAddressedStore(MakeAddresser(sha256.New), store)
 	wg := new(sync.WaitGroup)
@@ -0,0 +1,103 @@
+package stores
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorIntegrity is returned when the data read from a store does not match the address it was stored at
+func ErrorIntegrity(address []byte, location string) error {
+	return status.Errorf(codes.DataLoss, "Data stored at address %s (location %s) does not match its address",
+		formatAddress(address), location)
+}
+
+// IsIntegrityError returns true if err indicates that data read from a store does not match its address
+func IsIntegrityError(err error) bool {
+	return status.Code(err) == codes.DataLoss
+}
+
+type verifyingStore struct {
+	NamedStore
+	hashProvider func() hash.Hash
+}
+
+// Decorates a Store so that data read from it is hashed and checked against the address it was read from, returning
+// ErrorIntegrity if they do not match. Partial reads with GetReader cannot be checked so are returned unverified.
+func NewVerifyingStore(hashProvider func() hash.Hash, store NamedStore) *verifyingStore {
+	return &verifyingStore{
+		NamedStore:   store,
+		hashProvider: hashProvider,
+	}
+}
+
+var _ StreamingStore = (*verifyingStore)(nil)
+var _ Lister = (*verifyingStore)(nil)
+
+func (inv *verifyingStore) Get(address []byte) ([]byte, error) {
+	data, err := inv.NamedStore.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	hasher := inv.hashProvider()
+	hasher.Write(data)
+	if !bytes.Equal(hasher.Sum(nil), address) {
+		return nil, ErrorIntegrity(address, inv.Location(address))
+	}
+	return data, nil
+}
+
+func (inv *verifyingStore) GetReader(address []byte, offset, length int64) (io.ReadCloser, error) {
+	reader, err := GetReader(inv.NamedStore, address, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	if offset != 0 || length >= 0 {
+		return reader, nil
+	}
+	return &verifyingReader{
+		ReadCloser: reader,
+		hasher:     inv.hashProvider(),
+		verify: func(digest []byte) error {
+			if !bytes.Equal(digest, address) {
+				return ErrorIntegrity(address, inv.Location(address))
+			}
+			return nil
+		},
+	}, nil
+}
+
+func (inv *verifyingStore) PutWriter(address []byte) (io.WriteCloser, error) {
+	return putWriter(inv.NamedStore, address)
+}
+
+func (inv *verifyingStore) List(prefix, after []byte, limit int) ([][]byte, error) {
+	return List(inv.NamedStore, prefix, after, limit)
+}
+
+func (inv *verifyingStore) Name() string {
+	return fmt.Sprintf("verifyingStore(%s)", inv.NamedStore.Name())
+}
+
+// Hashes everything read and checks the digest once the underlying reader is exhausted
+type verifyingReader struct {
+	io.ReadCloser
+	hasher hash.Hash
+	verify func(digest []byte) error
+}
+
+func (vr *verifyingReader) Read(p []byte) (int, error) {
+	n, err := vr.ReadCloser.Read(p)
+	vr.hasher.Write(p[:n])
+	if err == io.EOF {
+		verr := vr.verify(vr.hasher.Sum(nil))
+		if verr != nil {
+			return n, verr
+		}
+	}
+	return n, err
+}
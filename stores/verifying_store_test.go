@@ -0,0 +1,40 @@
+package stores
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyingStore(t *testing.T) {
+	memoryStore := NewMemoryStore()
+	cas := NewContentAddressedStore(MakeAddresser(sha256.New), NewVerifyingStore(sha256.New, memoryStore))
+
+	address, err := cas.Put(bs("data"))
+	require.NoError(t, err)
+	data, err := cas.Get(address)
+	require.NoError(t, err)
+	assert.Equal(t, bs("data"), data)
+
+	// Tamper with the data behind the verifying store's back
+	_, err = memoryStore.Put(address, bs("dat4"))
+	require.NoError(t, err)
+
+	_, err = cas.Get(address)
+	assert.True(t, IsIntegrityError(err), "Get should return an integrity error")
+
+	reader, err := GetReader(cas, address, 0, -1)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	assert.True(t, IsIntegrityError(err), "Reading all the data should return an integrity error")
+
+	// Partial reads cannot be verified
+	reader, err = GetReader(cas, address, 1, 2)
+	require.NoError(t, err)
+	data, err = ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, bs("at"), data)
+}
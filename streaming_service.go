@@ -1,18 +1,23 @@
 package hoard
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/monax/hoard/v8/encryption"
 
 	"github.com/monax/hoard/v8/protodet"
 	"github.com/monax/hoard/v8/versions"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/monax/hoard/v8/api"
 	"github.com/monax/hoard/v8/grant"
 	"github.com/monax/hoard/v8/reference"
 	"github.com/monax/hoard/v8/stores"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // StreamingService provides the API implementation for Service without relying directly on the
@@ -20,6 +25,29 @@ import (
 type StreamingService struct {
 	grantService GrantService
 	chunkSize    int64
+	prefetch     int
+	workers      int
+	chunker      api.Chunker
+	reportUpload func(stats *UploadStats)
+}
+
+// UploadStats describes the chunks (including any header) stored by a single Put or PutSeal
+type UploadStats struct {
+	// The number of chunks stored and the size of their plaintext
+	Chunks int64
+	Bytes  int64
+	// The number of those chunks (and the size of their plaintext) that were already stored so were deduplicated
+	DuplicateChunks int64
+	DuplicateBytes  int64
+}
+
+func (stats *UploadStats) add(size int, duplicate bool) {
+	atomic.AddInt64(&stats.Chunks, 1)
+	atomic.AddInt64(&stats.Bytes, int64(size))
+	if duplicate {
+		atomic.AddInt64(&stats.DuplicateChunks, 1)
+		atomic.AddInt64(&stats.DuplicateBytes, int64(size))
+	}
 }
 
 // Create a streaming service that will re-buffer any plaintext data in blocks of chunkSize. linker is a
@@ -32,6 +60,40 @@ func NewStreamingService(grantService GrantService, chunkSize int64) *StreamingS
 	}
 }
 
+// WithPrefetch sets the number of chunks that are fetched and decrypted concurrently when following a LINK ref, their
+// plaintext is still sent in order. By default each chunk is only fetched once the previous chunk has been sent.
+func (service *StreamingService) WithPrefetch(window int) *StreamingService {
+	service.prefetch = window
+	return service
+}
+
+// WithEncryptWorkers sets the number of chunks that are encrypted (and stored by Put and PutSeal) concurrently, their
+// references are still produced in order. Plaintext is not received while every worker is busy. By default each chunk
+// is encrypted before the next is received.
+func (service *StreamingService) WithEncryptWorkers(workers int) *StreamingService {
+	service.workers = workers
+	return service
+}
+
+// WithChunker sets how plaintext is split into chunks when its header does not select a chunker. By default chunks
+// are cut every chunkSize bytes.
+func (service *StreamingService) WithChunker(chunker api.Chunker) *StreamingService {
+	service.chunker = chunker
+	return service
+}
+
+// WithUploadStats passes report the UploadStats of each successful Put and PutSeal. Counting duplicates requires
+// checking whether each chunk is already stored before putting it.
+func (service *StreamingService) WithUploadStats(report func(stats *UploadStats)) *StreamingService {
+	service.reportUpload = report
+	return service
+}
+
+// Fetches chunks for decoding within the prefetch window
+func (service *StreamingService) fetch(refs []*reference.Ref, receive func(ref *reference.Ref, data []byte) error) error {
+	return prefetcher(service.prefetch, service.grantService.Get)(refs, receive)
+}
+
 func (service *StreamingService) PutSeal(sendAndClose func(*grant.Grant) error, recv func() (*api.PlaintextAndGrantSpec, error)) error {
 	first, err := recv()
 	if err != nil {
@@ -48,10 +110,23 @@ func (service *StreamingService) PutSeal(sendAndClose func(*grant.Grant) error,
 
 	head := first.GetPlaintext().GetHead()
 
+	storeName, err := selectStore(spec.GetStore(), head.GetStore())
+	if err != nil {
+		return err
+	}
+	stats := new(UploadStats)
+	put := service.putTo(storeName, stats)
+
 	var refs []*reference.Ref
 
-	err = encrypt(first.GetPlaintext(), service.put,
+	// Header metadata may be sent as a trailer after the body, so the header is sent last and normalised to the front
+	// of the refs array where it would be had it been sent first
+	err = encrypt(first.GetPlaintext(), put,
 		func(ref *reference.Ref, encryptedData []byte) error {
+			if ref.Type == reference.Ref_HEADER {
+				refs = append([]*reference.Ref{ref}, refs...)
+				return nil
+			}
 			refs = append(refs, ref)
 			return nil
 		},
@@ -61,17 +136,15 @@ func (service *StreamingService) PutSeal(sendAndClose func(*grant.Grant) error,
 				return nil, err
 			}
 			return ptgs.GetPlaintext(), nil
-		}, service.chunkSize)
-
-	// TODO: it would be useful to be able to send Header.Data (i.e. metadata) as a trailer, this could then be
-	//   normalised to the front of the refs array in the object pointed to by a LINK ref. This would allow things like
-	//   file size, detected mime type, etc to be provided at the end of (or during) a stream. If a salt/chunksize is used
-	//   we do  need that at the beginning of the stream though. The decision to explicitly only support first-message headers
-	//   was to avoid unintended behaviour from multiple headers appearing in the stream. Another option is to provide another call
-	//   that take a grant without a header and adds a header by creating a copy of the link ref with a header added.
+		}, service.chunkSize, service.workers, service.chunker, true)
+	if err != nil {
+		return err
+	}
 
 	// Convert base refs into link ref(s) (usually a single unique link ref to allow for safe deletion of links)
-	refs, err = link(refs, head.GetSalt(), spec.LinkNonce, service.grantService.Put)
+	refs, err = link(refs, head.GetSalt(), spec.LinkNonce, func(data, salt []byte) (*reference.Ref, error) {
+		return service.grantService.PutTo(storeName, data, salt)
+	})
 	if err != nil {
 		return fmt.Errorf("could not link refs: %w", err)
 	}
@@ -81,6 +154,7 @@ func (service *StreamingService) PutSeal(sendAndClose func(*grant.Grant) error,
 	if err != nil {
 		return err
 	}
+	service.report(stats)
 
 	return sendAndClose(grt)
 }
@@ -92,37 +166,52 @@ func (service *StreamingService) UnsealGet(grt *grant.Grant, send func(*api.Plai
 		return err
 	}
 
-	for _, ref := range refs {
-		data, err := service.grantService.Get(ref)
-		if err != nil {
-			return err
-		}
-
-		err = decode(data, ref.GetType(), service.grantService.Get, send, versions.LatestGrantVersion)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return service.fetch(refs, func(ref *reference.Ref, data []byte) error {
+		return decode(data, ref.GetType(), service.fetch, send, versions.LatestGrantVersion)
+	})
 }
 
-// UnsealDelete gets the references stored in a grant and deletes them
-func (service *StreamingService) UnsealDelete(grt *grant.Grant, send func(address *api.Address) error) error {
-	refs, err := service.grantService.Unseal(grt)
+// UnsealGetRange decrypts and gets the plaintext body associated with a grant from offset for length bytes (or to the
+// end if length is zero) along with any header. BODY chunks outside of the range are skipped using the plaintext size
+// recorded in their references so that they are never fetched.
+func (service *StreamingService) UnsealGetRange(req *api.UnsealGetRangeRequest, send func(*api.Plaintext) error) error {
+	if req.GetOffset() < 0 || req.GetLength() < 0 {
+		return status.Errorf(codes.InvalidArgument, "offset and length must not be negative but got offset %d "+
+			"and length %d", req.GetOffset(), req.GetLength())
+	}
+	refs, err := service.grantService.Unseal(req.GetGrant())
 	if err != nil {
 		return err
 	}
-
-	for _, ref := range refs {
-		err := service.grantService.Store().Delete(ref.Address)
-		if err != nil {
-			return err
-		}
-		if err = send(&api.Address{Address: ref.Address}); err != nil {
-			return err
-		}
+	rng := &plaintextRange{
+		offset:    req.GetOffset(),
+		remaining: req.GetLength(),
 	}
-	return nil
+	if rng.remaining == 0 {
+		rng.remaining = -1
+	}
+	err = decodeRange(refs, rng, service.fetch, send, versions.LatestGrantVersion)
+	if err == errRangeSent {
+		return nil
+	}
+	return err
+}
+
+// UnsealDelete gets the references stored in a grant and deletes them along with all the data they link to
+func (service *StreamingService) UnsealDelete(grt *grant.Grant, send func(address *api.Address) error) error {
+	return service.grantService.UnsealDelete(grt, func(ref *reference.Ref) error {
+		return send(&api.Address{Address: ref.Address, Store: ref.Store})
+	})
+}
+
+// UnsealDeleteRecursive gets the references stored in a grant and deletes them along with all the data they link to,
+// except for data reachable from the grants to keep
+func (service *StreamingService) UnsealDeleteRecursive(req *api.UnsealDeleteRequest,
+	send func(address *api.Address) error) error {
+	return service.grantService.UnsealDeleteRecursive(req.GetGrant(), req.GetKeep(), req.GetForce(),
+		func(ref *reference.Ref) error {
+			return send(&api.Address{Address: ref.Address, Store: ref.Store})
+		})
 }
 
 // Put encrypted data in the store
@@ -135,13 +224,16 @@ func (service *StreamingService) Put(send func(*reference.Ref) error, recv func(
 		return err
 	}
 
-	err = encrypt(first, service.put, func(ref *reference.Ref, _ []byte) error { return send(ref) },
-		recv, service.chunkSize)
+	stats := new(UploadStats)
+	err = encrypt(first, service.putTo(first.GetHead().GetStore(), stats),
+		func(ref *reference.Ref, _ []byte) error { return send(ref) },
+		recv, service.chunkSize, service.workers, service.chunker, false)
 
 	if err != nil {
 		return fmt.Errorf("Put: could not put plaintexts: %w", err)
 	}
 
+	service.report(stats)
 	return nil
 }
 
@@ -162,7 +254,7 @@ func (service *StreamingService) Get(send func(*api.Plaintext) error, recv func(
 			return err
 		}
 
-		err = decode(data, ref.GetType(), service.grantService.Get, send, versions.LatestGrantVersion)
+		err = decode(data, ref.GetType(), service.fetch, send, versions.LatestGrantVersion)
 		if err != nil {
 			return err
 		}
@@ -186,7 +278,7 @@ func (service *StreamingService) Encrypt(send func(*api.ReferenceAndCiphertext)
 				EncryptedData: encryptedData,
 			},
 		})
-	}, recv, service.chunkSize)
+	}, recv, service.chunkSize, service.workers, service.chunker, false)
 
 	if err != nil {
 		return fmt.Errorf("Could not encrypt data: %w", err)
@@ -211,7 +303,7 @@ func (service *StreamingService) Decrypt(send func(*api.Plaintext) error, recv f
 			return err
 		}
 
-		err = decode(data, refAndCiphertext.Reference.GetType(), service.grantService.Get, send, versions.LatestGrantVersion)
+		err = decode(data, refAndCiphertext.Reference.GetType(), service.fetch, send, versions.LatestGrantVersion)
 		if err != nil {
 			return err
 		}
@@ -255,7 +347,12 @@ func (service *StreamingService) Pull(send func(*api.Ciphertext) error, recv fun
 			return err
 		}
 
-		data, err := service.grantService.Store().Get(addr.Address)
+		store, err := service.grantService.Route(addr.Store)
+		if err != nil {
+			return err
+		}
+
+		data, err := store.Get(addr.Address)
 		if err != nil {
 			return err
 		}
@@ -335,46 +432,277 @@ func (service *StreamingService) Reseal(arg *api.GrantAndGrantSpec) (*grant.Gran
 	if err != nil {
 		return nil, err
 	}
-	// TODO: could provide a way to add Header metadata after the fact by re-linking some refs with header appended
-	//  something like: refs ,err = link(append([]*reference.Ref{headerRef}, delink(refs)...), salt, linkNonce, service.grantService.Put)
 	return service.grantService.Seal(refs, arg.GrantSpec)
 }
 
+// Annotate replaces the metadata in the header of the plaintext behind a grant (adding a header if it has none) and
+// links it with the grant's existing body chunks in a new grant sealed with the same spec. The body chunks are not
+// copied so they are shared with the original grant.
+func (service *StreamingService) Annotate(req *api.AnnotateRequest) (*grant.Grant, error) {
+	if req.GetHead() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "a header is required to annotate a grant")
+	}
+	err := checkMetadataOnly(req.Head)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := service.grantService.Unseal(req.GetGrant())
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot annotate a grant without references")
+	}
+	storeName := refs[0].Store
+	if len(refs) == 1 && refs[0].Type == reference.Ref_LINK {
+		data, err := service.grantService.Get(refs[0])
+		if err != nil {
+			return nil, err
+		}
+		refs, err = reference.RefsFromPlaintext(data, versions.LatestGrantVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	head := new(api.Header)
+	body := make([]*reference.Ref, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Type != reference.Ref_HEADER {
+			body = append(body, ref)
+			continue
+		}
+		data, err := service.grantService.Get(ref)
+		if err != nil {
+			return nil, err
+		}
+		err = protodet.Unmarshal(data, head)
+		if err != nil {
+			return nil, err
+		}
+	}
+	head.Data = req.Head.Data
+
+	data, err := protodet.Marshal(head)
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := service.grantService.PutTo(storeName, data, head.GetSalt())
+	if err != nil {
+		return nil, err
+	}
+	headRef.Type = reference.Ref_HEADER
+
+	spec := req.GetGrant().GetSpec()
+	refs, err = link(append([]*reference.Ref{headRef}, body...), head.GetSalt(), spec.GetLinkNonce(),
+		func(data, salt []byte) (*reference.Ref, error) {
+			return service.grantService.PutTo(storeName, data, salt)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("could not link refs: %w", err)
+	}
+	return service.grantService.Seal(refs, spec)
+}
+
+// StatGrant describes the plaintext behind a grant from the sizes recorded in its references, fetching only its header
+// and LINK refs, and checks that each of its chunks is stored. If a LINK ref is missing the chunks it links to cannot be
+// counted.
+func (service *StreamingService) StatGrant(grt *grant.Grant) (*api.GrantStat, error) {
+	refs, err := service.grantService.Unseal(grt)
+	if err != nil {
+		return nil, err
+	}
+	stat := &api.GrantStat{
+		Version:  grt.GetVersion(),
+		Complete: true,
+	}
+	return stat, service.statRefs(refs, stat)
+}
+
+func (service *StreamingService) statRefs(refs []*reference.Ref, stat *api.GrantStat) error {
+	for _, ref := range refs {
+		store, err := service.grantService.Route(ref.Store)
+		if err != nil {
+			return err
+		}
+		statInfo, err := store.Stat(ref.Address)
+		if err != nil {
+			return err
+		}
+		if !statInfo.Exists {
+			stat.Complete = false
+		}
+		switch ref.Type {
+		case reference.Ref_HEADER:
+			if !statInfo.Exists {
+				continue
+			}
+			data, err := service.grantService.Get(ref)
+			if err != nil {
+				return err
+			}
+			stat.Head = new(api.Header)
+			err = protodet.Unmarshal(data, stat.Head)
+			if err != nil {
+				return err
+			}
+
+		case reference.Ref_LINK:
+			if !statInfo.Exists {
+				continue
+			}
+			data, err := service.grantService.Get(ref)
+			if err != nil {
+				return err
+			}
+			linked, err := reference.RefsFromPlaintext(data, versions.LatestGrantVersion)
+			if err != nil {
+				return err
+			}
+			err = service.statRefs(linked, stat)
+			if err != nil {
+				return err
+			}
+
+		default:
+			stat.Chunks++
+			stat.Size_ += ref.GetSize_()
+		}
+	}
+	return nil
+}
+
 func (service *StreamingService) Stat(address *api.Address) (*stores.StatInfo, error) {
-	statInfo, err := service.grantService.Store().Stat(address.Address)
+	store, err := service.grantService.Route(address.Store)
+	if err != nil {
+		return nil, err
+	}
+	statInfo, err := store.Stat(address.Address)
 	if err != nil {
 		return nil, err
 	}
 	// provide the address and the canonical location
 	statInfo.Address = address.Address
-	statInfo.Location = service.grantService.Store().Location(address.Address)
+	statInfo.Location = store.Location(address.Address)
 	return statInfo, nil
 }
 
-func (service *StreamingService) Delete(address []byte) error {
-	return service.grantService.Store().Delete(address)
+func (service *StreamingService) Delete(address *api.Address) error {
+	store, err := service.grantService.Route(address.Store)
+	if err != nil {
+		return err
+	}
+	return store.Delete(address.Address)
+}
+
+// List the addresses held by a store, streaming all remaining addresses when no limit is given
+func (service *StreamingService) List(req *api.ListRequest, send func(*api.Address) error) error {
+	store, err := service.grantService.Route(req.Store)
+	if err != nil {
+		return err
+	}
+	sendAddress := func(address []byte) error {
+		return send(&api.Address{Address: address, Store: req.Store})
+	}
+	if req.Limit <= 0 {
+		return stores.ListAll(store, req.Prefix, req.After, sendAddress)
+	}
+	addresses, err := stores.List(store, req.Prefix, req.After, int(req.Limit))
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		err = sendAddress(address)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GarbageCollect sweeps a store of any data not reachable from the grants and references received, sending the
+// address of each unreachable blob. Options may only be provided in the first message.
+func (service *StreamingService) GarbageCollect(send func(*api.Address) error,
+	recv func() (*api.GarbageCollectRequest, error)) error {
+	var options *api.GarbageCollectOptions
+	var roots []*reference.Ref
+	for {
+		req, err := recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if req.GetOptions() != nil {
+			if options != nil || len(roots) > 0 {
+				return fmt.Errorf("garbage collection options may only be provided in the first message")
+			}
+			options = req.GetOptions()
+		}
+		if req.GetGrant() != nil {
+			refs, err := service.grantService.Unseal(req.GetGrant())
+			if err != nil {
+				return err
+			}
+			roots = append(roots, refs...)
+		}
+		if req.GetReference() != nil {
+			roots = append(roots, req.GetReference())
+		}
+	}
+
+	storeName := options.GetStore()
+	return CollectGarbage(service.grantService, storeName, roots, options.GetDryRun(), options.GetAll(),
+		func(address []byte) error {
+			return send(&api.Address{Address: address, Store: storeName})
+		})
 }
 
-// Put wrapped with dummy 'encrypt' signature to help with reuse
-func (service *StreamingService) put(data, salt []byte) (*reference.Ref, []byte, error) {
-	ref, err := service.grantService.Put(data, salt)
-	return ref, nil, err
+// Returns a function putting chunks in the named store, counting them in stats if their upload is being reported
+func (service *StreamingService) putTo(storeName string, stats *UploadStats) func(data, salt []byte) (*reference.Ref, []byte, error) {
+	return func(data, salt []byte) (*reference.Ref, []byte, error) {
+		if service.reportUpload == nil {
+			ref, err := service.grantService.PutTo(storeName, data, salt)
+			return ref, nil, err
+		}
+		ref, existed, err := service.grantService.PutNewTo(storeName, data, salt)
+		if err != nil {
+			return nil, nil, err
+		}
+		stats.add(len(data), existed)
+		return ref, nil, nil
+	}
 }
 
-// Abstracts the handling of incoming plaintexts that is common between Encrypt, Put, and PutSeal
+func (service *StreamingService) report(stats *UploadStats) {
+	if service.reportUpload != nil {
+		service.reportUpload(stats)
+	}
+}
+
+// Resolves the store named by a grant spec and the store named by a header, which must agree if both are given
+func selectStore(specStore, headStore string) (string, error) {
+	if specStore != "" && headStore != "" && specStore != headStore {
+		return "", fmt.Errorf("grant spec selects store '%s' but header selects store '%s'", specStore, headStore)
+	}
+	if specStore != "" {
+		return specStore, nil
+	}
+	return headStore, nil
+}
+
+// Abstracts the handling of incoming plaintexts that is common between Encrypt, Put, and PutSeal. Up to workers
+// chunks are encrypted concurrently but are always sent in order. The chunker is used unless the header selects one.
+// If trailing is set the header may be followed by a trailing header carrying metadata (Header.Data) after the body, in
+// which case the header is sent last with the trailing metadata.
 func encrypt(first *api.Plaintext,
 	encrypt func(data []byte, salt []byte) (ref *reference.Ref, encryptedData []byte, err error),
 	send func(ref *reference.Ref, encryptedData []byte) error,
 	recv func() (*api.Plaintext, error),
-	chunkSize int64) error {
+	chunkSize int64, workers int, chunker api.Chunker, trailing bool) error {
 
-	// Expect header to always be in first message if provided
-	head := first.GetHead()
-	if head != nil {
-		// Use chunkSize if supplied
-		if head.GetChunkSize() > 0 {
-			chunkSize = head.ChunkSize
-		}
+	sendHead := func(head *api.Header) error {
 		data, err := protodet.Marshal(head)
 		if err != nil {
 			return err
@@ -386,23 +714,52 @@ func encrypt(first *api.Plaintext,
 		}
 		ref.Type = reference.Ref_HEADER
 
-		err = send(ref, encryptedData)
-		if err != nil {
-			return err
+		return send(ref, encryptedData)
+	}
+
+	// Expect header to always be in first message if provided
+	head := first.GetHead()
+	if head != nil {
+		// Use chunkSize if supplied
+		if head.GetChunkSize() > 0 {
+			chunkSize = head.ChunkSize
+		}
+		if head.GetChunker() != api.Chunker_DEFAULT {
+			chunker = head.Chunker
+		}
+		if !trailing {
+			err := sendHead(head)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	// Truncate to max chunkSize
 	if chunkSize > MaxChunkSize {
 		chunkSize = MaxChunkSize
 	}
-	return CopyChunked(
-		func(chunk []byte) error {
-			ref, encryptedData, err := encrypt(chunk, head.GetSalt())
-			if err != nil {
-				return err
-			}
-			return send(ref, encryptedData)
-		},
+	copyChunked := CopyChunked
+	switch chunker {
+	case api.Chunker_CONTENT_DEFINED:
+		copyChunked = CopyContentDefined
+	case api.Chunker_DEFAULT, api.Chunker_FIXED:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown chunker %v", chunker)
+	}
+	push := func(chunk []byte) error {
+		ref, encryptedData, err := encrypt(chunk, head.GetSalt())
+		if err != nil {
+			return err
+		}
+		return send(ref, encryptedData)
+	}
+	var pipeline *encryptPipeline
+	if workers > 1 {
+		pipeline = newEncryptPipeline(workers, head.GetSalt(), encrypt, send)
+		push = pipeline.push
+	}
+	var trailer *api.Header
+	err := copyChunked(push,
 		func() ([]byte, error) {
 			// Consume any body that may have been in the first message
 			if first.GetBody() != nil {
@@ -414,18 +771,63 @@ func encrypt(first *api.Plaintext,
 			if err != nil {
 				return nil, err
 			}
+			if trailer != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "no plaintext may follow a trailing header")
+			}
+			if plaintext.GetHead() != nil {
+				trailer = plaintext.Head
+				return nil, checkTrailer(trailer, len(plaintext.Body), trailing)
+			}
 			return plaintext.Body, nil
 		},
 		chunkSize)
+	if pipeline != nil {
+		// Always wait for the workers so that nothing is sent after we return
+		closeErr := pipeline.close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil || !trailing || (head == nil && trailer == nil) {
+		return err
+	}
+	if head == nil {
+		head = new(api.Header)
+	}
+	if trailer != nil {
+		head = proto.Clone(head).(*api.Header)
+		head.Data = trailer.Data
+	}
+	return sendHead(head)
+}
+
+// Checks a header received after the body
+func checkTrailer(trailer *api.Header, bodyLength int, trailing bool) error {
+	if !trailing {
+		return status.Errorf(codes.InvalidArgument, "a header may only be sent in the first message")
+	}
+	if bodyLength > 0 {
+		return status.Errorf(codes.InvalidArgument, "a trailing header must not be sent with plaintext")
+	}
+	return checkMetadataOnly(trailer)
+}
+
+// Only the metadata of a header may be given once the body has been chunked (by a trailer or Annotate) since its
+// other fields determine how the body is chunked and stored
+func checkMetadataOnly(head *api.Header) error {
+	if len(head.Salt) > 0 || head.ChunkSize != 0 || head.Store != "" || head.Chunker != api.Chunker_DEFAULT {
+		return status.Errorf(codes.InvalidArgument, "only the Data of a header may be given after its body has "+
+			"been chunked, other fields must be sent with the body")
+	}
+	return nil
 }
 
 // Converts raw plaintext data to the wrapper type
 // In the case of a HEADER ref type the plaintext is deserialised using the header type
-// In the case of a LINK ref type the supplied get function is used to fetch additional plaintext data which are themselves each decoded
+// In the case of a LINK ref type the supplied fetch function is used to fetch additional plaintext data which are themselves each decoded
 // Otherwise the data is returned as MustPlaintextFromRefs.Body
 // The decoded plaintext(s) are then streamed as output via the supplied send function
-func decode(data []byte, refType reference.Ref_RefType,
-	get func(*reference.Ref) ([]byte, error),
+func decode(data []byte, refType reference.Ref_RefType, fetch fetchFunc,
 	send func(*api.Plaintext) error, version int32) error {
 
 	switch refType {
@@ -442,17 +844,9 @@ func decode(data []byte, refType reference.Ref_RefType,
 		if err != nil {
 			return err
 		}
-		for _, ref := range refs {
-			data, err := get(ref)
-			if err != nil {
-				return err
-			}
-			err = decode(data, ref.Type, get, send, version)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+		return fetch(refs, func(ref *reference.Ref, data []byte) error {
+			return decode(data, ref.Type, fetch, send, version)
+		})
 
 	default:
 		return send(&api.Plaintext{
@@ -461,6 +855,106 @@ func decode(data []byte, refType reference.Ref_RefType,
 	}
 }
 
+// Returned by decodeRange to stop fetching once the range has been sent
+var errRangeSent = errors.New("range sent")
+
+// The part of a plaintext body still to be sent by decodeRange, remaining is negative when unbounded
+type plaintextRange struct {
+	offset    int64
+	remaining int64
+}
+
+func (rng *plaintextRange) done() bool {
+	return rng.remaining == 0
+}
+
+// Skips a chunk of size bytes that lies entirely before the range returning true, or returns false if the chunk
+// overlaps the range
+func (rng *plaintextRange) skip(size int64) bool {
+	if size > rng.offset {
+		return false
+	}
+	rng.offset -= size
+	return true
+}
+
+// Advances the range past a chunk of size bytes returning the bounds of the part of the chunk within the range
+func (rng *plaintextRange) advance(size int64) (start, end int64) {
+	if rng.skip(size) {
+		return 0, 0
+	}
+	start, end = rng.offset, size
+	rng.offset = 0
+	if rng.remaining >= 0 && end-start > rng.remaining {
+		end = start + rng.remaining
+	}
+	if rng.remaining > 0 {
+		rng.remaining -= end - start
+	}
+	return start, end
+}
+
+// Returns the part of body in the range and advances the range past body
+func (rng *plaintextRange) take(body []byte) []byte {
+	start, end := rng.advance(int64(len(body)))
+	return body[start:end]
+}
+
+// Selects the refs that must be fetched to decode rng from refs, skipping rng past the BODY refs before it. BODY refs
+// recording a size are only selected if they overlap the range, but once a ref of unknown body size (a LINK or a BODY
+// ref from an older client) is reached every remaining ref is selected. Headers are always selected.
+func rangeRefs(refs []*reference.Ref, rng *plaintextRange) []*reference.Ref {
+	// Only refs before the start of the range are skipped so those selected are decoded from where rng is left
+	sent := *rng
+	var selected []*reference.Ref
+	for i, ref := range refs {
+		if sent.done() {
+			break
+		}
+		switch {
+		case ref.GetType() == reference.Ref_HEADER:
+		case ref.GetType() == reference.Ref_BODY && ref.GetSize_() > 0:
+			if sent.skip(ref.GetSize_()) {
+				rng.skip(ref.GetSize_())
+				continue
+			}
+			sent.advance(ref.GetSize_())
+		default:
+			return append(selected, refs[i:]...)
+		}
+		selected = append(selected, ref)
+	}
+	return selected
+}
+
+// Like decode but only sends the part of the body within rng, fetching only the refs selected by rangeRefs. Returns
+// errRangeSent once the range has been sent. Headers are always sent.
+func decodeRange(refs []*reference.Ref, rng *plaintextRange, fetch fetchFunc, send func(*api.Plaintext) error,
+	version int32) error {
+
+	return fetch(rangeRefs(refs, rng), func(ref *reference.Ref, data []byte) error {
+		if rng.done() {
+			return errRangeSent
+		}
+		switch ref.GetType() {
+		case reference.Ref_HEADER:
+			return decode(data, ref.GetType(), fetch, send, version)
+		case reference.Ref_LINK:
+			linked, err := reference.RefsFromPlaintext(data, version)
+			if err != nil {
+				return err
+			}
+			return decodeRange(linked, rng, fetch, send, version)
+		default:
+			body := rng.take(data)
+			if len(body) == 0 {
+				return nil
+			}
+			return send(&api.Plaintext{Body: body})
+		}
+	})
+}
+
 func link(refs []*reference.Ref, salt []byte, linkNonce []byte,
 	put func(data, salt []byte) (*reference.Ref, error)) ([]*reference.Ref, error) {
 	var err error
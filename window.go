@@ -0,0 +1,54 @@
+package hoard
+
+// Runs up to size tasks concurrently while delivering their results in the order the tasks were started. Start blocks
+// while the window is full so that tasks are only started as fast as their results can be delivered.
+type orderedWindow struct {
+	// Holds a channel for the result of each task in flight in order, one of which may be held by the deliverer so the
+	// buffer is one less than the size of the window
+	pending chan chan func() error
+	// Closed once every result has been delivered or the first error has been encountered
+	stopped chan struct{}
+	err     error
+}
+
+func newOrderedWindow(size int) *orderedWindow {
+	ow := &orderedWindow{
+		pending: make(chan chan func() error, size-1),
+		stopped: make(chan struct{}),
+	}
+	go ow.deliverAll()
+	return ow
+}
+
+func (ow *orderedWindow) deliverAll() {
+	defer close(ow.stopped)
+	for result := range ow.pending {
+		err := (<-result)()
+		if err != nil {
+			ow.err = err
+			return
+		}
+	}
+}
+
+// Runs task in its own goroutine, the function it returns is called to deliver its result once the results of every
+// task started before it have been delivered. Returns the first error encountered delivering a previous result.
+func (ow *orderedWindow) start(task func() (deliver func() error)) error {
+	result := make(chan func() error, 1)
+	select {
+	case ow.pending <- result:
+	case <-ow.stopped:
+		return ow.err
+	}
+	go func() {
+		result <- task()
+	}()
+	return nil
+}
+
+// Waits for the results of the tasks started to be delivered returning the first error encountered
+func (ow *orderedWindow) close() error {
+	close(ow.pending)
+	<-ow.stopped
+	return ow.err
+}